@@ -4,11 +4,18 @@ import (
 	"context"
 	"fmt"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/auth"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/config"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/events"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/logger"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/openapi"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/outbox"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/repository"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/scheduler"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/server"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/server/routes"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/storage"
+	"github.com/gin-gonic/gin"
 )
 
 func main() {
@@ -16,11 +23,14 @@ func main() {
 
 	// Чтение конфигурации
 	cfg := config.ReadConfig()
-	fmt.Println(cfg)
+	fmt.Println(cfg.Redacted())
 
 	// Настройка логгера
 	zlog := logger.SetupLogger(cfg.DebugFlag)
-	zlog.Debug().Any("config", cfg).Msg("Check cfg value")
+	zlog.Debug().Any("config", cfg.Redacted()).Msg("Check cfg value")
+	if err := cfg.Validate(); err != nil {
+		zlog.Fatal().Err(err).Msg("Invalid configuration")
+	}
 	zlog.Debug().Str("migration_path", cfg.MPath).Msg("Path to migrations")
 
 	// Формирование строки подключения к базе данных
@@ -41,11 +51,55 @@ func main() {
 		zlog.Fatal().Err(err).Msg("Unable to create database storage")
 	}
 
+	// Закрытие тендеров с истекшими сроками. Запускается в каждой реплике,
+	// проход выполняет та, что первой получила advisory-блокировку.
+	if cfg.SchedulerInterval > 0 {
+		go scheduler.New(dbStorage, cfg.SchedulerInterval, zlog).Run(context.Background())
+	}
+
+	// Доставка доменных событий из outbox. Реплики забирают разные события,
+	// поэтому relay запускается в каждой.
+	if len(cfg.OutboxSinks) > 0 {
+		sinks, err := outbox.ParseSinks(cfg.OutboxSinks)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Invalid outbox sinks")
+		}
+		go outbox.New(dbStorage, sinks, cfg.OutboxInterval, zlog).Run(context.Background())
+	}
+
+	// Выдача и проверка токенов
+	authManager := auth.NewManager(cfg.JWTSecret, cfg.TokenTTL)
+
+	// Хранилище содержимого вложений
+	blobs, err := storage.NewLocal(cfg.BlobDir)
+	if err != nil {
+		zlog.Fatal().Err(err).Msg("Unable to create blob storage")
+	}
+	limits := server.AttachmentLimits{MaxSize: cfg.AttachmentMaxSize, Types: cfg.AttachmentTypes}
+
+	// События активности из LISTEN/NOTIFY для потоков server-sent events
+	hub := events.NewHub()
+	go events.Listen(context.Background(), dsn, hub, zlog)
+
 	// Создание сервера
-	server := server.New(context.Background(), dbStorage, zlog)
+	server := server.New(context.Background(), dbStorage, authManager, blobs, limits, hub, zlog)
+
+	// Проверка запросов и ответов по спецификации OpenAPI
+	var middleware []gin.HandlerFunc
+	if cfg.OpenAPIValidate {
+		doc, err := openapi.Load(cfg.OpenAPISpec)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Unable to load OpenAPI spec")
+		}
+		validator, err := openapi.New(doc, *zlog)
+		if err != nil {
+			zlog.Fatal().Err(err).Msg("Unable to create OpenAPI validator")
+		}
+		middleware = append(middleware, validator.Middleware())
+	}
 
 	// Настройка маршрутов
-	r := routes.SetupRoutes(server)
+	r := routes.SetupRoutes(server, middleware...)
 
 	// Запуск сервера
 	zlog.Info().Msgf("Starting server on %s", cfg.Addr)
@@ -0,0 +1,118 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Категории ошибок предметной области. По категории выбирается HTTP-статус ответа.
+var (
+	ErrInvalid      = errors.New("invalid request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	// ErrPrecondition - версия из If-Match не совпала с текущей
+	ErrPrecondition = errors.New("precondition failed")
+	// ErrTooLarge и ErrUnsupported - размер и тип загружаемого файла вне ограничений
+	ErrTooLarge    = errors.New("payload too large")
+	ErrUnsupported = errors.New("unsupported media type")
+)
+
+// internalReason - единственное, что клиент узнает о непредвиденной ошибке
+const internalReason = "Internal server error"
+
+var statuses = map[error]int{
+	ErrInvalid:      http.StatusBadRequest,
+	ErrUnauthorized: http.StatusUnauthorized,
+	ErrForbidden:    http.StatusForbidden,
+	ErrNotFound:     http.StatusNotFound,
+	ErrConflict:     http.StatusConflict,
+	ErrPrecondition: http.StatusPreconditionFailed,
+	ErrTooLarge:     http.StatusRequestEntityTooLarge,
+	ErrUnsupported:  http.StatusUnsupportedMediaType,
+}
+
+// Error - ошибка с сообщением для клиента. Причина Err попадает только в лог.
+type Error struct {
+	Kind   error
+	Reason string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Reason + ": " + e.Err.Error()
+	}
+	return e.Reason
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+func newError(kind error, format string, args []any) *Error {
+	return &Error{Kind: kind, Reason: fmt.Sprintf(format, args...)}
+}
+
+func Invalid(format string, args ...any) error   { return newError(ErrInvalid, format, args) }
+func Forbidden(format string, args ...any) error { return newError(ErrForbidden, format, args) }
+func NotFound(format string, args ...any) error  { return newError(ErrNotFound, format, args) }
+func Conflict(format string, args ...any) error  { return newError(ErrConflict, format, args) }
+
+func Unauthorized(format string, args ...any) error {
+	return newError(ErrUnauthorized, format, args)
+}
+
+func PreconditionFailed(format string, args ...any) error {
+	return newError(ErrPrecondition, format, args)
+}
+
+func TooLarge(format string, args ...any) error    { return newError(ErrTooLarge, format, args) }
+func Unsupported(format string, args ...any) error { return newError(ErrUnsupported, format, args) }
+
+// Wrap добавляет к причине err категорию и сообщение для клиента
+func Wrap(kind error, err error, format string, args ...any) error {
+	e := newError(kind, format, args)
+	e.Err = err
+	return e
+}
+
+// kindOf возвращает категорию ошибки или nil, если ошибка непредвиденная
+func kindOf(err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	for kind := range statuses {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// HTTPStatus сопоставляет ошибке код ответа. Все, что не относится к известным категориям, - 500.
+func HTTPStatus(err error) int {
+	if status, ok := statuses[kindOf(err)]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Reason возвращает текст ошибки для клиента. Текст непредвиденных ошибок
+// (в том числе ошибок базы данных) наружу не передается.
+func Reason(err error) string {
+	if kindOf(err) == nil {
+		return internalReason
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Reason
+	}
+	return err.Error()
+}
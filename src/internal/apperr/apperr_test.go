@@ -0,0 +1,43 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapping(t *testing.T) {
+	dbErr := errors.New(`pq: relation "tender" does not exist`)
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		reason string
+	}{
+		{name: "Invalid", err: Invalid("Invalid status"), status: http.StatusBadRequest, reason: "Invalid status"},
+		{name: "Not found", err: NotFound("Tender %d not found", 1), status: http.StatusNotFound, reason: "Tender 1 not found"},
+		{name: "Wrapped conflict", err: fmt.Errorf("tx: %w", Conflict("Bid 1 was modified concurrently")), status: http.StatusConflict, reason: "Bid 1 was modified concurrently"},
+		{name: "Sentinel", err: fmt.Errorf("%w: tender:edit", ErrForbidden), status: http.StatusForbidden, reason: "forbidden: tender:edit"},
+		{name: "Cause is hidden", err: Wrap(ErrNotFound, dbErr, "Version %d not found", 2), status: http.StatusNotFound, reason: "Version 2 not found"},
+		{name: "Too large", err: TooLarge("File exceeds %d bytes", 10), status: http.StatusRequestEntityTooLarge, reason: "File exceeds 10 bytes"},
+		{name: "Unsupported", err: Unsupported("File type %s is not allowed", "text/html"), status: http.StatusUnsupportedMediaType, reason: "File type text/html is not allowed"},
+		{name: "Database error", err: dbErr, status: http.StatusInternalServerError, reason: "Internal server error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.status, HTTPStatus(tt.err))
+			assert.Equal(t, tt.reason, Reason(tt.err))
+		})
+	}
+}
+
+func TestWrapKeepsCause(t *testing.T) {
+	cause := errors.New("record not found")
+	err := Wrap(ErrNotFound, cause, "Bid %d not found", 3)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "Bid 3 not found: record not found", err.Error())
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims - содержимое токена: кому он выдан и до какого времени действует
+type Claims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+// UserID возвращает идентификатор сотрудника, которому выдан токен
+func (c *Claims) UserID() (int, error) {
+	return strconv.Atoi(c.Subject)
+}
+
+type Manager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewManager(secret string, ttl time.Duration) *Manager {
+	return &Manager{
+		secret: []byte(secret),
+		ttl:    ttl,
+	}
+}
+
+// Issue подписывает токен для сотрудника из таблицы employee
+func (m *Manager) Issue(employee models.Employee) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+	claims := Claims{
+		Username: employee.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(employee.ID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// Parse проверяет подпись и срок действия токена
+func (m *Manager) Parse(token string) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Username == "" {
+		return nil, fmt.Errorf("%w: missing username", ErrInvalidToken)
+	}
+	return &claims, nil
+}
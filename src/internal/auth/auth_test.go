@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueAndParse(t *testing.T) {
+	m := NewManager("secret", time.Hour)
+	token, expiresAt, err := m.Issue(models.Employee{ID: 1, Username: "user1"})
+	assert.NoError(t, err)
+	assert.True(t, expiresAt.After(time.Now()))
+
+	claims, err := m.Parse(token)
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", claims.Username)
+	id, err := claims.UserID()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, id)
+}
+
+func TestParseInvalidToken(t *testing.T) {
+	m := NewManager("secret", time.Hour)
+	token, _, err := m.Issue(models.Employee{ID: 1, Username: "user1"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		manager *Manager
+		token   string
+	}{
+		{name: "Wrong secret", manager: NewManager("other", time.Hour), token: token},
+		{name: "Garbage", manager: m, token: "not-a-token"},
+		{name: "Expired", manager: m, token: func() string {
+			expired, _, _ := NewManager("secret", -time.Minute).Issue(models.Employee{ID: 1, Username: "user1"})
+			return expired
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.manager.Parse(tt.token)
+			assert.True(t, errors.Is(err, ErrInvalidToken))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+var (
+	// ErrForbidden совпадает с категорией apperr, поэтому отказ в доступе отображается в 403
+	ErrForbidden        = apperr.ErrForbidden
+	ErrUndeclaredAction = errors.New("action has no declared policy")
+)
+
+type Role string
+
+const (
+	// RoleOrganizationResponsible - ответственный за организацию, которой принадлежит ресурс
+	RoleOrganizationResponsible Role = "ORGANIZATION_RESPONSIBLE"
+	// RoleBidAuthor - создатель пользовательского предложения или ответственный за организацию-автора
+	RoleBidAuthor Role = "BID_AUTHOR"
+	// RoleTenderViewer - пользователь, которому виден тендер с учетом его статуса и видимости
+	RoleTenderViewer Role = "TENDER_VIEWER"
+	// RoleAdmin - администратор сервиса
+	RoleAdmin Role = "ADMIN"
+	// RoleSelf - сотрудник, который сам является ресурсом
+	RoleSelf Role = "SELF"
+)
+
+type Action string
+
+const (
+	ActionListTenders       Action = "tender:list"
+	ActionViewTender        Action = "tender:view"
+	ActionListOwnTenders    Action = "tender:list_own"
+	ActionCreateTender      Action = "tender:create"
+	ActionEditTender        Action = "tender:edit"
+	ActionSetTenderStatus   Action = "tender:set_status"
+	ActionRollbackTender    Action = "tender:rollback"
+	ActionAwardTender       Action = "tender:award"
+	ActionViewAward         Action = "tender:view_award"
+	ActionViewScores        Action = "tender:view_scores"
+	ActionViewInvitations   Action = "tender:view_invitations"
+	ActionInvite            Action = "tender:invite"
+	ActionAskQuestion       Action = "tender:ask_question"
+	ActionAnswerQuestion    Action = "tender:answer_question"
+	ActionViewAllQuestions  Action = "tender:view_all_questions" // включая чужие вопросы без ответа
+	ActionOpenRound         Action = "tender:open_round"
+	ActionOpenAuction       Action = "tender:open_auction"
+	ActionViewBid           Action = "bid:view"
+	ActionViewSealedBid     Action = "bid:view_sealed" // содержимое закрытого тендера до окончания подачи
+	ActionListOwnBids       Action = "bid:list_own"
+	ActionListTenderBids    Action = "bid:list_for_tender"
+	ActionListAllTenderBids Action = "bid:list_all_for_tender" // все предложения тендера, а не только свои
+	ActionCreateBid         Action = "bid:create"
+	ActionBidOnBehalfOfOrg  Action = "bid:create_for_organization"
+	ActionEditBid           Action = "bid:edit"
+	ActionSetBidStatus      Action = "bid:set_status"
+	ActionRollbackBid       Action = "bid:rollback"
+	ActionDecideBid         Action = "bid:decide"
+	ActionViewDecisions     Action = "bid:view_decisions"
+	ActionScoreBid          Action = "bid:score"
+	ActionAuctionOffer      Action = "bid:auction_offer"
+	ActionWatchOwnBid       Action = "bid:watch_own" // события своих предложений
+	ActionAddFeedback       Action = "review:add"
+	ActionViewAuthorReviews Action = "review:list"
+
+	ActionSetOrganizationPolicy Action = "organization:set_policy"
+	ActionListOrganizations     Action = "organization:list"
+	ActionViewOrganization      Action = "organization:view"
+	ActionCreateOrganization    Action = "organization:create"
+	ActionEditOrganization      Action = "organization:edit"
+	ActionDeleteOrganization    Action = "organization:delete"
+	ActionManageResponsibles    Action = "organization:manage_responsibles"
+	ActionListEmployees         Action = "employee:list"
+	ActionViewEmployee          Action = "employee:view"
+	ActionCreateEmployee        Action = "employee:create"
+	ActionEditEmployee          Action = "employee:edit"
+	ActionGrantAdmin            Action = "employee:grant_admin"
+	ActionDeleteEmployee        Action = "employee:delete"
+)
+
+// Rule разрешает действие, если у пользователя есть хотя бы одна из ролей AnyOf.
+// Authenticated означает, что достаточно быть аутентифицированным.
+type Rule struct {
+	Authenticated bool
+	AnyOf         []Role
+}
+
+// Policies - декларативное описание прав на каждое действие. Маршрут без
+// действия отсюда не регистрируется; правила Authenticated проверяет маршрут,
+// роли относительно ресурса - метод репозитория через Authorize.
+var Policies = map[Action]Rule{
+	ActionListTenders:       {Authenticated: true},
+	ActionViewTender:        {AnyOf: []Role{RoleTenderViewer, RoleAdmin}},
+	ActionListOwnTenders:    {Authenticated: true},
+	ActionCreateTender:      {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionEditTender:        {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionSetTenderStatus:   {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionRollbackTender:    {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionAwardTender:       {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionViewAward:         {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionViewScores:        {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionViewInvitations:   {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionInvite:            {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionAskQuestion:       {AnyOf: []Role{RoleTenderViewer}},
+	ActionAnswerQuestion:    {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionViewAllQuestions:  {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionOpenRound:         {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionOpenAuction:       {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionViewBid:           {AnyOf: []Role{RoleBidAuthor, RoleOrganizationResponsible, RoleAdmin}},
+	ActionViewSealedBid:     {AnyOf: []Role{RoleBidAuthor}},
+	ActionListOwnBids:       {Authenticated: true},
+	ActionListTenderBids:    {AnyOf: []Role{RoleTenderViewer, RoleAdmin}},
+	ActionListAllTenderBids: {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionCreateBid:         {AnyOf: []Role{RoleTenderViewer}},
+	ActionBidOnBehalfOfOrg:  {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionEditBid:           {AnyOf: []Role{RoleBidAuthor, RoleAdmin}},
+	ActionSetBidStatus:      {AnyOf: []Role{RoleBidAuthor, RoleAdmin}},
+	ActionRollbackBid:       {AnyOf: []Role{RoleBidAuthor, RoleAdmin}},
+	ActionDecideBid:         {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionViewDecisions:     {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionScoreBid:          {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionAuctionOffer:      {AnyOf: []Role{RoleBidAuthor}},
+	ActionWatchOwnBid:       {AnyOf: []Role{RoleBidAuthor}},
+	ActionAddFeedback:       {AnyOf: []Role{RoleOrganizationResponsible}},
+	ActionViewAuthorReviews: {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+
+	ActionSetOrganizationPolicy: {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionListOrganizations:     {Authenticated: true},
+	ActionViewOrganization:      {Authenticated: true},
+	ActionCreateOrganization:    {AnyOf: []Role{RoleAdmin}},
+	ActionEditOrganization:      {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionDeleteOrganization:    {AnyOf: []Role{RoleAdmin}},
+	ActionManageResponsibles:    {AnyOf: []Role{RoleOrganizationResponsible, RoleAdmin}},
+	ActionListEmployees:         {Authenticated: true},
+	ActionViewEmployee:          {Authenticated: true},
+	ActionCreateEmployee:        {AnyOf: []Role{RoleAdmin}},
+	ActionEditEmployee:          {AnyOf: []Role{RoleSelf, RoleAdmin}},
+	ActionGrantAdmin:            {AnyOf: []Role{RoleAdmin}},
+	ActionDeleteEmployee:        {AnyOf: []Role{RoleAdmin}},
+}
+
+// Declared сообщает, описана ли политика для действия
+func Declared(action Action) bool {
+	_, ok := Policies[action]
+	return ok
+}
+
+// MustDeclared паникует, если для действия не описана политика.
+// Используется при регистрации маршрутов.
+func MustDeclared(action Action) {
+	if !Declared(action) {
+		panic(fmt.Sprintf("authz: %v: %q", ErrUndeclaredAction, action))
+	}
+}
+
+type ResourceKind string
+
+const (
+	ResourceNone         ResourceKind = ""
+	ResourceTender       ResourceKind = "tender"
+	ResourceBid          ResourceKind = "bid"
+	ResourceOrganization ResourceKind = "organization"
+	ResourceEmployee     ResourceKind = "employee"
+)
+
+// Resource - объект, над которым выполняется действие
+type Resource struct {
+	Kind ResourceKind
+	ID   int
+}
+
+func Tender(id int) Resource       { return Resource{Kind: ResourceTender, ID: id} }
+func Bid(id int) Resource          { return Resource{Kind: ResourceBid, ID: id} }
+func Organization(id int) Resource { return Resource{Kind: ResourceOrganization, ID: id} }
+func Employee(id int) Resource     { return Resource{Kind: ResourceEmployee, ID: id} }
+
+type RoleSet map[Role]bool
+
+func NewRoleSet(roles ...Role) RoleSet {
+	set := make(RoleSet, len(roles))
+	for _, r := range roles {
+		set[r] = true
+	}
+	return set
+}
+
+// RoleResolver определяет роли пользователя относительно ресурса
+type RoleResolver interface {
+	Roles(ctx context.Context, username string, resource Resource) (RoleSet, error)
+}
+
+// Check применяет правило действия к уже известному набору ролей
+func Check(action Action, roles RoleSet) error {
+	rule, ok := Policies[action]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUndeclaredAction, action)
+	}
+	if rule.Authenticated {
+		return nil
+	}
+	for _, r := range rule.AnyOf {
+		if roles[r] {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrForbidden, action)
+}
+
+type Authorizer struct {
+	resolver RoleResolver
+}
+
+func New(resolver RoleResolver) *Authorizer {
+	return &Authorizer{resolver: resolver}
+}
+
+// Authorize проверяет, может ли пользователь выполнить действие над ресурсом
+func (a *Authorizer) Authorize(ctx context.Context, username string, action Action, resource Resource) error {
+	if username == "" {
+		return fmt.Errorf("%w: anonymous user", ErrForbidden)
+	}
+	rule, ok := Policies[action]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUndeclaredAction, action)
+	}
+	if rule.Authenticated {
+		return nil
+	}
+	roles, err := a.resolver.Roles(ctx, username, resource)
+	if err != nil {
+		return fmt.Errorf("failed to resolve roles: %w", err)
+	}
+	return Check(action, roles)
+}
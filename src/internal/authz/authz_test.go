@@ -0,0 +1,83 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeResolver struct {
+	roles RoleSet
+	err   error
+	calls int
+}
+
+func (f *fakeResolver) Roles(ctx context.Context, username string, resource Resource) (RoleSet, error) {
+	f.calls++
+	return f.roles, f.err
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		action Action
+		roles  RoleSet
+		err    error
+	}{
+		{name: "Responsible edits tender", action: ActionEditTender, roles: NewRoleSet(RoleOrganizationResponsible)},
+		{name: "Admin edits tender", action: ActionEditTender, roles: NewRoleSet(RoleAdmin)},
+		{name: "Viewer cannot edit tender", action: ActionEditTender, roles: NewRoleSet(RoleTenderViewer), err: ErrForbidden},
+		{name: "Author edits bid", action: ActionEditBid, roles: NewRoleSet(RoleBidAuthor)},
+		{name: "Responsible cannot edit foreign bid", action: ActionEditBid, roles: NewRoleSet(RoleOrganizationResponsible), err: ErrForbidden},
+		{name: "Admin does not vote", action: ActionDecideBid, roles: NewRoleSet(RoleAdmin), err: ErrForbidden},
+		{name: "Anyone lists tenders", action: ActionListTenders, roles: NewRoleSet()},
+		{name: "Employee edits own profile", action: ActionEditEmployee, roles: NewRoleSet(RoleSelf)},
+		{name: "Employee cannot grant admin to self", action: ActionGrantAdmin, roles: NewRoleSet(RoleSelf), err: ErrForbidden},
+		{name: "Responsible cannot create organization", action: ActionCreateOrganization, roles: NewRoleSet(RoleOrganizationResponsible), err: ErrForbidden},
+		{name: "Responsible manages responsibles", action: ActionManageResponsibles, roles: NewRoleSet(RoleOrganizationResponsible)},
+		{name: "Viewer lists only own bids of tender", action: ActionListAllTenderBids, roles: NewRoleSet(RoleTenderViewer), err: ErrForbidden},
+		{name: "Responsible invites to tender", action: ActionInvite, roles: NewRoleSet(RoleOrganizationResponsible)},
+		{name: "Viewer asks question", action: ActionAskQuestion, roles: NewRoleSet(RoleTenderViewer)},
+		{name: "Viewer cannot answer question", action: ActionAnswerQuestion, roles: NewRoleSet(RoleTenderViewer), err: ErrForbidden},
+		{name: "Admin cannot open round", action: ActionOpenRound, roles: NewRoleSet(RoleAdmin), err: ErrForbidden},
+		{name: "Author bids in auction", action: ActionAuctionOffer, roles: NewRoleSet(RoleBidAuthor)},
+		{name: "Responsible cannot bid in auction", action: ActionAuctionOffer, roles: NewRoleSet(RoleOrganizationResponsible), err: ErrForbidden},
+		{name: "Admin does not watch foreign bids", action: ActionWatchOwnBid, roles: NewRoleSet(RoleAdmin), err: ErrForbidden},
+		{name: "Undeclared action", action: Action("tender:delete"), roles: NewRoleSet(RoleAdmin), err: ErrUndeclaredAction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.action, tt.roles)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.err), err)
+		})
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	ctx := context.Background()
+
+	resolver := &fakeResolver{roles: NewRoleSet(RoleBidAuthor)}
+	a := New(resolver)
+	assert.NoError(t, a.Authorize(ctx, "user1", ActionRollbackBid, Bid(1)))
+	assert.True(t, errors.Is(a.Authorize(ctx, "user1", ActionDecideBid, Bid(1)), ErrForbidden))
+	assert.True(t, errors.Is(a.Authorize(ctx, "", ActionListTenders, Resource{}), ErrForbidden))
+
+	// Для действий, доступных любому пользователю, роли не вычисляются
+	resolver.calls = 0
+	assert.NoError(t, a.Authorize(ctx, "user1", ActionListOwnBids, Resource{}))
+	assert.Equal(t, 0, resolver.calls)
+
+	resolver.err = errors.New("db error")
+	assert.Error(t, a.Authorize(ctx, "user1", ActionEditTender, Tender(1)))
+}
+
+func TestMustDeclared(t *testing.T) {
+	assert.NotPanics(t, func() { MustDeclared(ActionCreateTender) })
+	assert.Panics(t, func() { MustDeclared(Action("bid:delete")) })
+}
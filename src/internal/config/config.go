@@ -2,7 +2,11 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type Config struct {
@@ -14,19 +18,51 @@ type Config struct {
 	PostgresUsername string
 	PostgresPass     string
 	PostgresDBName   string
+	JWTSecret        string
+	TokenTTL         time.Duration
+	OpenAPISpec      string
+	OpenAPIValidate  bool
+	// Интервал проверки сроков тендеров, 0 отключает планировщик
+	SchedulerInterval time.Duration
+	// Вложения: каталог блобов, наибольший размер файла и разрешенные MIME-типы
+	BlobDir           string
+	AttachmentMaxSize int64
+	AttachmentTypes   []string
+	// Типы услуг, тендеры которых можно проводить как аукцион на понижение
+	AuctionServiceTypes []string
+	// Получатели доменных событий (stdout, file:<путь>, webhook:<url>) и интервал
+	// опроса outbox; без получателей события копятся в outbox
+	OutboxSinks    []string
+	OutboxInterval time.Duration
 }
 
 // Константы по умолчанию
 const (
-	defaultAddr             = ":8080"
-	defaultMigratePath      = "migrations"
-	defaultPostgresHost     = "db"
-	defaultPostgresPort     = "5432"
-	defaultPostgresUsername = "nastya"
-	defaultPostgresPass     = "pgspgs"
-	defaultPostgresDBName   = "avito"
+	defaultAddr              = ":8080"
+	defaultMigratePath       = "migrations"
+	defaultPostgresHost      = "db"
+	defaultPostgresPort      = "5432"
+	defaultPostgresUsername  = "nastya"
+	defaultPostgresPass      = "pgspgs"
+	defaultPostgresDBName    = "avito"
+	defaultTokenTTL          = 24 * time.Hour
+	defaultOpenAPISpec       = "задание/openapi.yml"
+	defaultSchedulerInterval = time.Minute
+	defaultBlobDir           = "data/blobs"
+	defaultAttachmentMaxSize = 10 << 20
+	// Тип определяется по содержимому: документы Office (docx, xlsx) распознаются как application/zip
+	defaultAttachmentTypes = "application/pdf,application/zip,image/png,image/jpeg,text/plain"
+	// Аукцион имеет смысл для типовых услуг, где предложения различаются только ценой
+	defaultAuctionServiceTypes = "Delivery"
+	defaultOutboxInterval      = time.Second
 )
 
+// minJWTSecretLen - наименьшая длина секрета подписи токенов HS256, 256 бит
+const minJWTSecretLen = 32
+
+// redacted заменяет секреты при выводе конфигурации
+const redacted = "[REDACTED]"
+
 // Функция обработки флагов запуска
 func ReadConfig() Config {
 	var addr string
@@ -50,6 +86,42 @@ func ReadConfig() Config {
 	postgresPass := getEnv("POSTGRES_PASSWORD", defaultPostgresPass)
 	postgresDBName := getEnv("POSTGRES_DATABASE", defaultPostgresDBName)
 
+	// Параметры выдачи токенов
+	// Секрет по умолчанию не задается: зная его, можно подписать токен любого сотрудника
+	jwtSecret := os.Getenv("JWT_SECRET")
+	tokenTTL, err := time.ParseDuration(getEnv("TOKEN_TTL", defaultTokenTTL.String()))
+	if err != nil {
+		tokenTTL = defaultTokenTTL
+	}
+
+	// Режим строгого соответствия спецификации OpenAPI
+	openAPISpec := getEnv("OPENAPI_SPEC", defaultOpenAPISpec)
+	openAPIValidate := getEnv("OPENAPI_VALIDATE", "false") == "true"
+
+	// Планировщик сроков тендеров
+	schedulerInterval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", defaultSchedulerInterval.String()))
+	if err != nil || schedulerInterval < 0 {
+		schedulerInterval = defaultSchedulerInterval
+	}
+
+	// Вложения тендеров и предложений
+	blobDir := getEnv("BLOB_DIR", defaultBlobDir)
+	attachmentMaxSize, err := strconv.ParseInt(getEnv("ATTACHMENT_MAX_SIZE", strconv.Itoa(defaultAttachmentMaxSize)), 10, 64)
+	if err != nil || attachmentMaxSize <= 0 {
+		attachmentMaxSize = defaultAttachmentMaxSize
+	}
+	attachmentTypes := splitList(getEnv("ATTACHMENT_TYPES", defaultAttachmentTypes))
+
+	// Аукционы на понижение цены
+	auctionServiceTypes := splitList(getEnv("AUCTION_SERVICE_TYPES", defaultAuctionServiceTypes))
+
+	// Доставка доменных событий из outbox
+	outboxSinks := splitList(getEnv("OUTBOX_SINKS", ""))
+	outboxInterval, err := time.ParseDuration(getEnv("OUTBOX_INTERVAL", defaultOutboxInterval.String()))
+	if err != nil || outboxInterval <= 0 {
+		outboxInterval = defaultOutboxInterval
+	}
+
 	return Config{
 		Addr:             addr,
 		MPath:            migratePath,
@@ -59,7 +131,39 @@ func ReadConfig() Config {
 		PostgresUsername: postgresUsername,
 		PostgresPass:     postgresPass,
 		PostgresDBName:   postgresDBName,
+		JWTSecret:        jwtSecret,
+		TokenTTL:         tokenTTL,
+		OpenAPISpec:      openAPISpec,
+		OpenAPIValidate:  openAPIValidate,
+
+		SchedulerInterval: schedulerInterval,
+		BlobDir:           blobDir,
+		AttachmentMaxSize: attachmentMaxSize,
+		AttachmentTypes:   attachmentTypes,
+
+		AuctionServiceTypes: auctionServiceTypes,
+		OutboxSinks:         outboxSinks,
+		OutboxInterval:      outboxInterval,
+	}
+}
+
+// Validate проверяет параметры, без которых сервис нельзя запускать
+func (c Config) Validate() error {
+	if len(c.JWTSecret) < minJWTSecretLen {
+		return fmt.Errorf("JWT_SECRET must be set to at least %d characters", minJWTSecretLen)
+	}
+	return nil
+}
+
+// Redacted возвращает копию конфигурации без секретов для вывода в лог
+func (c Config) Redacted() Config {
+	if c.JWTSecret != "" {
+		c.JWTSecret = redacted
+	}
+	if c.PostgresPass != "" {
+		c.PostgresPass = redacted
 	}
+	return c
 }
 
 // Функция для получения переменной окружения или значения по умолчанию
@@ -69,3 +173,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// splitList разбирает список через запятую, пропуская пустые элементы
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
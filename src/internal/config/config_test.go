@@ -0,0 +1,41 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "Missing secret", wantErr: true},
+		{name: "Short secret", secret: "avito-secret", wantErr: true},
+		{name: "Long secret", secret: strings.Repeat("s", minJWTSecretLen)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{JWTSecret: tt.secret}.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Config{JWTSecret: "jwt-secret", PostgresPass: "pgspgs", PostgresUsername: "nastya"}
+
+	printed := cfg.Redacted()
+	assert.Equal(t, redacted, printed.JWTSecret)
+	assert.Equal(t, redacted, printed.PostgresPass)
+	assert.Equal(t, "nastya", printed.PostgresUsername)
+	// Исходная конфигурация не меняется
+	assert.Equal(t, "jwt-secret", cfg.JWTSecret)
+}
@@ -0,0 +1,71 @@
+package events
+
+import (
+	"sync"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+// subscriptionBuffer - сколько событий может ждать медленного подписчика
+const subscriptionBuffer = 64
+
+// Hub раздает события активности подписчикам внутри одной реплики
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[*Subscription]struct{})}
+}
+
+// Subscription - подписка на события, для которых filter возвращает true.
+// Канал Events закрывается при Close и при переполнении буфера: отставшему
+// подписчику нужно переподключиться и перечитать состояние.
+type Subscription struct {
+	Events <-chan models.Event
+
+	events chan models.Event
+	filter func(models.Event) bool
+	hub    *Hub
+}
+
+func (h *Hub) Subscribe(filter func(models.Event) bool) *Subscription {
+	events := make(chan models.Event, subscriptionBuffer)
+	s := &Subscription{Events: events, events: events, filter: filter, hub: h}
+	h.mu.Lock()
+	h.subscribers[s] = struct{}{}
+	h.mu.Unlock()
+	return s
+}
+
+// Close отменяет подписку, повторный вызов ничего не делает
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.drop(s)
+}
+
+// Publish передает событие подходящим подписчикам не блокируясь
+func (h *Hub) Publish(event models.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for s := range h.subscribers {
+		if !s.filter(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+			h.drop(s)
+		}
+	}
+}
+
+// drop удаляет подписчика и закрывает его канал, вызывается под h.mu
+func (h *Hub) drop(s *Subscription) {
+	if _, ok := h.subscribers[s]; ok {
+		delete(h.subscribers, s)
+		close(s.events)
+	}
+}
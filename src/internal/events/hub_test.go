@@ -0,0 +1,44 @@
+package events
+
+import (
+	"testing"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub(t *testing.T) {
+	hub := NewHub()
+	tender1 := hub.Subscribe(func(e models.Event) bool { return e.TenderID == 1 })
+	all := hub.Subscribe(func(models.Event) bool { return true })
+
+	hub.Publish(models.Event{Type: models.TenderEdited, TenderID: 1, Version: 2})
+	hub.Publish(models.Event{Type: models.TenderEdited, TenderID: 2, Version: 5})
+
+	assert.Equal(t, models.Event{Type: models.TenderEdited, TenderID: 1, Version: 2}, <-tender1.Events)
+	assert.Empty(t, tender1.Events)
+	assert.Len(t, all.Events, 2)
+
+	tender1.Close()
+	tender1.Close()
+	_, open := <-tender1.Events
+	assert.False(t, open)
+	hub.Publish(models.Event{Type: models.TenderEdited, TenderID: 1, Version: 3})
+	assert.Len(t, all.Events, 3)
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	hub := NewHub()
+	slow := hub.Subscribe(func(models.Event) bool { return true })
+	for i := 0; i <= subscriptionBuffer; i++ {
+		hub.Publish(models.Event{Type: models.BidEdited, TenderID: 1, Version: i})
+	}
+
+	received := 0
+	for range slow.Events {
+		received++
+	}
+	assert.Equal(t, subscriptionBuffer, received)
+	// Отписка после сброса ничего не ломает
+	slow.Close()
+}
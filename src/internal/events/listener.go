@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+)
+
+// Channel - канал NOTIFY, в который триггеры пишут события активности
+const Channel = "activity"
+
+// retryDelay - пауза перед повторным подключением слушателя
+const retryDelay = 5 * time.Second
+
+// Listen слушает канал Channel на отдельном соединении и публикует события в hub,
+// пока не отменен ctx. После обрыва слушатель переподключается и публикует
+// models.Resync: события за время обрыва потеряны.
+func Listen(ctx context.Context, dsn string, hub *Hub, log *zerolog.Logger) {
+	// connected - сессия LISTEN уже устанавливалась: только после нее подписчики
+	// могли пропустить события
+	connected := false
+	for {
+		listening, err := listen(ctx, dsn, hub, log, connected)
+		if ctx.Err() != nil {
+			return
+		}
+		connected = connected || listening
+		log.Error().Err(err).Msg("Activity listener disconnected")
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// listen слушает канал до ошибки. listening сообщает, что сессия LISTEN была установлена.
+func listen(ctx context.Context, dsn string, hub *Hub, log *zerolog.Logger, reconnected bool) (listening bool, err error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+Channel); err != nil {
+		return false, fmt.Errorf("failed to listen: %w", err)
+	}
+	if reconnected {
+		hub.Publish(models.Event{Type: models.Resync})
+	}
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, fmt.Errorf("failed to wait for notification: %w", err)
+		}
+		var event models.Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			log.Error().Err(err).Str("payload", notification.Payload).Msg("Invalid activity event")
+			continue
+		}
+		hub.Publish(event)
+	}
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxAttachments - сколько вложений может быть у одного тендера или предложения
+const MaxAttachments = 20
+
+// Attachment - описание файла, приложенного к тендеру или предложению.
+// Содержимое хранится в storage.BlobStore под ключом Checksum.
+type Attachment struct {
+	ID          int       `json:"id" gorm:"primaryKey"`
+	TenderID    *int      `json:"-"`
+	BidID       *int      `json:"-"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	UploadedBy  string    `json:"uploadedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type AttachmentOwnerKind string
+
+const (
+	TenderAttachments AttachmentOwnerKind = "tender"
+	BidAttachments    AttachmentOwnerKind = "bid"
+)
+
+// AttachmentOwner - тендер или предложение, которому принадлежат вложения
+type AttachmentOwner struct {
+	Kind AttachmentOwnerKind
+	ID   int
+}
+
+func TenderOwner(id int) AttachmentOwner { return AttachmentOwner{Kind: TenderAttachments, ID: id} }
+func BidOwner(id int) AttachmentOwner    { return AttachmentOwner{Kind: BidAttachments, ID: id} }
+
+// FormatAttachments представляет вложения в истории версий: имя и начало
+// контрольной суммы, чтобы замена файла с тем же именем была видна в diff
+func FormatAttachments(attachments []Attachment) string {
+	parts := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		parts = append(parts, a.Name+"#"+a.Checksum[:min(8, len(a.Checksum))])
+	}
+	return strings.Join(parts, ", ")
+}
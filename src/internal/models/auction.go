@@ -0,0 +1,139 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+type AuctionStatus string
+
+const (
+	AuctionScheduled AuctionStatus = "Scheduled"
+	AuctionRunning   AuctionStatus = "Running"
+	AuctionFinished  AuctionStatus = "Finished"
+)
+
+// Auction - аукцион на понижение цены по тендеру. Участвуют опубликованные
+// предложения тендера: каждая ставка должна быть ниже лучшей цены хотя бы на MinStep
+// и становится ценой предложения.
+type Auction struct {
+	TenderID int       `json:"tenderId" gorm:"primaryKey"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	MinStep  Money     `json:"minStep" gorm:"embedded;embeddedPrefix:min_step_"`
+	// Продление (anti-sniping), в секундах: ставка позже чем за ExtendWithin
+	// до конца переносит конец на ExtendBy от момента ставки
+	ExtendWithin int `json:"extendWithin"`
+	ExtendBy     int `json:"extendBy"`
+	// Лучшая цена и предложение с ней; нулевая цена - ставок и цен еще нет
+	BestPrice Money `json:"bestPrice" gorm:"embedded;embeddedPrefix:best_price_"`
+	BestBidID *int  `json:"bestBidId"`
+	// Offers - число принятых ставок, растет с каждой ставкой
+	Offers    int       `json:"offers"`
+	OpenedBy  string    `json:"openedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Leading - лучшая цена принадлежит пользователю, запросившему аукцион
+	Leading bool `json:"leading" gorm:"-"`
+}
+
+// Status возвращает состояние аукциона в момент now
+func (a Auction) Status(now time.Time) AuctionStatus {
+	switch {
+	case now.Before(a.StartsAt):
+		return AuctionScheduled
+	case now.Before(a.EndsAt):
+		return AuctionRunning
+	}
+	return AuctionFinished
+}
+
+// Ceiling - наибольшая цена, которую примет аукцион: лучшая цена минус шаг,
+// а до первой цены - бюджет тендера
+func (a Auction) Ceiling(budget Money) Amount {
+	if a.BestPrice.IsZero() {
+		return budget.Amount
+	}
+	return a.BestPrice.Amount - a.MinStep.Amount
+}
+
+// Accept принимает ставку price по предложению bidID в момент now и возвращает
+// аукцион с новой лучшей ценой и, если ставка поздняя, продленным концом
+func (a Auction) Accept(bidID int, price Money, budget Money, now time.Time) (Auction, error) {
+	if a.Status(now) != AuctionRunning {
+		return a, apperr.Conflict("Auction of tender %d is not running", a.TenderID)
+	}
+	if err := price.Validate("price"); err != nil {
+		return a, err
+	}
+	if price.Currency != a.MinStep.Currency {
+		return a, apperr.Invalid("Price currency must be %s", a.MinStep.Currency)
+	}
+	if ceiling := a.Ceiling(budget); price.Amount > ceiling {
+		return a, apperr.Conflict("Price must be at most %s", Money{Amount: ceiling, Currency: price.Currency})
+	}
+
+	a.BestPrice = price
+	a.BestBidID = &bidID
+	a.Offers++
+	if a.EndsAt.Sub(now) < time.Duration(a.ExtendWithin)*time.Second {
+		if extended := now.Add(time.Duration(a.ExtendBy) * time.Second); extended.After(a.EndsAt) {
+			a.EndsAt = extended
+		}
+	}
+	return a, nil
+}
+
+// AuctionSettings - параметры открытия аукциона
+type AuctionSettings struct {
+	StartsAt     time.Time
+	EndsAt       time.Time
+	MinStep      Money
+	ExtendWithin int
+	ExtendBy     int
+}
+
+// Validate проверяет параметры аукциона для тендера в момент now. Аукцион
+// проводится только по опубликованным тендерам типов из auctionTypes с бюджетом.
+func (s AuctionSettings) Validate(tender Tender, auctionTypes []string, now time.Time) error {
+	if !slices.Contains(auctionTypes, tender.ServiceType) {
+		return apperr.Conflict("Service type %s does not support auctions", tender.ServiceType)
+	}
+	if tender.Status != PublishedT {
+		return apperr.Conflict("Auction cannot be opened for tender %d in status %s", tender.ID, tender.Status.API())
+	}
+	// Цена ставки заменяет цену предложения целиком: позиции и закрытые цены несовместимы с аукционом
+	if tender.Budget.IsZero() || len(tender.RequiredItems) > 0 || tender.Sealed {
+		return apperr.Conflict("Auction requires tender %d to have a budget, no required items and open bids", tender.ID)
+	}
+	if tender.Round > 1 {
+		return apperr.Conflict("Auction cannot be opened during round %d of tender %d", tender.Round, tender.ID)
+	}
+	if err := s.MinStep.Validate("minStep"); err != nil {
+		return err
+	}
+	if s.MinStep.Currency != tender.Budget.Currency {
+		return apperr.Invalid("minStep currency must be %s", tender.Budget.Currency)
+	}
+	if s.ExtendWithin < 0 || s.ExtendBy < 0 {
+		return apperr.Invalid("extendWithin and extendBy must be non-negative")
+	}
+	if !s.EndsAt.After(s.StartsAt) || !s.EndsAt.After(now) {
+		return apperr.Invalid("Auction must end in the future and after it starts")
+	}
+	if tender.DecisionDeadline != nil && s.EndsAt.After(*tender.DecisionDeadline) {
+		return apperr.Invalid("Auction must end no later than decisionDeadline")
+	}
+	return nil
+}
+
+// AuctionOffer - принятая ставка аукциона
+type AuctionOffer struct {
+	ID          int       `json:"id" gorm:"primaryKey"`
+	TenderID    int       `json:"tenderId"`
+	BidID       int       `json:"bidId"`
+	Amount      Amount    `json:"amount"`
+	SubmittedBy string    `json:"submittedBy"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
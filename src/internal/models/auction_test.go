@@ -0,0 +1,77 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionAccept(t *testing.T) {
+	start := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	budget := Money{Amount: 100000, Currency: "RUB"}
+	auction := Auction{TenderID: 1, StartsAt: start, EndsAt: end, MinStep: Money{Amount: 1000, Currency: "RUB"}, ExtendWithin: 60, ExtendBy: 120}
+
+	assert.Equal(t, AuctionScheduled, auction.Status(start.Add(-time.Second)))
+	assert.Equal(t, AuctionRunning, auction.Status(start))
+	assert.Equal(t, AuctionFinished, auction.Status(end))
+
+	// До первой ставки потолок - бюджет тендера
+	_, err := auction.Accept(1, Money{Amount: 90000, Currency: "RUB"}, budget, start.Add(-time.Second))
+	assert.Error(t, err)
+	_, err = auction.Accept(1, Money{Amount: 100001, Currency: "RUB"}, budget, start)
+	assert.Error(t, err)
+	_, err = auction.Accept(1, Money{Amount: 90000, Currency: "USD"}, budget, start)
+	assert.Error(t, err)
+
+	auction, err = auction.Accept(1, Money{Amount: 90000, Currency: "RUB"}, budget, start.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, Money{Amount: 90000, Currency: "RUB"}, auction.BestPrice)
+	assert.Equal(t, 1, *auction.BestBidID)
+	assert.Equal(t, 1, auction.Offers)
+	assert.Equal(t, end, auction.EndsAt)
+
+	// Следующая ставка должна быть ниже лучшей цены хотя бы на шаг
+	_, err = auction.Accept(2, Money{Amount: 89500, Currency: "RUB"}, budget, start.Add(2*time.Minute))
+	assert.Error(t, err)
+
+	// Поздняя ставка продлевает аукцион на ExtendBy от момента ставки
+	late := end.Add(-30 * time.Second)
+	auction, err = auction.Accept(2, Money{Amount: 89000, Currency: "RUB"}, budget, late)
+	assert.NoError(t, err)
+	assert.Equal(t, late.Add(2*time.Minute), auction.EndsAt)
+	assert.Equal(t, 2, *auction.BestBidID)
+	assert.Equal(t, Amount(88000), auction.Ceiling(budget))
+}
+
+func TestAuctionSettings(t *testing.T) {
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	tender := Tender{ID: 1, ServiceType: "Delivery", Status: PublishedT, Budget: Money{Amount: 100000, Currency: "RUB"}}
+	settings := AuctionSettings{StartsAt: now, EndsAt: now.Add(time.Hour), MinStep: Money{Amount: 1000, Currency: "RUB"}, ExtendWithin: 60, ExtendBy: 60}
+	types := []string{"Delivery"}
+
+	assert.NoError(t, settings.Validate(tender, types, now))
+	assert.Error(t, settings.Validate(tender, []string{"Manufacture"}, now))
+
+	noBudget := tender
+	noBudget.Budget = Money{}
+	assert.Error(t, settings.Validate(noBudget, types, now))
+
+	sealed := tender
+	sealed.Sealed = true
+	assert.Error(t, settings.Validate(sealed, types, now))
+
+	decision := now.Add(30 * time.Minute)
+	early := tender
+	early.DecisionDeadline = &decision
+	assert.Error(t, settings.Validate(early, types, now))
+
+	wrongCurrency := settings
+	wrongCurrency.MinStep.Currency = "USD"
+	assert.Error(t, wrongCurrency.Validate(tender, types, now))
+
+	ended := settings
+	ended.EndsAt = now
+	assert.Error(t, ended.Validate(tender, types, now))
+}
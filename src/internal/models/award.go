@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Award - выбор победителя тендера среди одобренных предложений
+type Award struct {
+	TenderID  int       `json:"tenderId" gorm:"primaryKey"`
+	BidID     int       `json:"bidId"`
+	Reason    string    `json:"reason"`
+	AwardedBy string    `json:"awardedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Candidate - предложение тендера с промежуточным итогом голосования по нему
+type Candidate struct {
+	Bid   Bid
+	Tally Tally
+}
+
+// AwardSummary - сравнение предложений тендера и выбранный победитель (nil, пока его нет)
+type AwardSummary struct {
+	Tender     Tender
+	Award      *Award
+	Candidates []Candidate
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type BidStatus string
 
 const (
@@ -10,23 +12,132 @@ const (
 	DeclinedB  BidStatus = "DECLINED"
 )
 
+// Значения статусов в API (openapi.yml) отличаются от хранимых в базе
+var bidStatusAPI = map[BidStatus]string{
+	CreatedB:   "Created",
+	PublishedB: "Published",
+	CanceledB:  "Canceled",
+	SubmittedB: "Approved",
+	DeclinedB:  "Rejected",
+}
+
+// API возвращает статус в том виде, в котором он описан в спецификации
+func (s BidStatus) API() string {
+	if v, ok := bidStatusAPI[s]; ok {
+		return v
+	}
+	return string(s)
+}
+
+// ParseBidStatus принимает статус как в формате API (Approved), так и в формате базы (SUBMITTED)
+func ParseBidStatus(s string) (BidStatus, bool) {
+	for status, api := range bidStatusAPI {
+		if s == api || s == string(status) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+// bidTransitions - допустимые переходы жизненного цикла предложения.
+// SUBMITTED и DECLINED выставляются только по итогам голосования; одобренное
+// предложение отклоняется, если победителем тендера выбрано другое.
+var bidTransitions = map[BidStatus][]BidStatus{
+	CreatedB:   {PublishedB, CanceledB},
+	PublishedB: {CanceledB, SubmittedB, DeclinedB},
+	SubmittedB: {DeclinedB},
+}
+
+// CanTransitionTo сообщает, можно ли перевести предложение из статуса s в статус to
+func (s BidStatus) CanTransitionTo(to BidStatus) bool {
+	for _, next := range bidTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition проверяет переход и возвращает *TransitionError, если он недопустим
+func (s BidStatus) Transition(to BidStatus) error {
+	if !s.CanTransitionTo(to) {
+		return &TransitionError{Entity: "bid", From: s.API(), To: to.API()}
+	}
+	return nil
+}
+
+// Editable сообщает, можно ли менять содержимое предложения в этом статусе
+func (s BidStatus) Editable() bool {
+	return s == CreatedB || s == PublishedB
+}
+
+// BidAuthorType - от чьего имени подано предложение (bidAuthorType в openapi.yml)
+type BidAuthorType string
+
+const (
+	// UserAuthor - предложение пользователя, им управляет только создатель
+	UserAuthor BidAuthorType = "User"
+	// OrganizationAuthor - предложение организации, им управляет любой ее ответственный
+	OrganizationAuthor BidAuthorType = "Organization"
+)
+
+// ParseBidAuthorType принимает тип автора в формате API
+func ParseBidAuthorType(s string) (BidAuthorType, bool) {
+	switch t := BidAuthorType(s); t {
+	case UserAuthor, OrganizationAuthor:
+		return t, true
+	}
+	return "", false
+}
+
 type Bid struct {
 	ID              int       `json:"id" gorm:"primaryKey"`
-	Name            string    `json:"name" gorm:"not null" validate:"required"`
-	Description     string    `json:"description" validate:"required"`
+	Name            string    `json:"name" gorm:"not null" validate:"required,max=100"`
+	Description     string    `json:"description" validate:"required,max=500"`
 	Status          BidStatus `json:"status"`
 	TenderID        int       `json:"tenderId" gorm:"not null" validate:"required"`
 	OrganizationID  *int      `json:"organizationId" gorm:"default:null"`
 	CreatorUsername string    `json:"creatorUsername" gorm:"not null" validate:"required"`
 	Version         int       `json:"version"`
+	CreatedAt       time.Time `json:"createdAt"`
+	// AuthorType задается при создании и не меняется; для OrganizationAuthor задан OrganizationID
+	AuthorType BidAuthorType `json:"authorType" gorm:"default:User" validate:"required,oneof=User Organization"`
+	// Цена в валюте бюджета тендера, срок поставки в днях и позиции предложения
+	Price        Money      `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	DeliveryDays int        `json:"deliveryDays"`
+	Items        []LineItem `json:"items" gorm:"serializer:json"`
+	// Round - раунд последнего пересмотра: предложение в шорт-листе, пока он
+	// совпадает с раундом тендера
+	Round int `json:"round" gorm:"default:1"`
+	// Sealed - содержимое скрыто до окончания подачи предложений к закрытому тендеру
+	Sealed bool `json:"sealed" gorm:"-"`
+}
+
+// Seal возвращает предложение без содержимого: остаются автор, статус и версия
+func (b Bid) Seal() Bid {
+	b.Name, b.Description = "", ""
+	b.Price, b.DeliveryDays, b.Items = Money{}, 0, nil
+	b.Sealed = true
+	return b
+}
+
+// BidUpdate - частичное изменение предложения, nil-поля остаются без изменений
+type BidUpdate struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,min=1,max=500"`
+	// Цена и позиции проверяются вместе с условиями тендера
+	Price        *Money      `json:"price"`
+	DeliveryDays *int        `json:"deliveryDays"`
+	Items        *[]LineItem `json:"items"`
 }
 
 /*
 {
     "name": "NEW BID",
     "description": "new",
-    "tenderId": 1,
-	"organizationId": 1,
+    "status": "Created",
+    "tenderId": "1",
+	"organizationId": "1",
     "creatorUsername": "user1"
 }
 */
@@ -9,6 +9,17 @@ const (
 	DeclinedD  Decision = "DECLINED"
 )
 
+// ParseDecision принимает решение в формате API (Approved, Rejected) или базы (SUBMITTED, DECLINED)
+func ParseDecision(s string) (Decision, bool) {
+	switch s {
+	case "Approved", string(SubmittedD):
+		return SubmittedD, true
+	case "Rejected", string(DeclinedD):
+		return DeclinedD, true
+	}
+	return "", false
+}
+
 type BidDecision struct {
 	ID             int       `json:"id" gorm:"primaryKey"`
 	BidID          int       `json:"bidID" gorm:"primaryKey"`
@@ -16,3 +27,20 @@ type BidDecision struct {
 	DecisionStatus Decision  `json:"decisionStatus"`
 	DecisionTime   time.Time `json:"decisionDate"`
 }
+
+// Ballot - состояние голосования по предложению: кто может голосовать и кто уже проголосовал
+type Ballot struct {
+	Bid    Bid
+	Policy DecisionPolicy
+	Voters []Voter
+	Votes  []BidDecision
+}
+
+// Tally подводит промежуточный итог по правилам тендера
+func (b Ballot) Tally() Tally {
+	votes := make(map[string]Decision, len(b.Votes))
+	for _, v := range b.Votes {
+		votes[v.Username] = v.DecisionStatus
+	}
+	return b.Policy.Count(b.Voters, votes)
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type BidHistory struct {
 	ID              int       `json:"id" gorm:"primaryKey"`
 	BidID           int       `json:"bidID" gorm:"primaryKey"`
@@ -10,4 +12,14 @@ type BidHistory struct {
 	OrganizationID  *int      `json:"organizationId" gorm:"default:null"`
 	CreatorUsername string    `json:"creatorUsername" gorm:"not null" validate:"required"`
 	Version         int       `json:"version"`
+	ChangedBy       string    `json:"changedBy"`
+	CreatedAt       time.Time `json:"createdAt"`
+	// Раунд тендера, в котором создана версия
+	Round int `json:"round"`
+	// Цена и позиции входят в версию и возвращаются откатом
+	Price        Money      `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	DeliveryDays int        `json:"deliveryDays"`
+	Items        []LineItem `json:"items" gorm:"serializer:json"`
+	// Вложения на момент версии, откат возвращает их
+	Attachments []Attachment `json:"attachments" gorm:"serializer:json"`
 }
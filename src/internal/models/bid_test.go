@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBidTransitions(t *testing.T) {
+	tests := []struct {
+		from, to BidStatus
+		allowed  bool
+	}{
+		{from: CreatedB, to: PublishedB, allowed: true},
+		{from: CreatedB, to: CanceledB, allowed: true},
+		{from: CreatedB, to: SubmittedB},
+		{from: PublishedB, to: SubmittedB, allowed: true},
+		{from: PublishedB, to: DeclinedB, allowed: true},
+		{from: PublishedB, to: CreatedB},
+		{from: DeclinedB, to: CreatedB},
+		{from: SubmittedB, to: PublishedB},
+		{from: SubmittedB, to: DeclinedB, allowed: true},
+		{from: CanceledB, to: PublishedB},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestTransitionError(t *testing.T) {
+	err := DeclinedB.Transition(PublishedB)
+
+	var transitionErr *TransitionError
+	assert.True(t, errors.As(err, &transitionErr))
+	assert.Equal(t, http.StatusConflict, apperr.HTTPStatus(err))
+	assert.Equal(t, "Cannot change bid status from Rejected to Published", apperr.Reason(err))
+	assert.NoError(t, CreatedB.Transition(PublishedB))
+}
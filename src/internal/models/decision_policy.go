@@ -0,0 +1,173 @@
+package models
+
+import (
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+type DecisionRule string
+
+const (
+	// RuleQuorum - нужно Quorum голосов "за" (по умолчанию min(3, число голосующих))
+	RuleQuorum DecisionRule = "QUORUM"
+	// RuleMajority - доля голосов "за" от всех голосующих не меньше Percent
+	RuleMajority DecisionRule = "MAJORITY"
+	// RuleUnanimous - "за" должны проголосовать все
+	RuleUnanimous DecisionRule = "UNANIMOUS"
+)
+
+// DefaultRole - роль ответственного в организации, если она не указана
+const DefaultRole = "member"
+
+// DecisionPolicy - правила голосования по предложениям тендера. Голосуют
+// ответственные за организацию тендера, вес голоса определяется ролью
+// ответственного (Weights, по умолчанию 1). Все пороги считаются в весах.
+type DecisionPolicy struct {
+	Rule    DecisionRule   `json:"rule"`
+	Quorum  int            `json:"quorum,omitempty"`
+	Percent int            `json:"percent,omitempty"`
+	Weights map[string]int `json:"weights,omitempty"`
+	// Veto - одно отклонение сразу отклоняет предложение. Если заданы VetoRoles,
+	// правом вето обладают только эти роли. Без вето отклонения считаются
+	// голосами "против", и предложение отклоняется, когда одобрение стало невозможным.
+	Veto      bool     `json:"veto"`
+	VetoRoles []string `json:"vetoRoles,omitempty"`
+}
+
+// DefaultDecisionPolicy повторяет исходные правила: кворум min(3, M), любое отклонение - вето
+var DefaultDecisionPolicy = DecisionPolicy{Rule: RuleQuorum, Veto: true}
+
+func (p DecisionPolicy) Validate() error {
+	switch p.Rule {
+	case RuleQuorum:
+		if p.Quorum < 0 {
+			return apperr.Invalid("quorum must be non-negative")
+		}
+	case RuleMajority:
+		if p.Percent < 1 || p.Percent > 100 {
+			return apperr.Invalid("percent must be between 1 and 100")
+		}
+	case RuleUnanimous:
+	default:
+		return apperr.Invalid("rule must be one of: QUORUM, MAJORITY, UNANIMOUS")
+	}
+	for role, w := range p.Weights {
+		if w < 0 {
+			return apperr.Invalid("weight of role %s must be non-negative", role)
+		}
+	}
+	if len(p.VetoRoles) > 0 && !p.Veto {
+		return apperr.Invalid("vetoRoles require veto")
+	}
+	return nil
+}
+
+// Voter - ответственный, который может голосовать по предложению
+type Voter struct {
+	Username string
+	Role     string
+}
+
+type Outcome int
+
+const (
+	OutcomePending Outcome = iota
+	OutcomeApproved
+	OutcomeRejected
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeApproved:
+		return "Approved"
+	case OutcomeRejected:
+		return "Rejected"
+	}
+	return "Pending"
+}
+
+func (p DecisionPolicy) weight(role string) int {
+	if w, ok := p.Weights[role]; ok {
+		return w
+	}
+	return 1
+}
+
+func (p DecisionPolicy) canVeto(role string) bool {
+	if !p.Veto {
+		return false
+	}
+	if len(p.VetoRoles) == 0 {
+		return true
+	}
+	for _, r := range p.VetoRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// required - вес голосов "за", достаточный для одобрения
+func (p DecisionPolicy) required(total, voters int) int {
+	switch p.Rule {
+	case RuleMajority:
+		return (total*p.Percent + 99) / 100
+	case RuleUnanimous:
+		return total
+	}
+	// Кворум больше числа голосующих недостижим, поэтому ограничивается им
+	if p.Quorum > 0 {
+		return min(p.Quorum, total)
+	}
+	return min(3, voters, total)
+}
+
+// Tally - промежуточный итог голосования в весах голосов
+type Tally struct {
+	Approvals  int
+	Rejections int
+	Total      int
+	Required   int
+	Outcome    Outcome
+}
+
+// Remaining - сколько еще голосов "за" нужно для одобрения
+func (t Tally) Remaining() int {
+	return max(t.Required-t.Approvals, 0)
+}
+
+// Count подводит итог голосования. votes - решение каждого голосовавшего;
+// голоса тех, кого нет среди voters, не учитываются.
+func (p DecisionPolicy) Count(voters []Voter, votes map[string]Decision) Tally {
+	var t Tally
+	vetoed := false
+	for _, v := range voters {
+		w := p.weight(v.Role)
+		t.Total += w
+		switch votes[v.Username] {
+		case SubmittedD:
+			t.Approvals += w
+		case DeclinedD:
+			t.Rejections += w
+			vetoed = vetoed || p.canVeto(v.Role)
+		}
+	}
+
+	t.Required = p.required(t.Total, len(voters))
+	switch {
+	case vetoed:
+		t.Outcome = OutcomeRejected
+	case t.Approvals >= t.Required && t.Approvals > 0:
+		t.Outcome = OutcomeApproved
+	// Оставшихся голосов не хватит для одобрения. Одобрение требует хотя бы
+	// одного голоса с весом, поэтому без таких голосующих предложение отклоняется.
+	case t.Total-t.Rejections < max(t.Required, 1):
+		t.Outcome = OutcomeRejected
+	}
+	return t
+}
+
+// Evaluate возвращает только исход голосования
+func (p DecisionPolicy) Evaluate(voters []Voter, votes map[string]Decision) Outcome {
+	return p.Count(voters, votes).Outcome
+}
@@ -0,0 +1,130 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecisionPolicyEvaluate(t *testing.T) {
+	voters := []Voter{
+		{Username: "user1", Role: "lead"},
+		{Username: "user2", Role: DefaultRole},
+		{Username: "user3", Role: DefaultRole},
+		{Username: "user4", Role: DefaultRole},
+	}
+	tests := []struct {
+		name   string
+		policy DecisionPolicy
+		votes  map[string]Decision
+		want   Outcome
+	}{
+		{
+			name:   "Default quorum is three approvals",
+			policy: DefaultDecisionPolicy,
+			votes:  map[string]Decision{"user1": SubmittedD, "user2": SubmittedD},
+			want:   OutcomePending,
+		},
+		{
+			name:   "Default quorum reached",
+			policy: DefaultDecisionPolicy,
+			votes:  map[string]Decision{"user1": SubmittedD, "user2": SubmittedD, "user3": SubmittedD},
+			want:   OutcomeApproved,
+		},
+		{
+			name:   "Any rejection is a veto by default",
+			policy: DefaultDecisionPolicy,
+			votes:  map[string]Decision{"user1": SubmittedD, "user2": DeclinedD},
+			want:   OutcomeRejected,
+		},
+		{
+			name:   "Fixed quorum of two",
+			policy: DecisionPolicy{Rule: RuleQuorum, Quorum: 2},
+			votes:  map[string]Decision{"user2": SubmittedD, "user3": SubmittedD, "user4": DeclinedD},
+			want:   OutcomeApproved,
+		},
+		{
+			name:   "Majority without veto",
+			policy: DecisionPolicy{Rule: RuleMajority, Percent: 51},
+			votes:  map[string]Decision{"user1": SubmittedD, "user2": DeclinedD},
+			want:   OutcomePending,
+		},
+		{
+			name:   "Majority becomes unreachable",
+			policy: DecisionPolicy{Rule: RuleMajority, Percent: 51},
+			votes:  map[string]Decision{"user2": DeclinedD, "user3": DeclinedD},
+			want:   OutcomeRejected,
+		},
+		{
+			name:   "Weighted lead decides majority",
+			policy: DecisionPolicy{Rule: RuleMajority, Percent: 50, Weights: map[string]int{"lead": 3}},
+			votes:  map[string]Decision{"user1": SubmittedD},
+			want:   OutcomeApproved,
+		},
+		{
+			name:   "Unanimous needs everyone",
+			policy: DecisionPolicy{Rule: RuleUnanimous},
+			votes:  map[string]Decision{"user1": SubmittedD, "user2": SubmittedD, "user3": SubmittedD},
+			want:   OutcomePending,
+		},
+		{
+			name:   "Only lead can veto",
+			policy: DecisionPolicy{Rule: RuleMajority, Percent: 50, Veto: true, VetoRoles: []string{"lead"}},
+			votes:  map[string]Decision{"user2": DeclinedD},
+			want:   OutcomePending,
+		},
+		{
+			name:   "Lead veto",
+			policy: DecisionPolicy{Rule: RuleMajority, Percent: 50, Veto: true, VetoRoles: []string{"lead"}},
+			votes:  map[string]Decision{"user1": DeclinedD, "user2": SubmittedD, "user3": SubmittedD},
+			want:   OutcomeRejected,
+		},
+		{
+			name:   "No voter carries weight",
+			policy: DecisionPolicy{Rule: RuleMajority, Percent: 50, Weights: map[string]int{"lead": 0, DefaultRole: 0}},
+			votes:  map[string]Decision{"user1": SubmittedD, "user2": SubmittedD},
+			want:   OutcomeRejected,
+		},
+		{
+			name:   "Votes of outsiders are ignored",
+			policy: DecisionPolicy{Rule: RuleQuorum, Quorum: 1},
+			votes:  map[string]Decision{"user9": SubmittedD},
+			want:   OutcomePending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Evaluate(voters, tt.votes))
+		})
+	}
+}
+
+func TestDecisionPolicyValidate(t *testing.T) {
+	assert.NoError(t, DefaultDecisionPolicy.Validate())
+	assert.Error(t, DecisionPolicy{Rule: "RANDOM"}.Validate())
+	assert.Error(t, DecisionPolicy{Rule: RuleMajority}.Validate())
+	assert.Error(t, DecisionPolicy{Rule: RuleQuorum, VetoRoles: []string{"lead"}}.Validate())
+}
+
+func TestBallotTally(t *testing.T) {
+	ballot := Ballot{
+		Policy: DecisionPolicy{Rule: RuleQuorum, Quorum: 2},
+		Voters: []Voter{{Username: "user1"}, {Username: "user2"}, {Username: "user3"}},
+		Votes: []BidDecision{
+			{Username: "user1", DecisionStatus: SubmittedD},
+			// Голоса тех, кто не отвечает за организацию, не учитываются
+			{Username: "user9", DecisionStatus: SubmittedD},
+		},
+	}
+	assert.Equal(t, Tally{Approvals: 1, Total: 3, Required: 2, Outcome: OutcomePending}, ballot.Tally())
+	assert.Equal(t, 1, ballot.Tally().Remaining())
+
+	ballot.Votes = append(ballot.Votes, BidDecision{Username: "user2", DecisionStatus: SubmittedD})
+	assert.Equal(t, OutcomeApproved, ballot.Tally().Outcome)
+	assert.Equal(t, 0, ballot.Tally().Remaining())
+
+	// Без голосующих с весом голосование не зависает в ожидании
+	ballot.Policy.Weights = map[string]int{DefaultRole: 0}
+	ballot.Voters = []Voter{{Username: "user1", Role: DefaultRole}, {Username: "user2", Role: DefaultRole}}
+	assert.Equal(t, OutcomeRejected, ballot.Tally().Outcome)
+}
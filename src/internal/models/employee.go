@@ -3,10 +3,22 @@ package models
 import "time"
 
 type Employee struct {
-	ID        int       `json:"id"`
-	Username  string    `json:"username" validate:"required"`
-	FirstName string    `json:"first_name" validate:"required"`
-	LastName  string    `json:"last_name" validate:"required"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID           int       `json:"id"`
+	Username     string    `json:"username" validate:"required,max=50"`
+	FirstName    string    `json:"first_name" validate:"required,max=50"`
+	LastName     string    `json:"last_name" validate:"required,max=50"`
+	PasswordHash string    `json:"-"`
+	IsAdmin      bool      `json:"is_admin"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// EmployeeUpdate - изменяемые поля сотрудника, nil - поле не меняется.
+// Пароль передается уже захешированным.
+type EmployeeUpdate struct {
+	FirstName    *string
+	LastName     *string
+	PasswordHash *string
+	// Назначить или снять администратора может только администратор
+	IsAdmin *bool
 }
@@ -0,0 +1,39 @@
+package models
+
+// EventType - тип события активности (см. миграцию 20_activity)
+type EventType string
+
+const (
+	TenderStatusChanged EventType = "TenderStatusChanged"
+	TenderEdited        EventType = "TenderEdited"
+	BidCreated          EventType = "BidCreated"
+	BidStatusChanged    EventType = "BidStatusChanged"
+	BidEdited           EventType = "BidEdited"
+	DecisionChanged     EventType = "DecisionChanged"
+	ReviewAdded         EventType = "ReviewAdded"
+	AuctionUpdated      EventType = "AuctionUpdated"
+	// Resync - соединение с базой восстановлено, события за время обрыва могли
+	// потеряться: подписчику нужно перечитать состояние
+	Resync EventType = "Resync"
+)
+
+// Event - событие активности по тендеру или предложению. Содержимого событие не
+// несет, только идентификаторы, статус и версию: за ним клиент идет в API.
+type Event struct {
+	Type     EventType `json:"type"`
+	TenderID int       `json:"tenderId"`
+	BidID    *int      `json:"bidId"`
+	// Статус в формате базы, задан для событий смены статуса и создания
+	Status  string `json:"status"`
+	Version int    `json:"version"`
+}
+
+// EventScope - на что подписан получатель событий
+type EventScope int
+
+const (
+	// TenderEvents - события одного тендера и его предложений
+	TenderEvents EventScope = iota
+	// OwnBidEvents - события предложений, автором которых является пользователь
+	OwnBidEvents
+)
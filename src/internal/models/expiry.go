@@ -0,0 +1,12 @@
+package models
+
+// ExpiryReport - итог прохода планировщика по истекшим срокам
+type ExpiryReport struct {
+	// Skipped - проход выполняет другая реплика
+	Skipped bool
+	// ClosedTenders - закрыто без победителя, AwardedTenders - закрыто с победителем
+	ClosedTenders  int
+	AwardedTenders int
+	// ExpiredBids - отклонено предложений, решение по которым не принято в срок
+	ExpiredBids int
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+// TenderVisibility определяет, кому виден опубликованный тендер.
+// Созданный тендер при любой видимости видят только ответственные организации.
+type TenderVisibility string
+
+const (
+	// PublicTender видят все пользователи
+	PublicTender TenderVisibility = "PUBLIC"
+	// InviteOnlyTender видят приглашенные пользователи и ответственные приглашенных организаций
+	InviteOnlyTender TenderVisibility = "INVITE_ONLY"
+	// InternalTender видят только ответственные организации тендера
+	InternalTender TenderVisibility = "INTERNAL"
+)
+
+var tenderVisibilityAPI = map[TenderVisibility]string{
+	PublicTender:     "Public",
+	InviteOnlyTender: "InviteOnly",
+	InternalTender:   "Internal",
+}
+
+// API возвращает видимость в том виде, в котором она передается в API
+func (v TenderVisibility) API() string {
+	if api, ok := tenderVisibilityAPI[v]; ok {
+		return api
+	}
+	return string(v)
+}
+
+// ParseTenderVisibility принимает видимость как в формате API (InviteOnly), так и в формате базы (INVITE_ONLY)
+func ParseTenderVisibility(s string) (TenderVisibility, bool) {
+	for visibility, api := range tenderVisibilityAPI {
+		if s == api || s == string(visibility) {
+			return visibility, true
+		}
+	}
+	return "", false
+}
+
+// Invitation приглашает к тендеру организацию или отдельного пользователя
+type Invitation struct {
+	ID             int       `json:"id" gorm:"primaryKey"`
+	TenderID       int       `json:"tenderId"`
+	OrganizationID *int      `json:"organizationId"`
+	Username       *string   `json:"username"`
+	InvitedBy      string    `json:"invitedBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Validate проверяет, что приглашен ровно один адресат
+func (i Invitation) Validate() error {
+	if (i.OrganizationID == nil) == (i.Username == nil) {
+		return apperr.Invalid("Invitation must name either an organization or a user")
+	}
+	return nil
+}
@@ -6,15 +6,24 @@ type OrganizationType string
 
 const (
 	IE  OrganizationType = "IE"
-	LLS OrganizationType = "LLS"
+	LLC OrganizationType = "LLC"
 	JSC OrganizationType = "JSC"
 )
 
 type Organization struct {
 	ID          int              `json:"id"`
-	Name        string           `json:"name" validate:"required"`
-	Description string           `json:"description" validate:"required"`
-	Type        OrganizationType `json:"type" validate:"required"`
+	Name        string           `json:"name" validate:"required,max=100"`
+	Description string           `json:"description" validate:"required,max=500"`
+	Type        OrganizationType `json:"type" validate:"required,oneof=IE LLC JSC"`
 	CreatedAt   time.Time        `json:"created_at"`
 	UpdatedAt   time.Time        `json:"updated_at"`
+	// Правила голосования для новых тендеров организации, nil - DefaultDecisionPolicy
+	DecisionPolicy *DecisionPolicy `json:"decisionPolicy" gorm:"serializer:json"`
+}
+
+// OrganizationUpdate - изменяемые поля организации, nil - поле не меняется
+type OrganizationUpdate struct {
+	Name        *string           `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string           `json:"description" validate:"omitempty,min=1,max=500"`
+	Type        *OrganizationType `json:"type" validate:"omitempty,oneof=IE LLC JSC"`
 }
@@ -4,4 +4,12 @@ type OrganizationResponsible struct {
 	ID              int `json:"id"`
 	Organization_ID int `json:"organization_id"`
 	User_ID         int `json:"user_id"`
+	// Role определяет вес голоса в DecisionPolicy
+	Role string `json:"role"`
+}
+
+// Responsible - ответственный за организацию вместе с данными сотрудника
+type Responsible struct {
+	Employee Employee
+	Role     string
 }
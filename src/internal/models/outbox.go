@@ -0,0 +1,130 @@
+package models
+
+import "time"
+
+// DomainEventType - тип доменного события для внешних систем. В отличие от
+// событий активности (EventType), доменные события пишутся в outbox и
+// доставляются не менее одного раза.
+type DomainEventType string
+
+const (
+	TenderCreatedEvent           DomainEventType = "TenderCreated"
+	TenderPublishedEvent         DomainEventType = "TenderPublished"
+	TenderClosedEvent            DomainEventType = "TenderClosed"
+	TenderCancelledEvent         DomainEventType = "TenderCancelled"
+	TenderArchivedEvent          DomainEventType = "TenderArchived"
+	TenderEditedEvent            DomainEventType = "TenderEdited"
+	TenderRolledBackEvent        DomainEventType = "TenderRolledBack"
+	TenderAwardedEvent           DomainEventType = "TenderAwarded"
+	TenderPolicyChangedEvent     DomainEventType = "TenderPolicyChanged"
+	TenderVisibilityChangedEvent DomainEventType = "TenderVisibilityChanged"
+	TenderCriteriaChangedEvent   DomainEventType = "TenderCriteriaChanged"
+	InvitationSentEvent          DomainEventType = "InvitationSent"
+	InvitationRevokedEvent       DomainEventType = "InvitationRevoked"
+	QuestionAskedEvent           DomainEventType = "QuestionAsked"
+	QuestionAnsweredEvent        DomainEventType = "QuestionAnswered"
+	RoundOpenedEvent             DomainEventType = "RoundOpened"
+	AuctionOpenedEvent           DomainEventType = "AuctionOpened"
+
+	BidCreatedEvent           DomainEventType = "BidCreated"
+	BidPublishedEvent         DomainEventType = "BidPublished"
+	BidCanceledEvent          DomainEventType = "BidCanceled"
+	BidSubmittedEvent         DomainEventType = "BidSubmitted"
+	BidDeclinedEvent          DomainEventType = "BidDeclined"
+	BidEditedEvent            DomainEventType = "BidEdited"
+	BidRolledBackEvent        DomainEventType = "BidRolledBack"
+	DecisionMadeEvent         DomainEventType = "DecisionMade"
+	DecisionRetractedEvent    DomainEventType = "DecisionRetracted"
+	BidScoredEvent            DomainEventType = "BidScored"
+	ReviewAddedEvent          DomainEventType = "ReviewAdded"
+	AuctionOfferAcceptedEvent DomainEventType = "AuctionOfferAccepted"
+
+	OrganizationCreatedEvent       DomainEventType = "OrganizationCreated"
+	OrganizationEditedEvent        DomainEventType = "OrganizationEdited"
+	OrganizationDeletedEvent       DomainEventType = "OrganizationDeleted"
+	OrganizationPolicyChangedEvent DomainEventType = "OrganizationPolicyChanged"
+	ResponsibleAssignedEvent       DomainEventType = "ResponsibleAssigned"
+	ResponsibleRemovedEvent        DomainEventType = "ResponsibleRemoved"
+
+	EmployeeCreatedEvent DomainEventType = "EmployeeCreated"
+	EmployeeEditedEvent  DomainEventType = "EmployeeEdited"
+	EmployeeDeletedEvent DomainEventType = "EmployeeDeleted"
+)
+
+// Агрегаты - сущности, к которым относятся доменные события
+const (
+	TenderAggregate       = "tender"
+	BidAggregate          = "bid"
+	OrganizationAggregate = "organization"
+	EmployeeAggregate     = "employee"
+)
+
+// Смена статуса публикуется событием, названным по новому статусу
+var (
+	tenderStatusEvents = map[TenderStatus]DomainEventType{
+		PublishedT: TenderPublishedEvent,
+		ClosedT:    TenderClosedEvent,
+		CancelledT: TenderCancelledEvent,
+		ArchivedT:  TenderArchivedEvent,
+	}
+	bidStatusEvents = map[BidStatus]DomainEventType{
+		PublishedB: BidPublishedEvent,
+		CanceledB:  BidCanceledEvent,
+		SubmittedB: BidSubmittedEvent,
+		DeclinedB:  BidDeclinedEvent,
+	}
+)
+
+// DomainEvent - доменное событие в outbox. Data содержит идентификаторы и
+// изменившиеся значения, но не содержимое тендеров и предложений: за ним
+// получатель идет в API.
+type DomainEvent struct {
+	ID          int                    `json:"id" gorm:"primaryKey"`
+	Type        DomainEventType        `json:"type"`
+	Aggregate   string                 `json:"aggregate"`
+	AggregateID int                    `json:"aggregateId"`
+	Actor       string                 `json:"actor"`
+	Data        map[string]interface{} `json:"data" gorm:"serializer:json"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	// Состояние доставки
+	Attempts      int        `json:"-"`
+	NextAttemptAt time.Time  `json:"-"`
+	DeliveredAt   *time.Time `json:"-"`
+	LastError     string     `json:"-"`
+}
+
+// NewDomainEvent создает событие агрегата; пустой actor - изменение выполнил планировщик
+func NewDomainEvent(t DomainEventType, aggregate string, id int, actor string, data map[string]interface{}) DomainEvent {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return DomainEvent{Type: t, Aggregate: aggregate, AggregateID: id, Actor: actor, Data: data}
+}
+
+// TenderEvent создает событие тендера
+func TenderEvent(t DomainEventType, tenderID int, actor string, data map[string]interface{}) DomainEvent {
+	return NewDomainEvent(t, TenderAggregate, tenderID, actor, data)
+}
+
+// BidEvent создает событие предложения; в данные добавляется тендер предложения
+func BidEvent(t DomainEventType, bid Bid, actor string, data map[string]interface{}) DomainEvent {
+	event := NewDomainEvent(t, BidAggregate, bid.ID, actor, data)
+	event.Data["tenderId"] = bid.TenderID
+	return event
+}
+
+// TenderStatusEvent создает событие перехода тендера в статус to
+func TenderStatusEvent(tender Tender, to TenderStatus, actor string) DomainEvent {
+	return TenderEvent(tenderStatusEvents[to], tender.ID, actor, map[string]interface{}{
+		"from": tender.Status.API(),
+		"to":   to.API(),
+	})
+}
+
+// BidStatusEvent создает событие перехода предложения в статус to
+func BidStatusEvent(bid Bid, to BidStatus, actor string) DomainEvent {
+	return BidEvent(bidStatusEvents[to], bid, actor, map[string]interface{}{
+		"from": bid.Status.API(),
+		"to":   to.API(),
+	})
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusEvents(t *testing.T) {
+	tender := Tender{ID: 7, Status: PublishedT}
+	event := TenderStatusEvent(tender, ClosedT, "user1")
+	assert.Equal(t, TenderClosedEvent, event.Type)
+	assert.Equal(t, TenderAggregate, event.Aggregate)
+	assert.Equal(t, 7, event.AggregateID)
+	assert.Equal(t, map[string]interface{}{"from": PublishedT.API(), "to": ClosedT.API()}, event.Data)
+
+	bid := Bid{ID: 3, TenderID: 7, Status: PublishedB}
+	event = BidStatusEvent(bid, DeclinedB, "")
+	assert.Equal(t, BidDeclinedEvent, event.Type)
+	assert.Equal(t, BidAggregate, event.Aggregate)
+	assert.Equal(t, 3, event.AggregateID)
+	assert.Equal(t, 7, event.Data["tenderId"])
+	assert.Empty(t, event.Actor)
+
+	// У каждого статуса, в который возможен переход, есть событие
+	for _, to := range []TenderStatus{PublishedT, ClosedT, CancelledT, ArchivedT} {
+		assert.NotEmpty(t, TenderStatusEvent(tender, to, "").Type, to)
+	}
+	for _, to := range []BidStatus{PublishedB, CanceledB, SubmittedB, DeclinedB} {
+		assert.NotEmpty(t, BidStatusEvent(bid, to, "").Type, to)
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+const (
+	DefaultPageLimit = 5
+	MaxPageLimit     = 50
+)
+
+// ListParams - параметры постраничной выборки, фильтрации и сортировки списков
+type ListParams struct {
+	Limit        int
+	Offset       int
+	Cursor       string
+	ServiceTypes []string
+	Statuses     []string
+	SortBy       string
+	Desc         bool
+}
+
+// Page - сведения о странице, которые не помещаются в тело ответа
+type Page struct {
+	Total      int64
+	NextCursor string
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+type Currency string
+
+// Поддерживаемые валюты, у всех две цифры после запятой
+var currencies = map[Currency]bool{
+	"RUB": true,
+	"USD": true,
+	"EUR": true,
+	"CNY": true,
+}
+
+// Amount - сумма в минимальных единицах валюты (копейках, центах).
+// В JSON передается десятичной строкой: "1500.50".
+type Amount int64
+
+func (a Amount) String() string {
+	sign := ""
+	if a < 0 {
+		sign, a = "-", -a
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, a/100, a%100)
+}
+
+// ParseAmount принимает сумму с не более чем двумя цифрами после точки
+func ParseAmount(s string) (Amount, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > 2 || strings.HasPrefix(whole, "-") || strings.HasPrefix(whole, "+") {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	cents := int64(0)
+	if frac != "" {
+		if cents, err = strconv.ParseInt(frac, 10, 64); err != nil || strings.HasPrefix(frac, "-") {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		if len(frac) == 1 {
+			cents *= 10
+		}
+	}
+	if units > (1<<63-1-cents)/100 {
+		return 0, fmt.Errorf("amount %q is too large", s)
+	}
+	return Amount(units*100 + cents), nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+// UnmarshalJSON принимает и строку, и число: "1500.50" или 1500.50
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	v, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = v
+	return nil
+}
+
+// Money - сумма в валюте. Нулевое значение означает, что сумма не задана.
+type Money struct {
+	Amount   Amount   `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func (m Money) IsZero() bool {
+	return m.Currency == "" && m.Amount == 0
+}
+
+func (m Money) String() string {
+	return m.Amount.String() + " " + string(m.Currency)
+}
+
+func (m Money) Validate(field string) error {
+	if !currencies[m.Currency] {
+		return apperr.Invalid("%s currency must be one of: RUB, USD, EUR, CNY", field)
+	}
+	if m.Amount <= 0 {
+		return apperr.Invalid("%s must be positive", field)
+	}
+	return nil
+}
+
+// RequiredItem - позиция, на которую должно ответить каждое предложение тендера
+type RequiredItem struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// LineItem - позиция предложения. Code ссылается на обязательную позицию тендера,
+// у дополнительных позиций он может быть пустым.
+type LineItem struct {
+	Code         string `json:"code,omitempty"`
+	Name         string `json:"name"`
+	Quantity     int    `json:"quantity"`
+	UnitPrice    Amount `json:"unitPrice"`
+	DeliveryDays int    `json:"deliveryDays,omitempty"`
+}
+
+// Total возвращает стоимость позиции; false - стоимость не помещается в Amount.
+// Количество и цена должны быть неотрицательными.
+func (i LineItem) Total() (Amount, bool) {
+	if i.Quantity > 0 && i.UnitPrice > math.MaxInt64/Amount(i.Quantity) {
+		return 0, false
+	}
+	return i.UnitPrice * Amount(i.Quantity), true
+}
+
+// ValidatePricing проверяет бюджет и обязательные позиции тендера
+func (t Tender) ValidatePricing() error {
+	if !t.Budget.IsZero() {
+		if err := t.Budget.Validate("budget"); err != nil {
+			return err
+		}
+	}
+	codes := make(map[string]bool, len(t.RequiredItems))
+	for _, item := range t.RequiredItems {
+		if item.Code == "" || item.Name == "" || item.Quantity <= 0 {
+			return apperr.Invalid("required item must have code, name and positive quantity")
+		}
+		if codes[item.Code] {
+			return apperr.Invalid("duplicate required item %s", item.Code)
+		}
+		codes[item.Code] = true
+	}
+	return nil
+}
+
+// ValidatePricing проверяет цену и позиции предложения. Если позиции заданы,
+// цена должна совпадать с их суммой.
+func (b Bid) ValidatePricing() error {
+	if b.DeliveryDays < 0 {
+		return apperr.Invalid("deliveryDays must be non-negative")
+	}
+	if len(b.Items) > 0 && b.Price.IsZero() {
+		return apperr.Invalid("price is required when items are given")
+	}
+	if b.Price.IsZero() {
+		return nil
+	}
+	if err := b.Price.Validate("price"); err != nil {
+		return err
+	}
+
+	codes := make(map[string]bool, len(b.Items))
+	var total Amount
+	for _, item := range b.Items {
+		if item.Name == "" || item.Quantity <= 0 || item.UnitPrice < 0 || item.DeliveryDays < 0 {
+			return apperr.Invalid("item must have name, positive quantity and non-negative price")
+		}
+		if item.Code != "" {
+			if codes[item.Code] {
+				return apperr.Invalid("duplicate item %s", item.Code)
+			}
+			codes[item.Code] = true
+		}
+		cost, ok := item.Total()
+		if !ok || total > math.MaxInt64-cost {
+			return apperr.Invalid("items total is too large")
+		}
+		total += cost
+	}
+	if len(b.Items) > 0 && total != b.Price.Amount {
+		return apperr.Invalid("price %s does not match items total %s", b.Price.Amount, total)
+	}
+	return nil
+}
+
+// CheckBid проверяет, что предложение укладывается в бюджет тендера
+// и отвечает на каждую обязательную позицию
+func (t Tender) CheckBid(b Bid) error {
+	if !t.Budget.IsZero() {
+		if b.Price.IsZero() {
+			return apperr.Invalid("Tender %d requires a price", t.ID)
+		}
+		if b.Price.Currency != t.Budget.Currency {
+			return apperr.Invalid("Bid currency must be %s", t.Budget.Currency)
+		}
+		if b.Price.Amount > t.Budget.Amount {
+			return apperr.Invalid("Bid price %s exceeds tender budget %s", b.Price, t.Budget)
+		}
+	}
+
+	offered := make(map[string]int, len(b.Items))
+	for _, item := range b.Items {
+		offered[item.Code] += item.Quantity
+	}
+	for _, required := range t.RequiredItems {
+		if offered[required.Code] < required.Quantity {
+			return apperr.Invalid("Bid must offer %d of required item %s", required.Quantity, required.Code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Amount
+		str  string
+		ok   bool
+	}{
+		{in: "1500", want: 150000, str: "1500.00", ok: true},
+		{in: "1500.5", want: 150050, str: "1500.50", ok: true},
+		{in: "1500.05", want: 150005, str: "1500.05", ok: true},
+		{in: "0.99", want: 99, str: "0.99", ok: true},
+		{in: "1500.005"},
+		{in: "-1"},
+		{in: "1.-5"},
+		{in: ".5"},
+		{in: "abc"},
+		{in: "92233720368547758.08"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in)
+			if !tt.ok {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.str, got.String())
+		})
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	var m Money
+	assert.NoError(t, json.Unmarshal([]byte(`{"amount":1500.5,"currency":"RUB"}`), &m))
+	assert.Equal(t, Money{Amount: 150050, Currency: "RUB"}, m)
+
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"1500.50","currency":"RUB"}`, string(data))
+}
+
+func TestBidPricing(t *testing.T) {
+	rub := func(a Amount) Money { return Money{Amount: a, Currency: "RUB"} }
+	tender := Tender{
+		ID:            1,
+		Budget:        rub(100000),
+		RequiredItems: []RequiredItem{{Code: "pipe", Name: "Pipe", Quantity: 10}},
+	}
+	items := []LineItem{
+		{Code: "pipe", Name: "Pipe", Quantity: 10, UnitPrice: 5000},
+		{Name: "Installation", Quantity: 1, UnitPrice: 20000},
+	}
+
+	tests := []struct {
+		name    string
+		bid     Bid
+		invalid bool
+		reject  bool
+	}{
+		{name: "Priced bid covering required items", bid: Bid{Price: rub(70000), Items: items}},
+		{name: "Price does not match items", bid: Bid{Price: rub(60000), Items: items}, invalid: true},
+		{name: "Items without price", bid: Bid{Items: items}, invalid: true},
+		{name: "Unknown currency", bid: Bid{Price: Money{Amount: 100, Currency: "XXX"}}, invalid: true},
+		{name: "Amount without currency", bid: Bid{Price: Money{Amount: 100}}, invalid: true},
+		{name: "Over budget", bid: Bid{Price: rub(100001)}, reject: true},
+		{name: "Other currency", bid: Bid{Price: Money{Amount: 100, Currency: "USD"}}, reject: true},
+		{name: "Missing required item", bid: Bid{Price: rub(20000), Items: items[1:]}, reject: true},
+		{name: "Not enough of required item", bid: Bid{Price: rub(45000), Items: []LineItem{{Code: "pipe", Name: "Pipe", Quantity: 9, UnitPrice: 5000}}}, reject: true},
+		{name: "Unpriced bid", bid: Bid{}, reject: true},
+		{name: "Item total overflows", bid: Bid{Price: rub(1), Items: []LineItem{{Name: "Pipe", Quantity: 3, UnitPrice: math.MaxInt64 / 2}}}, invalid: true},
+		{name: "Items sum overflows", bid: Bid{Price: rub(1), Items: []LineItem{{Name: "Pipe", Quantity: 1, UnitPrice: math.MaxInt64}, {Name: "Valve", Quantity: 1, UnitPrice: 1}}}, invalid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.bid.ValidatePricing()
+			if tt.invalid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.reject {
+				assert.Error(t, tender.CheckBid(tt.bid))
+			} else {
+				assert.NoError(t, tender.CheckBid(tt.bid))
+			}
+		})
+	}
+
+	// Тендер без бюджета и обязательных позиций принимает любое предложение
+	assert.NoError(t, Tender{}.CheckBid(Bid{}))
+}
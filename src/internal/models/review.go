@@ -1,18 +1,16 @@
 package models
 
+import "time"
+
 type Review struct {
-	ID             int    `json:"id" gorm:"primaryKey"`
-	BidID          int    `json:"bidId" validate:"bidId"`
-	Username       string `json:"username" validate:"required"`
-	OrganizationID int    `json:"organizationId" validate:"required"`
-	Comment        string `json:"comment" validate:"required"`
+	ID             int       `json:"id" gorm:"primaryKey"`
+	BidID          int       `json:"bidId" validate:"bidId"`
+	Username       string    `json:"username" validate:"required"`
+	OrganizationID int       `json:"organizationId" validate:"required"`
+	Comment        string    `json:"comment" validate:"required"`
+	CreatedAt      time.Time `json:"createdAt"`
 }
 
 /*
-{
-	"bidId": "1",
-	"username": "user2",
-	"organizationId": "1",
-    "comment": "Good job!"
-}
+PUT /api/bids/1/feedback?bidFeedback=Good%20job!
 */
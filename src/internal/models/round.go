@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+// Question - вопрос участника к тендеру. Ответ видят все, кому виден тендер,
+// сам вопрос без ответа - только его автор и ответственные организации.
+type Question struct {
+	ID         int        `json:"id" gorm:"primaryKey"`
+	TenderID   int        `json:"tenderId"`
+	Text       string     `json:"question" gorm:"column:question"`
+	AskedBy    string     `json:"askedBy"`
+	Answer     *string    `json:"answer"`
+	AnsweredBy *string    `json:"answeredBy"`
+	AnsweredAt *time.Time `json:"answeredAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// Answered сообщает, опубликован ли ответ на вопрос
+func (q Question) Answered() bool {
+	return q.Answer != nil
+}
+
+// Round - раунд пересмотра предложений (best and final offer): предложения
+// из шорт-листа можно менять до Deadline, остальные отклоняются при открытии раунда
+type Round struct {
+	ID        int       `json:"id" gorm:"primaryKey"`
+	TenderID  int       `json:"tenderId"`
+	Number    int       `json:"number"`
+	Deadline  time.Time `json:"deadline"`
+	OpenedBy  string    `json:"openedBy"`
+	CreatedAt time.Time `json:"createdAt"`
+	// Шорт-лист: предложения, пересматриваемые в раунде
+	BidIDs []int `json:"bidIds" gorm:"-"`
+}
+
+// RoundRequest - параметры открытия следующего раунда
+type RoundRequest struct {
+	BidIDs   []int
+	Deadline time.Time
+}
+
+// Validate проверяет шорт-лист и срок раунда относительно момента now
+func (r RoundRequest) Validate(tender Tender, now time.Time) error {
+	if len(r.BidIDs) == 0 {
+		return apperr.Invalid("Shortlist must contain at least one bid")
+	}
+	if !r.Deadline.After(now) {
+		return apperr.Invalid("Round deadline must be in the future")
+	}
+	// Голоса сбрасываются при открытии раунда, а голосовать можно только после
+	// его окончания: без срока решения позже раунда итоги подвелись бы без голосования
+	if tender.DecisionDeadline == nil || !tender.DecisionDeadline.After(r.Deadline) {
+		return apperr.Invalid("Round deadline must be earlier than decisionDeadline")
+	}
+	return nil
+}
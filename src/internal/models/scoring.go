@@ -0,0 +1,161 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+// MaxScore - наибольшая оценка по критерию, наименьшая - 0
+const MaxScore = 10
+
+// Criterion - критерий оценки предложений тендера (цена, опыт, сроки, качество...)
+type Criterion struct {
+	ID       int    `json:"-" gorm:"primaryKey"`
+	TenderID int    `json:"-"`
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Weight   int    `json:"weight"`
+}
+
+func ValidateCriteria(criteria []Criterion) error {
+	if len(criteria) == 0 {
+		return apperr.Invalid("at least one criterion is required")
+	}
+	codes := make(map[string]bool, len(criteria))
+	for _, c := range criteria {
+		if c.Code == "" || len(c.Code) > 50 || c.Name == "" || len([]rune(c.Name)) > 100 {
+			return apperr.Invalid("criterion must have code and name")
+		}
+		if c.Weight <= 0 {
+			return apperr.Invalid("weight of criterion %s must be positive", c.Code)
+		}
+		if codes[c.Code] {
+			return apperr.Invalid("duplicate criterion %s", c.Code)
+		}
+		codes[c.Code] = true
+	}
+	return nil
+}
+
+// Score - оценка предложения ответственным по одному критерию
+type Score struct {
+	ID          int `json:"-" gorm:"primaryKey"`
+	BidID       int `json:"bidId"`
+	CriterionID int `json:"-"`
+	// Criterion - код критерия, только для чтения
+	Criterion string    `json:"criterion" gorm:"->"`
+	Username  string    `json:"username"`
+	Value     int       `json:"score" gorm:"column:score"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewScores сопоставляет оценки по кодам критериев с критериями тендера
+func NewScores(criteria []Criterion, bidID int, username string, values map[string]int) ([]Score, error) {
+	if len(criteria) == 0 {
+		return nil, apperr.Conflict("Tender has no evaluation criteria")
+	}
+	if len(values) == 0 {
+		return nil, apperr.Invalid("at least one score is required")
+	}
+	byCode := make(map[string]Criterion, len(criteria))
+	for _, c := range criteria {
+		byCode[c.Code] = c
+	}
+	scores := make([]Score, 0, len(values))
+	for code, value := range values {
+		c, ok := byCode[code]
+		if !ok {
+			return nil, apperr.Invalid("unknown criterion %s", code)
+		}
+		if value < 0 || value > MaxScore {
+			return nil, apperr.Invalid("score for %s must be between 0 and %d", code, MaxScore)
+		}
+		scores = append(scores, Score{BidID: bidID, CriterionID: c.ID, Username: username, Value: value})
+	}
+	// Порядок оценок не зависит от порядка обхода map
+	sort.Slice(scores, func(i, j int) bool { return scores[i].CriterionID < scores[j].CriterionID })
+	return scores, nil
+}
+
+// Standing - место предложения в рейтинге тендера
+type Standing struct {
+	Bid Bid
+	// Averages - средняя оценка по коду критерия, только для оцененных критериев
+	Averages  map[string]float64
+	Reviewers int
+	// Total - взвешенная оценка от 0 до MaxScore; неоцененный критерий дает 0
+	Total float64
+	// Complete - оценены все критерии
+	Complete bool
+}
+
+// Rank строит рейтинг предложений по взвешенной сумме средних оценок.
+// При равенстве выше стоит предложение с оценками по всем критериям, затем поданное раньше.
+func Rank(criteria []Criterion, bids []Bid, scores []Score) []Standing {
+	totalWeight := 0
+	byID := make(map[int]Criterion, len(criteria))
+	for _, c := range criteria {
+		totalWeight += c.Weight
+		byID[c.ID] = c
+	}
+
+	type sum struct{ total, count int }
+	sums := make(map[int]map[string]*sum, len(bids))
+	reviewers := make(map[int]map[string]bool, len(bids))
+	for _, s := range scores {
+		c, ok := byID[s.CriterionID]
+		if !ok {
+			continue
+		}
+		if sums[s.BidID] == nil {
+			sums[s.BidID] = map[string]*sum{}
+			reviewers[s.BidID] = map[string]bool{}
+		}
+		if sums[s.BidID][c.Code] == nil {
+			sums[s.BidID][c.Code] = &sum{}
+		}
+		sums[s.BidID][c.Code].total += s.Value
+		sums[s.BidID][c.Code].count++
+		reviewers[s.BidID][s.Username] = true
+	}
+
+	standings := make([]Standing, 0, len(bids))
+	for _, b := range bids {
+		st := Standing{Bid: b, Averages: map[string]float64{}, Reviewers: len(reviewers[b.ID])}
+		weighted := 0.0
+		for _, c := range criteria {
+			s := sums[b.ID][c.Code]
+			if s == nil {
+				continue
+			}
+			avg := float64(s.total) / float64(s.count)
+			st.Averages[c.Code] = avg
+			weighted += avg * float64(c.Weight)
+		}
+		if totalWeight > 0 {
+			st.Total = weighted / float64(totalWeight)
+		}
+		st.Complete = len(criteria) > 0 && len(st.Averages) == len(criteria)
+		standings = append(standings, st)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		a, b := standings[i], standings[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		if a.Complete != b.Complete {
+			return a.Complete
+		}
+		return a.Bid.ID < b.Bid.ID
+	})
+	return standings
+}
+
+// Leaderboard - критерии тендера и рейтинг его предложений
+type Leaderboard struct {
+	Criteria  []Criterion
+	Standings []Standing
+}
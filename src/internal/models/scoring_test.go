@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewScores(t *testing.T) {
+	criteria := []Criterion{
+		{ID: 1, Code: "price", Name: "Price", Weight: 3},
+		{ID: 2, Code: "quality", Name: "Quality", Weight: 1},
+	}
+	tests := []struct {
+		name     string
+		criteria []Criterion
+		values   map[string]int
+		ok       bool
+	}{
+		{name: "valid", criteria: criteria, values: map[string]int{"quality": 7, "price": 10}, ok: true},
+		{name: "no criteria", values: map[string]int{"price": 5}},
+		{name: "empty", criteria: criteria, values: map[string]int{}},
+		{name: "unknown criterion", criteria: criteria, values: map[string]int{"speed": 5}},
+		{name: "out of range", criteria: criteria, values: map[string]int{"price": 11}},
+		{name: "negative", criteria: criteria, values: map[string]int{"price": -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores, err := NewScores(tt.criteria, 5, "user1", tt.values)
+			if !tt.ok {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []Score{
+				{BidID: 5, CriterionID: 1, Username: "user1", Value: 10},
+				{BidID: 5, CriterionID: 2, Username: "user1", Value: 7},
+			}, scores)
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	criteria := []Criterion{
+		{ID: 1, Code: "price", Weight: 3},
+		{ID: 2, Code: "quality", Weight: 1},
+	}
+	bids := []Bid{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	scores := []Score{
+		// Предложение 1: price 6, quality 10 -> (18 + 10) / 4 = 7
+		{BidID: 1, CriterionID: 1, Username: "user1", Value: 4},
+		{BidID: 1, CriterionID: 1, Username: "user2", Value: 8},
+		{BidID: 1, CriterionID: 2, Username: "user1", Value: 10},
+		// Предложение 2: только price 9 -> 27 / 4 = 6.75
+		{BidID: 2, CriterionID: 1, Username: "user1", Value: 9},
+		// Предложение 3: price 8, quality 4 -> (24 + 4) / 4 = 7, оценено полностью, как и 1
+		{BidID: 3, CriterionID: 1, Username: "user2", Value: 8},
+		{BidID: 3, CriterionID: 2, Username: "user2", Value: 4},
+		// Оценка по чужому критерию не учитывается
+		{BidID: 4, CriterionID: 99, Username: "user1", Value: 10},
+	}
+
+	standings := Rank(criteria, bids, scores)
+	var order []int
+	for _, st := range standings {
+		order = append(order, st.Bid.ID)
+	}
+	assert.Equal(t, []int{1, 3, 2, 4}, order)
+
+	assert.Equal(t, map[string]float64{"price": 6, "quality": 10}, standings[0].Averages)
+	assert.Equal(t, 7.0, standings[0].Total)
+	assert.Equal(t, 2, standings[0].Reviewers)
+	assert.True(t, standings[0].Complete)
+
+	assert.Equal(t, 6.75, standings[2].Total)
+	assert.False(t, standings[2].Complete)
+
+	assert.Empty(t, standings[3].Averages)
+	assert.Zero(t, standings[3].Total)
+	assert.Zero(t, standings[3].Reviewers)
+}
@@ -1,30 +1,222 @@
 package models
 
+import (
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
 type TenderStatus string
 
+// CREATED играет роль черновика: такой тендер видят только ответственные организации
 const (
 	CreatedT   TenderStatus = "CREATED"
 	PublishedT TenderStatus = "PUBLISHED"
 	ClosedT    TenderStatus = "CLOSED"
+	CancelledT TenderStatus = "CANCELLED"
+	ArchivedT  TenderStatus = "ARCHIVED"
 )
 
+// Значения статусов в API (openapi.yml) отличаются от хранимых в базе
+var tenderStatusAPI = map[TenderStatus]string{
+	CreatedT:   "Created",
+	PublishedT: "Published",
+	ClosedT:    "Closed",
+	CancelledT: "Cancelled",
+	ArchivedT:  "Archived",
+}
+
+// tenderTransitions - допустимые переходы жизненного цикла тендера
+var tenderTransitions = map[TenderStatus][]TenderStatus{
+	CreatedT:   {PublishedT, CancelledT},
+	PublishedT: {ClosedT, CancelledT},
+	ClosedT:    {ArchivedT},
+	CancelledT: {ArchivedT},
+}
+
+// CanTransitionTo сообщает, можно ли перевести тендер из статуса s в статус to
+func (s TenderStatus) CanTransitionTo(to TenderStatus) bool {
+	for _, next := range tenderTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition проверяет переход и возвращает *TransitionError, если он недопустим
+func (s TenderStatus) Transition(to TenderStatus) error {
+	if !s.CanTransitionTo(to) {
+		return &TransitionError{Entity: "tender", From: s.API(), To: to.API()}
+	}
+	return nil
+}
+
+// Editable сообщает, можно ли менять содержимое тендера в этом статусе
+func (s TenderStatus) Editable() bool {
+	return s == CreatedT || s == PublishedT
+}
+
+// Final сообщает, что тендер больше не принимает предложения и решения
+func (s TenderStatus) Final() bool {
+	return s != CreatedT && s != PublishedT
+}
+
+// API возвращает статус в том виде, в котором он описан в спецификации
+func (s TenderStatus) API() string {
+	if v, ok := tenderStatusAPI[s]; ok {
+		return v
+	}
+	return string(s)
+}
+
+// ParseTenderStatus принимает статус как в формате API (Published), так и в формате базы (PUBLISHED)
+func ParseTenderStatus(s string) (TenderStatus, bool) {
+	for status, api := range tenderStatusAPI {
+		if s == api || s == string(status) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
 type Tender struct {
 	ID              int          `json:"id" gorm:"primaryKey"`
-	Name            string       `json:"name" gorm:"not null" validate:"required"`
-	Description     string       `json:"description" validate:"required"`
-	ServiceType     string       `json:"serviceType" validate:"required"`
+	Name            string       `json:"name" gorm:"not null" validate:"required,max=100"`
+	Description     string       `json:"description" validate:"required,max=500"`
+	ServiceType     string       `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
 	Status          TenderStatus `json:"status"`
 	OrganizationID  int          `json:"organizationId" gorm:"not null" validate:"required"`
 	CreatorUsername string       `json:"creatorUsername" validate:"required"`
 	Version         int          `json:"version"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	// Правила голосования фиксируются при создании тендера
+	DecisionPolicy *DecisionPolicy `json:"decisionPolicy" gorm:"serializer:json"`
+	// Срок подачи предложений и срок принятия решения, nil - без срока
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline"`
+	// Бюджет и обязательные позиции задаются при создании тендера
+	Budget        Money          `json:"budget" gorm:"embedded;embeddedPrefix:budget_"`
+	RequiredItems []RequiredItem `json:"requiredItems" gorm:"serializer:json"`
+	// Видимость меняется отдельно от содержимого и не входит в версию
+	Visibility TenderVisibility `json:"visibility" gorm:"default:PUBLIC"`
+	// Sealed - закрытый прием предложений: до окончания подачи их содержимое
+	// видят только авторы. Задается при создании и требует срока подачи.
+	Sealed bool `json:"sealed"`
+	// Round - текущий раунд: 1 - прием предложений, следующие - пересмотр
+	// предложений из шорт-листа до RoundDeadline
+	Round         int        `json:"round" gorm:"default:1"`
+	RoundDeadline *time.Time `json:"roundDeadline"`
+}
+
+// ActivePolicy возвращает правила голосования тендера или правила по умолчанию
+func (t Tender) ActivePolicy() DecisionPolicy {
+	if t.DecisionPolicy != nil {
+		return *t.DecisionPolicy
+	}
+	return DefaultDecisionPolicy
+}
+
+// SubmissionOpen сообщает, принимаются ли предложения в момент now.
+// Новые предложения принимаются только в первом раунде.
+func (t Tender) SubmissionOpen(now time.Time) bool {
+	if t.Round > 1 {
+		return false
+	}
+	return t.SubmissionDeadline == nil || now.Before(*t.SubmissionDeadline)
+}
+
+// RevisionOpen сообщает, можно ли в момент now менять содержимое предложений:
+// в первом раунде - до окончания подачи, в следующих - до окончания раунда
+func (t Tender) RevisionOpen(now time.Time) bool {
+	if t.Round > 1 {
+		return t.RoundDeadline != nil && now.Before(*t.RoundDeadline)
+	}
+	return t.SubmissionOpen(now)
+}
+
+// InRevisionRound сообщает, что в момент now идет пересмотр предложений из
+// шорт-листа: до его окончания по ним нельзя голосовать
+func (t Tender) InRevisionRound(now time.Time) bool {
+	return t.Round > 1 && t.RevisionOpen(now)
+}
+
+// RevisionDeadline - окончание текущего раунда, nil - без срока
+func (t Tender) RevisionDeadline() *time.Time {
+	if t.Round > 1 {
+		return t.RoundDeadline
+	}
+	return t.SubmissionDeadline
+}
+
+// BidsSealed сообщает, скрыто ли в момент now содержимое предложений от всех, кроме
+// их авторов. Содержимое всех предложений раскрывается одновременно, в момент
+// окончания раунда: до него предложения нельзя оценивать и голосовать по ним.
+func (t Tender) BidsSealed(now time.Time) bool {
+	return t.Sealed && t.RevisionOpen(now)
+}
+
+// ClosingDeadline - момент, когда планировщик подводит итоги тендера: срок
+// принятия решения, а если он не задан - окончание подачи. По закрытому тендеру и
+// в раундах пересмотра голосуют только после окончания раунда, поэтому без срока
+// решения их итоги автоматически не подводятся.
+func (t Tender) ClosingDeadline() *time.Time {
+	if t.DecisionDeadline != nil {
+		return t.DecisionDeadline
+	}
+	if t.Sealed || t.Round > 1 {
+		return nil
+	}
+	return t.SubmissionDeadline
+}
+
+// SubmissionsFinal сообщает, что в момент now набор предложений опубликованного
+// тендера уже не изменится: подача окончена и раунд пересмотра не идет
+func (t Tender) SubmissionsFinal(now time.Time) bool {
+	return t.Status == PublishedT && !t.RevisionOpen(now)
+}
+
+// DueForClosing сообщает, пора ли планировщику подвести итоги тендера в момент now
+func (t Tender) DueForClosing(now time.Time) bool {
+	deadline := t.ClosingDeadline()
+	return t.Status == PublishedT && deadline != nil && !deadline.After(now)
+}
+
+// ValidateDeadlines проверяет, что решение принимается не раньше окончания подачи
+// предложений, а по закрытому тендеру - строго после их раскрытия
+func (t Tender) ValidateDeadlines() error {
+	if t.SubmissionDeadline != nil && t.DecisionDeadline != nil && t.DecisionDeadline.Before(*t.SubmissionDeadline) {
+		return apperr.Invalid("decisionDeadline must not be earlier than submissionDeadline")
+	}
+	if t.Sealed && t.SubmissionDeadline == nil {
+		return apperr.Invalid("Sealed tender requires submissionDeadline")
+	}
+	if t.Sealed && (t.DecisionDeadline == nil || !t.DecisionDeadline.After(*t.SubmissionDeadline)) {
+		return apperr.Invalid("Sealed tender requires decisionDeadline after submissionDeadline")
+	}
+	if t.Round > 1 && t.RoundDeadline != nil && (t.DecisionDeadline == nil || !t.DecisionDeadline.After(*t.RoundDeadline)) {
+		return apperr.Invalid("decisionDeadline must be later than the end of round %d", t.Round)
+	}
+	return nil
+}
+
+// TenderUpdate - частичное изменение тендера, nil-поля остаются без изменений
+type TenderUpdate struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,min=1,max=500"`
+	ServiceType *string `json:"serviceType" validate:"omitempty,oneof=Construction Delivery Manufacture"`
+	// Сроки можно перенести, но не снять
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline"`
 }
 
 /*
 {
     "name": "FIRST TENDER",
     "description": "NEW",
-    "serviceType": "it",
-    "organizationId": 1,
+    "serviceType": "Construction",
+    "status": "Created",
+    "organizationId": "1",
     "creatorUsername": "user1"
 }
 */
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type TenderHistory struct {
 	ID              int          `json:"id" gorm:"primaryKey"`
 	TenderID        int          `json:"tenderID"`
@@ -10,4 +12,13 @@ type TenderHistory struct {
 	OrganizationID  int          `json:"organizationId" gorm:"not null"`
 	CreatorUsername string       `json:"creatorUsername"`
 	Version         int          `json:"version"`
+	// Сроки тендера входят в версию и возвращаются откатом
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline"`
+	// Правила голосования входят в версию, nil - правила по умолчанию
+	DecisionPolicy *DecisionPolicy `json:"decisionPolicy" gorm:"serializer:json"`
+	// Вложения на момент версии, откат возвращает их
+	Attachments []Attachment `json:"attachments" gorm:"serializer:json"`
+	ChangedBy   string       `json:"changedBy"`
+	CreatedAt   time.Time    `json:"createdAt"`
 }
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTenderTransitions(t *testing.T) {
+	tests := []struct {
+		from, to TenderStatus
+		allowed  bool
+	}{
+		{from: CreatedT, to: PublishedT, allowed: true},
+		{from: CreatedT, to: CancelledT, allowed: true},
+		{from: CreatedT, to: ClosedT},
+		{from: PublishedT, to: ClosedT, allowed: true},
+		{from: PublishedT, to: CreatedT},
+		{from: ClosedT, to: PublishedT},
+		{from: ClosedT, to: ArchivedT, allowed: true},
+		{from: CancelledT, to: ArchivedT, allowed: true},
+		{from: ArchivedT, to: PublishedT},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestTenderDeadlines(t *testing.T) {
+	submission := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	decision := submission.Add(24 * time.Hour)
+
+	tender := Tender{SubmissionDeadline: &submission}
+	assert.True(t, tender.SubmissionOpen(submission.Add(-time.Second)))
+	assert.False(t, tender.SubmissionOpen(submission))
+	assert.Equal(t, &submission, tender.ClosingDeadline())
+	assert.True(t, Tender{}.SubmissionOpen(submission))
+	assert.Nil(t, Tender{}.ClosingDeadline())
+
+	// Победитель выбирается автоматически только после окончания подачи
+	published := Tender{Status: PublishedT, SubmissionDeadline: &submission}
+	assert.False(t, published.SubmissionsFinal(submission.Add(-time.Second)))
+	assert.True(t, published.SubmissionsFinal(submission))
+	assert.False(t, Tender{Status: PublishedT}.SubmissionsFinal(submission))
+
+	tender.DecisionDeadline = &decision
+	assert.Equal(t, &decision, tender.ClosingDeadline())
+	assert.NoError(t, tender.ValidateDeadlines())
+
+	tender.DecisionDeadline = &submission
+	assert.NoError(t, tender.ValidateDeadlines())
+
+	early := submission.Add(-time.Hour)
+	tender.DecisionDeadline = &early
+	assert.Error(t, tender.ValidateDeadlines())
+}
+
+func TestSealedBids(t *testing.T) {
+	submission := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	decision := submission.Add(24 * time.Hour)
+
+	tender := Tender{Sealed: true, SubmissionDeadline: &submission, DecisionDeadline: &decision}
+	assert.NoError(t, tender.ValidateDeadlines())
+	assert.True(t, tender.BidsSealed(submission.Add(-time.Second)))
+	// Все предложения раскрываются одновременно с окончанием подачи
+	assert.False(t, tender.BidsSealed(submission))
+	assert.False(t, Tender{SubmissionDeadline: &submission}.BidsSealed(submission.Add(-time.Second)))
+	assert.Error(t, Tender{Sealed: true}.ValidateDeadlines())
+	// Голосовать можно только после раскрытия, поэтому срок решения обязателен и позже подачи
+	assert.Error(t, Tender{Sealed: true, SubmissionDeadline: &submission}.ValidateDeadlines())
+	assert.Error(t, Tender{Sealed: true, SubmissionDeadline: &submission, DecisionDeadline: &submission}.ValidateDeadlines())
+	// Без срока решения итоги закрытого тендера по окончании подачи не подводятся
+	published := Tender{Status: PublishedT, Sealed: true, SubmissionDeadline: &submission}
+	assert.Nil(t, published.ClosingDeadline())
+	assert.False(t, published.DueForClosing(submission.Add(time.Hour)))
+
+	orgID := 2
+	bid := Bid{ID: 1, Name: "bid", Description: "terms", Status: PublishedB, OrganizationID: &orgID, AuthorType: OrganizationAuthor,
+		Price: Money{Amount: 150000, Currency: "RUB"}, DeliveryDays: 10, Items: []LineItem{{Code: "pipe", Quantity: 1}}}
+	sealed := bid.Seal()
+	assert.Equal(t, Bid{ID: 1, Status: PublishedB, OrganizationID: &orgID, AuthorType: OrganizationAuthor, Sealed: true}, sealed)
+	assert.False(t, bid.Sealed)
+}
+
+func TestRevisionRounds(t *testing.T) {
+	submission := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	roundEnd := submission.Add(72 * time.Hour)
+
+	tender := Tender{Round: 1, SubmissionDeadline: &submission}
+	assert.True(t, tender.RevisionOpen(submission.Add(-time.Second)))
+	assert.False(t, tender.InRevisionRound(submission.Add(-time.Second)))
+
+	// Во втором раунде новые предложения не принимаются, а пересмотр идет до конца раунда
+	tender.Round, tender.RoundDeadline = 2, &roundEnd
+	assert.False(t, tender.SubmissionOpen(submission.Add(-time.Second)))
+	assert.True(t, tender.RevisionOpen(submission.Add(time.Hour)))
+	assert.True(t, tender.InRevisionRound(submission.Add(time.Hour)))
+	assert.False(t, tender.RevisionOpen(roundEnd))
+	tender.Status = PublishedT
+	assert.False(t, tender.SubmissionsFinal(submission.Add(time.Hour)))
+	assert.True(t, tender.SubmissionsFinal(roundEnd))
+	// Голоса сброшены, а голосовать можно только после раунда: без срока решения
+	// итоги в конце раунда не подводятся, а сам срок обязателен и позже раунда
+	assert.Nil(t, tender.ClosingDeadline())
+	assert.Error(t, tender.ValidateDeadlines())
+	decision := roundEnd.Add(24 * time.Hour)
+	tender.DecisionDeadline = &decision
+	assert.NoError(t, tender.ValidateDeadlines())
+	assert.Equal(t, &decision, tender.ClosingDeadline())
+	tender.DecisionDeadline = &roundEnd
+	assert.Error(t, tender.ValidateDeadlines())
+
+	tender.Sealed = true
+	assert.True(t, tender.BidsSealed(submission.Add(time.Hour)))
+	assert.False(t, tender.BidsSealed(roundEnd))
+
+	early := roundEnd.Add(-time.Hour)
+	tender.DecisionDeadline = &early
+	assert.Error(t, tender.ValidateDeadlines())
+
+	request := RoundRequest{BidIDs: []int{1}, Deadline: roundEnd}
+	withDecision := Tender{DecisionDeadline: &decision}
+	assert.NoError(t, request.Validate(withDecision, submission))
+	assert.Error(t, request.Validate(withDecision, roundEnd))
+	assert.Error(t, request.Validate(Tender{DecisionDeadline: &early}, submission))
+	assert.Error(t, request.Validate(Tender{DecisionDeadline: &roundEnd}, submission))
+	assert.Error(t, request.Validate(Tender{}, submission))
+	assert.Error(t, RoundRequest{Deadline: roundEnd}.Validate(withDecision, submission))
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// TenderTransition - запись журнала смены статусов тендера.
+// У записи о создании тендера исходный статус пустой.
+type TenderTransition struct {
+	ID         int           `json:"id" gorm:"primaryKey"`
+	TenderID   int           `json:"tenderId"`
+	FromStatus *TenderStatus `json:"fromStatus"`
+	ToStatus   TenderStatus  `json:"toStatus"`
+	Username   string        `json:"username"`
+	Reason     string        `json:"reason"`
+	CreatedAt  time.Time     `json:"createdAt"`
+}
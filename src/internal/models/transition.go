@@ -0,0 +1,22 @@
+package models
+
+import (
+	"fmt"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+)
+
+// TransitionError - недопустимая смена статуса. Относится к категории apperr.ErrConflict (409).
+type TransitionError struct {
+	Entity string
+	From   string
+	To     string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("Cannot change %s status from %s to %s", e.Entity, e.From, e.To)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return apperr.ErrConflict
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Version - снимок содержимого тендера или предложения. Fields хранит
+// редактируемые поля под именами из API (name, description, serviceType).
+type Version struct {
+	Version   int
+	Fields    map[string]string
+	ChangedBy string
+	CreatedAt time.Time
+}
+
+// FieldChange - изменение одного поля между версиями
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// Diff возвращает изменения полей от версии prev к версии next в алфавитном порядке
+func Diff(prev, next Version) []FieldChange {
+	keys := make([]string, 0, len(next.Fields))
+	for k := range next.Fields {
+		keys = append(keys, k)
+	}
+	for k := range prev.Fields {
+		if _, ok := next.Fields[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	changes := []FieldChange{}
+	for _, k := range keys {
+		if prev.Fields[k] != next.Fields[k] {
+			changes = append(changes, FieldChange{Field: k, From: prev.Fields[k], To: next.Fields[k]})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+)
+
+// BasePath - префикс, под которым API описано в спецификации
+const BasePath = "/api"
+
+// Load читает спецификацию и проверяет ее корректность. Примеры в задании
+// содержат опечатки, поэтому их проверка отключена.
+func Load(path string) (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+	if err := doc.Validate(context.Background(), openapi3.DisableExamplesValidation()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	// Сервер из спецификации привязан к localhost:8080, сопоставляем только путь
+	doc.Servers = openapi3.Servers{{URL: BasePath}}
+	return doc, nil
+}
+
+// Validator проверяет запросы и ответы на соответствие спецификации
+type Validator struct {
+	router routers.Router
+	log    zerolog.Logger
+}
+
+func New(doc *openapi3.T, zlog zerolog.Logger) (*Validator, error) {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build openapi router: %w", err)
+	}
+	return &Validator{router: router, log: zlog}, nil
+}
+
+// Middleware отклоняет запросы, не соответствующие спецификации, с кодом 400,
+// а ответы, нарушающие контракт, заменяет ошибкой 500. Маршруты, которых нет
+// в спецификации, пропускаются без проверки.
+func (v *Validator) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, pathParams, err := v.router.FindRoute(ctx.Request)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    ctx.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// Токен проверяет AuthMiddleware
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		if err := openapi3filter.ValidateRequest(ctx.Request.Context(), input); err != nil {
+			v.log.Debug().Err(err).Str("operation", route.Operation.OperationID).Msg("Request violates API contract")
+			// Первая строка без дампа схемы
+			reason, _, _ := strings.Cut(err.Error(), "\n")
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"reason": reason})
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+		ctx.Next()
+		ctx.Writer = w.ResponseWriter
+
+		status, body := w.Status(), w.body.Bytes()
+		resp := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 status,
+			Header:                 w.Header(),
+		}
+		resp.SetBodyBytes(body)
+		if err := openapi3filter.ValidateResponse(ctx.Request.Context(), resp); err != nil {
+			v.log.Error().Err(err).Str("operation", route.Operation.OperationID).Msg("Response violates API contract")
+			status = http.StatusInternalServerError
+			body, _ = json.Marshal(gin.H{"reason": "response does not match API contract"})
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		}
+		w.ResponseWriter.WriteHeader(status)
+		w.ResponseWriter.WriteHeaderNow()
+		_, _ = w.ResponseWriter.Write(body)
+	}
+}
+
+// bufferedWriter придерживает ответ обработчика до окончания проверки
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// Параметры доставки
+const (
+	batchSize = 10
+	// deliveryTimeout ограничивает доставку одного события одному получателю
+	deliveryTimeout = webhookTimeout
+	// leaseMargin - запас аренды сверх худшего времени доставки пачки
+	leaseMargin = 30 * time.Second
+	// Повторы: baseDelay, удваиваясь с каждой попыткой, но не больше maxDelay
+	baseDelay = 5 * time.Second
+	maxDelay  = time.Hour
+	// Доставленные события хранятся retention и затем удаляются
+	retention = 7 * 24 * time.Hour
+)
+
+// Store - outbox доменных событий. Реализация гарантирует, что одно событие
+// не выдается двум репликам одновременно.
+type Store interface {
+	ClaimEvents(limit int, lease time.Duration) ([]models.DomainEvent, error)
+	AckEvent(id int) error
+	RetryEvent(id int, delay time.Duration, reason string) error
+	PurgeEvents(age time.Duration) (int, error)
+}
+
+// Relay доставляет события из outbox получателям не менее одного раза: событие
+// подтверждается только после доставки всем получателям, а при ошибке любого
+// из них повторяется целиком.
+type Relay struct {
+	store    Store
+	sinks    []Sink
+	interval time.Duration
+	log      *zerolog.Logger
+	timeout  time.Duration
+}
+
+func New(store Store, sinks []Sink, interval time.Duration, log *zerolog.Logger) *Relay {
+	return &Relay{
+		store:    store,
+		sinks:    sinks,
+		interval: interval,
+		log:      log,
+		timeout:  deliveryTimeout,
+	}
+}
+
+// lease - на сколько забирается пачка. Каждая доставка ограничена timeout, поэтому
+// пачка успевает разойтись по всем получателям до конца аренды, и другая реплика
+// не выдает повторно события, которые еще доставляются.
+func (r *Relay) lease() time.Duration {
+	return time.Duration(batchSize*len(r.sinks))*r.timeout + leaseMargin
+}
+
+// Run выполняет проходы с заданным интервалом, пока не отменен ctx
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.tick(ctx)
+		}
+	}
+}
+
+// tick разбирает outbox пачками, пока в нем есть события, готовые к доставке
+func (r *Relay) tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, err := r.store.ClaimEvents(batchSize, r.lease())
+		if err != nil {
+			r.log.Error().Err(err).Msg("Outbox claim failed")
+			return
+		}
+		for _, event := range events {
+			r.deliver(ctx, event)
+		}
+		if len(events) < batchSize {
+			break
+		}
+	}
+
+	purged, err := r.store.PurgeEvents(retention)
+	if err != nil {
+		r.log.Error().Err(err).Msg("Outbox purge failed")
+	} else if purged > 0 {
+		r.log.Debug().Int("events", purged).Msg("Delivered events purged")
+	}
+}
+
+func (r *Relay) deliver(ctx context.Context, event models.DomainEvent) {
+	var errs []error
+	for _, sink := range r.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		err := sink.Deliver(sinkCtx, event)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		delay := backoff(event.Attempts)
+		r.log.Warn().Err(err).
+			Int("event_id", event.ID).
+			Str("event_type", string(event.Type)).
+			Int("attempts", event.Attempts).
+			Dur("retry_in", delay).
+			Msg("Event delivery failed")
+		if err := r.store.RetryEvent(event.ID, delay, err.Error()); err != nil {
+			r.log.Error().Err(err).Int("event_id", event.ID).Msg("Outbox retry failed")
+		}
+		return
+	}
+	// Если подтверждение не записалось, событие доставится повторно после lease
+	if err := r.store.AckEvent(event.ID); err != nil {
+		r.log.Error().Err(err).Int("event_id", event.ID).Msg("Outbox ack failed")
+	}
+}
+
+// backoff возвращает задержку перед следующей попыткой после attempts попыток
+func backoff(attempts int) time.Duration {
+	delay := baseDelay
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,176 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type retry struct {
+	id    int
+	delay time.Duration
+}
+
+type fakeStore struct {
+	mu      sync.Mutex
+	pending []models.DomainEvent
+	acked   []int
+	retried []retry
+	purged  int
+	leases  []time.Duration
+}
+
+func (f *fakeStore) ClaimEvents(limit int, lease time.Duration) ([]models.DomainEvent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.leases = append(f.leases, lease)
+	n := min(limit, len(f.pending))
+	events := f.pending[:n]
+	f.pending = f.pending[n:]
+	for i := range events {
+		events[i].Attempts++
+	}
+	return events, nil
+}
+
+func (f *fakeStore) AckEvent(id int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.acked = append(f.acked, id)
+	return nil
+}
+
+func (f *fakeStore) RetryEvent(id int, delay time.Duration, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.retried = append(f.retried, retry{id: id, delay: delay})
+	return nil
+}
+
+func (f *fakeStore) PurgeEvents(time.Duration) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.purged++
+	return 0, nil
+}
+
+type fakeSink struct {
+	mu        sync.Mutex
+	delivered []int
+	// fail - события, доставка которых завершается ошибкой
+	fail map[int]bool
+	// hang - доставка ждет отмены контекста
+	hang bool
+}
+
+func (f *fakeSink) Name() string { return "fake" }
+
+func (f *fakeSink) Deliver(ctx context.Context, event models.DomainEvent) error {
+	if f.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.fail[event.ID] {
+		return errors.New("unavailable")
+	}
+	f.delivered = append(f.delivered, event.ID)
+	return nil
+}
+
+func TestRelayTick(t *testing.T) {
+	zlog := zerolog.New(os.Stdout)
+
+	events := func(n int, attempts int) []models.DomainEvent {
+		result := make([]models.DomainEvent, n)
+		for i := range result {
+			result[i] = models.DomainEvent{ID: i + 1, Type: models.TenderCreatedEvent, Attempts: attempts}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		pending     []models.DomainEvent
+		fail        map[int]bool
+		wantAcked   int
+		wantRetried []retry
+	}{
+		{name: "All delivered", pending: events(3, 0), wantAcked: 3},
+		// Пачки забираются, пока outbox не опустеет
+		{name: "Several batches", pending: events(batchSize+5, 0), wantAcked: batchSize + 5},
+		{
+			name:        "Failed delivery is retried",
+			pending:     events(2, 0),
+			fail:        map[int]bool{2: true},
+			wantAcked:   1,
+			wantRetried: []retry{{id: 2, delay: baseDelay}},
+		},
+		{
+			name:        "Retry delay grows with attempts",
+			pending:     events(1, 2),
+			fail:        map[int]bool{1: true},
+			wantRetried: []retry{{id: 1, delay: 4 * baseDelay}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{pending: tt.pending}
+			good := &fakeSink{}
+			flaky := &fakeSink{fail: tt.fail}
+			r := New(store, []Sink{good, flaky}, time.Second, &zlog)
+
+			r.tick(context.Background())
+
+			assert.Len(t, store.acked, tt.wantAcked)
+			assert.Equal(t, tt.wantRetried, store.retried)
+			assert.Len(t, good.delivered, len(tt.pending), "healthy sinks get every event")
+			assert.Equal(t, 1, store.purged)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 5 * time.Second},
+		{attempts: 2, want: 10 * time.Second},
+		{attempts: 4, want: 40 * time.Second},
+		{attempts: 30, want: time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, backoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
+
+// Аренда пачки покрывает худшее время ее доставки: зависший получатель
+// прерывается по таймауту, и событие не успевает выдаться другой реплике
+func TestRelayLease(t *testing.T) {
+	zlog := zerolog.New(os.Stdout)
+	events := []models.DomainEvent{{ID: 1, Type: models.TenderCreatedEvent}, {ID: 2, Type: models.TenderCreatedEvent}}
+	store := &fakeStore{pending: events}
+	r := New(store, []Sink{&fakeSink{}, &fakeSink{hang: true}}, time.Second, &zlog)
+	r.timeout = 10 * time.Millisecond
+
+	start := time.Now()
+	r.tick(context.Background())
+	elapsed := time.Since(start)
+
+	assert.Len(t, store.retried, 2)
+	assert.Equal(t, []time.Duration{batchSize*2*r.timeout + leaseMargin}, store.leases)
+	assert.Less(t, elapsed, store.leases[0])
+
+	// Для настоящего таймаута аренда не меньше худшего времени доставки пачки
+	r = New(store, make([]Sink, 3), time.Second, &zlog)
+	assert.Greater(t, r.lease(), batchSize*3*deliveryTimeout)
+}
@@ -0,0 +1,140 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+// webhookTimeout ограничивает один запрос к webhook
+const webhookTimeout = 10 * time.Second
+
+// Sink - получатель доменных событий. Deliver может получить одно событие
+// несколько раз: получатель отбрасывает повторы по id события.
+type Sink interface {
+	Name() string
+	Deliver(ctx context.Context, event models.DomainEvent) error
+}
+
+// ParseSinks создает получателей по описаниям вида stdout, file:<путь>, webhook:<url>
+func ParseSinks(specs []string) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(specs))
+	for _, spec := range specs {
+		kind, target, _ := strings.Cut(spec, ":")
+		switch kind {
+		case "stdout":
+			sinks = append(sinks, NewWriterSink("stdout", os.Stdout))
+		case "file":
+			if target == "" {
+				return nil, fmt.Errorf("file sink requires a path")
+			}
+			sink, err := NewFileSink(target)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		case "webhook":
+			if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+				return nil, fmt.Errorf("webhook sink requires an http(s) url, got %q", target)
+			}
+			sinks = append(sinks, NewWebhookSink(target))
+		default:
+			return nil, fmt.Errorf("unknown sink %q", spec)
+		}
+	}
+	return sinks, nil
+}
+
+// WriterSink пишет события строками JSON
+type WriterSink struct {
+	name string
+	mu   sync.Mutex
+	w    io.Writer
+	// sync сбрасывает запись на диск, nil - не требуется
+	sync func() error
+}
+
+func NewWriterSink(name string, w io.Writer) *WriterSink {
+	return &WriterSink{name: name, w: w}
+}
+
+// NewFileSink дописывает события в файл path
+func NewFileSink(path string) (*WriterSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event file: %w", err)
+	}
+	return &WriterSink{name: "file:" + path, w: file, sync: file.Sync}, nil
+}
+
+func (s *WriterSink) Name() string {
+	return s.name
+}
+
+func (s *WriterSink) Deliver(_ context.Context, event models.DomainEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	if s.sync != nil {
+		if err := s.sync(); err != nil {
+			return fmt.Errorf("failed to sync event file: %w", err)
+		}
+	}
+	return nil
+}
+
+// WebhookSink отправляет события POST-запросом с JSON. Доставка успешна при
+// ответе 2xx.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook:" + s.url
+}
+
+func (s *WebhookSink) Deliver(ctx context.Context, event models.DomainEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", strconv.Itoa(event.ID))
+	req.Header.Set("X-Event-Type", string(event.Type))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSinks(t *testing.T) {
+	tests := []struct {
+		name      string
+		specs     []string
+		wantNames []string
+		wantErr   bool
+	}{
+		{name: "Stdout and webhook", specs: []string{"stdout", "webhook:http://localhost:9000/events"}, wantNames: []string{"stdout", "webhook:http://localhost:9000/events"}},
+		{name: "File", specs: []string{"file:" + t.TempDir() + "/events.log"}, wantNames: []string{"file:"}},
+		{name: "Unknown kind", specs: []string{"kafka:events"}, wantErr: true},
+		{name: "Webhook without url", specs: []string{"webhook:events"}, wantErr: true},
+		{name: "File without path", specs: []string{"file:"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks, err := ParseSinks(tt.specs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sinks, len(tt.wantNames))
+			for i, sink := range sinks {
+				assert.Contains(t, sink.Name(), tt.wantNames[i])
+			}
+		})
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink("buffer", &buf)
+	event := models.TenderEvent(models.TenderCreatedEvent, 7, "user1", map[string]interface{}{"serviceType": "Delivery"})
+	event.ID = 1
+
+	require.NoError(t, sink.Deliver(context.Background(), event))
+	require.NoError(t, sink.Deliver(context.Background(), event))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var got models.DomainEvent
+	require.NoError(t, json.Unmarshal(lines[0], &got))
+	assert.Equal(t, models.TenderCreatedEvent, got.Type)
+	assert.Equal(t, 7, got.AggregateID)
+	assert.Equal(t, "Delivery", got.Data["serviceType"])
+}
+
+func TestWebhookSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "Accepted", status: http.StatusNoContent},
+		{name: "Rejected", status: http.StatusServiceUnavailable, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got models.DomainEvent
+			var headers http.Header
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header
+				_ = json.NewDecoder(r.Body).Decode(&got)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			event := models.BidEvent(models.BidDeclinedEvent, models.Bid{ID: 3, TenderID: 7}, "", nil)
+			event.ID = 42
+			err := NewWebhookSink(srv.URL).Deliver(context.Background(), event)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "42", headers.Get("X-Event-Id"))
+			assert.Equal(t, "BidDeclined", headers.Get("X-Event-Type"))
+			assert.Equal(t, 3, got.AggregateID)
+			assert.EqualValues(t, 7, got.Data["tenderId"])
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// attachmentTarget - колонка ссылки на владельца в таблице attachment и права
+// на просмотр и изменение вложений: они совпадают с правами на сам тендер или предложение
+type attachmentTarget struct {
+	column   string
+	view     authz.Action
+	edit     authz.Action
+	resource authz.Resource
+}
+
+func targetOf(owner models.AttachmentOwner) (attachmentTarget, error) {
+	switch owner.Kind {
+	case models.TenderAttachments:
+		return attachmentTarget{tenderVersions.key, authz.ActionViewTender, authz.ActionEditTender, authz.Tender(owner.ID)}, nil
+	case models.BidAttachments:
+		return attachmentTarget{bidVersions.key, authz.ActionViewBid, authz.ActionEditBid, authz.Bid(owner.ID)}, nil
+	}
+	return attachmentTarget{}, fmt.Errorf("unknown attachment owner %q", owner.Kind)
+}
+
+// AddAttachment сохраняет вложение и создает новую версию владельца. put записывает
+// содержимое в BlobStore: он вызывается после проверки прав и ограничений, до фиксации
+// транзакции. Если транзакция не зафиксирована, в хранилище остается блоб без описания.
+func (db *DBstorage) AddAttachment(owner models.AttachmentOwner, attachment models.Attachment, put func(context.Context) error, username string, expectedVersion int) (models.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	target, err := targetOf(owner)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if err := db.authorize(ctx, username, target.edit, target.resource); err != nil {
+		return models.Attachment{}, err
+	}
+
+	attachment.ID = 0
+	attachment.TenderID, attachment.BidID = nil, nil
+	if owner.Kind == models.TenderAttachments {
+		attachment.TenderID = &owner.ID
+	} else {
+		attachment.BidID = &owner.ID
+	}
+	attachment.UploadedBy = username
+
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		return tx.changeAttachments(ctx, owner, username, expectedVersion, func() error {
+			var count int64
+			if err := tx.conn.WithContext(ctx).
+				Table("attachment").
+				Where(target.column+" = ?", owner.ID).
+				Count(&count).Error; err != nil {
+				return fmt.Errorf("failed to count attachments: %w", err)
+			}
+			if count >= models.MaxAttachments {
+				return apperr.Conflict("No more than %d attachments are allowed", models.MaxAttachments)
+			}
+			if err := put(ctx); err != nil {
+				return fmt.Errorf("failed to store attachment content: %w", err)
+			}
+			if err := tx.conn.WithContext(ctx).
+				Table("attachment").
+				Omit("id", "created_at").
+				Create(&attachment).Error; err != nil {
+				return fmt.Errorf("failed to save attachment: %w", err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	return db.getAttachment(ctx, target.column, owner.ID, attachment.ID)
+}
+
+// DeleteAttachment удаляет описание вложения и создает новую версию владельца.
+// Содержимое остается в BlobStore: на него ссылаются снимки прежних версий.
+func (db *DBstorage) DeleteAttachment(owner models.AttachmentOwner, attachmentID int, username string, expectedVersion int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	target, err := targetOf(owner)
+	if err != nil {
+		return err
+	}
+	if err := db.authorize(ctx, username, target.edit, target.resource); err != nil {
+		return err
+	}
+
+	return db.unitOfWork(ctx, func(tx *DBstorage) error {
+		return tx.changeAttachments(ctx, owner, username, expectedVersion, func() error {
+			query := tx.conn.WithContext(ctx).
+				Table("attachment").
+				Where("id = ? AND "+target.column+" = ?", attachmentID, owner.ID).
+				Delete(&models.Attachment{})
+			if query.Error != nil {
+				return fmt.Errorf("failed to delete attachment: %w", query.Error)
+			}
+			if query.RowsAffected == 0 {
+				return apperr.NotFound("Attachment %d not found", attachmentID)
+			}
+			return nil
+		})
+	})
+}
+
+// changeAttachments блокирует владельца, проверяет версию и статус, выполняет fn
+// и фиксирует новую версию со снимком вложений. Вызывается внутри unitOfWork.
+// Колонки владельца не меняются, событие правки сообщает об изменении вложений.
+func (db *DBstorage) changeAttachments(ctx context.Context, owner models.AttachmentOwner, username string, expectedVersion int, fn func() error) error {
+	switch owner.Kind {
+	case models.TenderAttachments:
+		current, err := db.lockTender(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		if err := tenderVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", owner.ID, current.Status.API())
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+		_, err = tenderVersions.apply(ctx, db, current, nil, username, tenderVersions.edited, attachmentsChanged())
+		return err
+	case models.BidAttachments:
+		current, err := db.lockBid(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		if err := bidVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Bid %d cannot be edited in status %s", owner.ID, current.Status.API())
+		}
+		tender, err := db.tenderOfBid(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		if err := checkRevisable(tender, current, time.Now()); err != nil {
+			return err
+		}
+		if err := fn(); err != nil {
+			return err
+		}
+		_, err = bidVersions.apply(ctx, db, current, nil, username, bidVersions.edited, attachmentsChanged())
+		return err
+	}
+	return fmt.Errorf("unknown attachment owner %q", owner.Kind)
+}
+
+func (db *DBstorage) GetAttachments(owner models.AttachmentOwner, username string) ([]models.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	target, err := targetOf(owner)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.authorize(ctx, username, target.view, target.resource); err != nil {
+		return nil, err
+	}
+	if owner.Kind == models.BidAttachments {
+		if err := db.checkUnsealed(ctx, owner.ID, username); err != nil {
+			return nil, err
+		}
+	}
+	return db.attachments(ctx, target.column, owner.ID)
+}
+
+func (db *DBstorage) GetAttachment(owner models.AttachmentOwner, attachmentID int, username string) (models.Attachment, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	target, err := targetOf(owner)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if err := db.authorize(ctx, username, target.view, target.resource); err != nil {
+		return models.Attachment{}, err
+	}
+	if owner.Kind == models.BidAttachments {
+		if err := db.checkUnsealed(ctx, owner.ID, username); err != nil {
+			return models.Attachment{}, err
+		}
+	}
+	return db.getAttachment(ctx, target.column, owner.ID, attachmentID)
+}
+
+func (db *DBstorage) getAttachment(ctx context.Context, column string, ownerID, id int) (models.Attachment, error) {
+	var attachment models.Attachment
+	err := db.conn.WithContext(ctx).
+		Table("attachment").
+		Where("id = ? AND "+column+" = ?", id, ownerID).
+		First(&attachment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Attachment{}, apperr.NotFound("Attachment %d not found", id)
+	}
+	if err != nil {
+		return models.Attachment{}, fmt.Errorf("failed to get attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// attachments возвращает вложения владельца в порядке загрузки
+func (db *DBstorage) attachments(ctx context.Context, column string, ownerID int) ([]models.Attachment, error) {
+	attachments := []models.Attachment{}
+	if err := db.conn.WithContext(ctx).
+		Table("attachment").
+		Where(column+" = ?", ownerID).
+		Order("id").
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// replaceAttachments заменяет вложения владельца списком из снимка версии.
+// Восстановленные вложения получают новые id, содержимое берется из BlobStore по checksum.
+func (db *DBstorage) replaceAttachments(ctx context.Context, column string, ownerID int, attachments []models.Attachment) error {
+	if err := db.conn.WithContext(ctx).
+		Table("attachment").
+		Where(column+" = ?", ownerID).
+		Delete(&models.Attachment{}).Error; err != nil {
+		return fmt.Errorf("failed to delete attachments: %w", err)
+	}
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	restored := make([]models.Attachment, 0, len(attachments))
+	for _, a := range attachments {
+		a.ID = 0
+		a.TenderID, a.BidID = nil, nil
+		if column == tenderVersions.key {
+			a.TenderID = &ownerID
+		} else {
+			a.BidID = &ownerID
+		}
+		restored = append(restored, a)
+	}
+	if err := db.conn.WithContext(ctx).
+		Table("attachment").
+		Omit("id").
+		Create(&restored).Error; err != nil {
+		return fmt.Errorf("failed to restore attachments: %w", err)
+	}
+	return nil
+}
+
+func attachmentsChanged() map[string]interface{} {
+	return map[string]interface{}{"fields": []string{"attachments"}}
+}
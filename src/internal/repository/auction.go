@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OpenAuction назначает аукцион на понижение цены по тендеру. Участвуют уже
+// опубликованные предложения: после открытия новые предложения не принимаются,
+// а цены меняются только ставками. Начальная лучшая цена - наименьшая из них.
+func (db *DBstorage) OpenAuction(tenderID int, settings models.AuctionSettings, username string) (models.Auction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionOpenAuction, authz.Tender(tenderID)); err != nil {
+		return models.Auction{}, err
+	}
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if err := settings.Validate(tender, tx.auctionTypes, time.Now()); err != nil {
+			return err
+		}
+		existing, err := tx.auctionOf(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return apperr.Conflict("Tender %d already has an auction", tenderID)
+		}
+
+		auction := models.Auction{
+			TenderID:     tenderID,
+			StartsAt:     settings.StartsAt.UTC(),
+			EndsAt:       settings.EndsAt.UTC(),
+			MinStep:      settings.MinStep,
+			ExtendWithin: settings.ExtendWithin,
+			ExtendBy:     settings.ExtendBy,
+			OpenedBy:     username,
+		}
+		var best models.Bid
+		err = tx.conn.WithContext(ctx).
+			Table("bid").
+			Where("tender_id = ? AND status = ? AND price_amount > 0", tenderID, models.PublishedB).
+			Order("price_amount, id").
+			Take(&best).Error
+		if err == nil {
+			auction.BestPrice, auction.BestBidID = best.Price, &best.ID
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get best price: %w", err)
+		}
+
+		if err := tx.conn.WithContext(ctx).
+			Table("auction").
+			Omit("created_at").
+			Create(&auction).Error; err != nil {
+			return fmt.Errorf("failed to create auction: %w", err)
+		}
+		return tx.emit(ctx, models.TenderEvent(models.AuctionOpenedEvent, tenderID, username, map[string]interface{}{
+			"startsAt": auction.StartsAt,
+			"endsAt":   auction.EndsAt,
+			"minStep":  auction.MinStep,
+		}))
+	})
+	if err != nil {
+		return models.Auction{}, err
+	}
+	return db.GetAuction(tenderID, username)
+}
+
+// GetAuction возвращает состояние аукциона. Предложение с лучшей ценой видят
+// ответственные тендера и его авторы, остальные участники - только саму цену.
+func (db *DBstorage) GetAuction(tenderID int, username string) (models.Auction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(tenderID)); err != nil {
+		return models.Auction{}, err
+	}
+	auction, err := db.auctionOf(ctx, tenderID)
+	if err != nil {
+		return models.Auction{}, err
+	}
+	if auction == nil {
+		return models.Auction{}, apperr.NotFound("Tender %d has no auction", tenderID)
+	}
+	if auction.BestBidID == nil {
+		return *auction, nil
+	}
+
+	err = db.authorize(ctx, username, authz.ActionAuctionOffer, authz.Bid(*auction.BestBidID))
+	if err == nil {
+		auction.Leading = true
+		return *auction, nil
+	}
+	if !errors.Is(err, authz.ErrForbidden) {
+		return models.Auction{}, err
+	}
+	err = db.authorize(ctx, username, authz.ActionListAllTenderBids, authz.Tender(tenderID))
+	if errors.Is(err, authz.ErrForbidden) {
+		auction.BestBidID = nil
+	} else if err != nil {
+		return models.Auction{}, err
+	}
+	return *auction, nil
+}
+
+// SubmitAuctionPrice принимает ставку по предложению. Ставки одного аукциона
+// сериализуются блокировкой строки аукциона: проверка цены, продление и запись
+// новой лучшей цены выполняются атомарно, и из двух одновременных ставок с одной
+// ценой вторая отклоняется.
+func (db *DBstorage) SubmitAuctionPrice(bidID int, price models.Money, username string) (models.Auction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionAuctionOffer, authz.Bid(bidID)); err != nil {
+		return models.Auction{}, err
+	}
+	tender, err := db.tenderOfBid(ctx, bidID)
+	if err != nil {
+		return models.Auction{}, err
+	}
+
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		auction, err := tx.lockAuction(ctx, tender.ID)
+		if err != nil {
+			return err
+		}
+		// Время ставки берется после блокировки: ставка, дождавшаяся очереди
+		// после конца аукциона, не принимается
+		now := time.Now()
+
+		current, err := tx.lockBid(ctx, bidID)
+		if err != nil {
+			return err
+		}
+		if current.Status != models.PublishedB {
+			return apperr.Conflict("Bid %d cannot take part in auction in status %s", bidID, current.Status.API())
+		}
+		if len(current.Items) > 0 {
+			return apperr.Conflict("Bid %d has line items and cannot be repriced by auction", bidID)
+		}
+		next, err := auction.Accept(bidID, price, tender.Budget, now)
+		if err != nil {
+			return err
+		}
+
+		bid, err := bidVersions.commit(ctx, tx, current, pricingColumns(price, current.DeliveryDays, nil), username)
+		if err != nil {
+			return err
+		}
+		offer := models.AuctionOffer{TenderID: tender.ID, BidID: bidID, Amount: price.Amount, SubmittedBy: username}
+		if err := tx.conn.WithContext(ctx).
+			Table("auction_offer").
+			Omit("id", "created_at").
+			Create(&offer).Error; err != nil {
+			return fmt.Errorf("failed to save auction offer: %w", err)
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("auction").
+			Where("tender_id = ?", tender.ID).
+			Updates(map[string]interface{}{
+				"best_price_amount":   int64(next.BestPrice.Amount),
+				"best_price_currency": string(next.BestPrice.Currency),
+				"best_bid_id":         bidID,
+				"offers":              next.Offers,
+				"ends_at":             next.EndsAt,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update auction: %w", err)
+		}
+		return tx.emit(ctx, models.BidEvent(models.AuctionOfferAcceptedEvent, bid, username, map[string]interface{}{
+			"price":  price,
+			"endsAt": next.EndsAt,
+		}))
+	})
+	if err != nil {
+		return models.Auction{}, err
+	}
+	return db.GetAuction(tender.ID, username)
+}
+
+// auctionOf возвращает аукцион тендера, nil - аукциона нет
+func (db *DBstorage) auctionOf(ctx context.Context, tenderID int) (*models.Auction, error) {
+	var auction models.Auction
+	err := db.conn.WithContext(ctx).
+		Table("auction").
+		Where("tender_id = ?", tenderID).
+		Take(&auction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get auction: %w", err)
+	}
+	return &auction, nil
+}
+
+// lockAuction читает аукцион с блокировкой строки до конца транзакции
+func (db *DBstorage) lockAuction(ctx context.Context, tenderID int) (models.Auction, error) {
+	var auction models.Auction
+	err := db.conn.WithContext(ctx).
+		Table("auction").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tender_id = ?", tenderID).
+		Take(&auction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Auction{}, apperr.NotFound("Tender %d has no auction", tenderID)
+	}
+	if err != nil {
+		return models.Auction{}, fmt.Errorf("failed to lock auction: %w", err)
+	}
+	return auction, nil
+}
+
+// checkNotAuctioned не дает подавать предложения и менять их цены в обход аукциона
+func (db *DBstorage) checkNotAuctioned(ctx context.Context, tenderID int) error {
+	auction, err := db.auctionOf(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if auction != nil {
+		return apperr.Conflict("Tender %d is auctioned: bids cannot be added or repriced outside the auction", tenderID)
+	}
+	return nil
+}
+
+// checkAuctionFinished не дает голосовать и оценивать, пока цены еще могут измениться
+func (db *DBstorage) checkAuctionFinished(ctx context.Context, tenderID int, now time.Time) error {
+	auction, err := db.auctionOf(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if auction != nil && auction.Status(now) != models.AuctionFinished {
+		return apperr.Conflict("Auction of tender %d ends at %s", tenderID, formatDeadline(&auction.EndsAt))
+	}
+	return nil
+}
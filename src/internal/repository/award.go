@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// AwardTender выбирает победителем одобренное предложение тендера. Остальные
+// одобренные предложения отклоняются, открытые отменяются, тендер закрывается.
+func (db *DBstorage) AwardTender(tenderID, bidID int, reason, username string) (models.AwardSummary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionAwardTender, authz.Tender(tenderID)); err != nil {
+		return models.AwardSummary{}, err
+	}
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Порядок блокировок тот же, что в SubmitDecision: тендер, затем предложение
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		bid, err := tx.lockBid(ctx, bidID)
+		if err != nil {
+			return err
+		}
+		if bid.TenderID != tenderID {
+			return apperr.Invalid("Bid %d does not belong to tender %d", bidID, tenderID)
+		}
+		return tx.award(ctx, tender, bid, reason, username)
+	})
+	if err != nil {
+		return models.AwardSummary{}, err
+	}
+	return db.awardSummary(ctx, tenderID)
+}
+
+// award записывает победителя и закрывает заблокированный тендер. Вызывается внутри unitOfWork.
+func (db *DBstorage) award(ctx context.Context, tender models.Tender, bid models.Bid, reason, username string) error {
+	if bid.Status != models.SubmittedB {
+		return apperr.Conflict("Bid %d is not approved", bid.ID)
+	}
+	if err := tender.Status.Transition(models.ClosedT); err != nil {
+		return err
+	}
+
+	if err := db.declineApproved(ctx, tender.ID, bid.ID, username); err != nil {
+		return err
+	}
+	award := models.Award{
+		TenderID:  tender.ID,
+		BidID:     bid.ID,
+		Reason:    reason,
+		AwardedBy: username,
+	}
+	omit := []string{"created_at"}
+	if username == "" {
+		omit = append(omit, "awarded_by")
+	}
+	if err := db.conn.WithContext(ctx).
+		Table("tender_award").
+		Omit(omit...).
+		Create(&award).Error; err != nil {
+		return fmt.Errorf("failed to save award: %w", err)
+	}
+	if err := db.emit(ctx, models.TenderEvent(models.TenderAwardedEvent, tender.ID, username, map[string]interface{}{
+		"bidId":  bid.ID,
+		"reason": reason,
+	})); err != nil {
+		return err
+	}
+
+	// Закрытие отменяет оставшиеся открытые предложения
+	return db.transitionTender(ctx, tender, models.ClosedT, username, fmt.Sprintf("bid %d awarded", bid.ID))
+}
+
+// awardSoleCandidate выбирает победителя без участия пользователя, если подача и
+// пересмотр предложений окончены, голосование завершено по всем опубликованным
+// предложениям и одобрено ровно одно из них. Пока подача идет, одобренное
+// предложение остается кандидатом до AwardTender или срока решения.
+func (db *DBstorage) awardSoleCandidate(ctx context.Context, tender models.Tender, username string) error {
+	if !tender.SubmissionsFinal(time.Now()) {
+		return nil
+	}
+	var bids []models.Bid
+	if err := db.conn.WithContext(ctx).
+		Table("bid").
+		Where("tender_id = ? AND status IN ?", tender.ID, []models.BidStatus{models.PublishedB, models.SubmittedB}).
+		Find(&bids).Error; err != nil {
+		return fmt.Errorf("failed to get competing bids: %w", err)
+	}
+	if len(bids) != 1 || bids[0].Status != models.SubmittedB {
+		return nil
+	}
+	return db.award(ctx, tender, bids[0], "the only approved bid", username)
+}
+
+// declineApproved отклоняет одобренные предложения тендера, кроме winner (0 - все)
+func (db *DBstorage) declineApproved(ctx context.Context, tenderID, winner int, username string) error {
+	_, err := db.transitionBids(ctx, tenderID, []models.BidStatus{models.SubmittedB}, []int{winner}, models.DeclinedB, username)
+	return err
+}
+
+// GetTenderAward возвращает победителя тендера и сравнение его предложений
+func (db *DBstorage) GetTenderAward(tenderID int, username string) (models.AwardSummary, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewAward, authz.Tender(tenderID)); err != nil {
+		return models.AwardSummary{}, err
+	}
+	summary, err := db.awardSummary(ctx, tenderID)
+	if err != nil {
+		return models.AwardSummary{}, err
+	}
+	if summary.Tender.BidsSealed(time.Now()) {
+		for i := range summary.Candidates {
+			summary.Candidates[i].Bid = summary.Candidates[i].Bid.Seal()
+		}
+	}
+	return summary, nil
+}
+
+func (db *DBstorage) awardSummary(ctx context.Context, tenderID int) (models.AwardSummary, error) {
+	var summary models.AwardSummary
+	err := db.conn.WithContext(ctx).
+		Table("tender").
+		Where("id = ?", tenderID).
+		First(&summary.Tender).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.AwardSummary{}, apperr.NotFound("Tender %d not found", tenderID)
+	}
+	if err != nil {
+		return models.AwardSummary{}, fmt.Errorf("failed to get tender: %w", err)
+	}
+
+	var award models.Award
+	err = db.conn.WithContext(ctx).
+		Table("tender_award").
+		Where("tender_id = ?", tenderID).
+		First(&award).Error
+	switch {
+	case err == nil:
+		summary.Award = &award
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return models.AwardSummary{}, fmt.Errorf("failed to get award: %w", err)
+	}
+
+	// Неопубликованные предложения ответственным не видны и не сравниваются
+	var bids []models.Bid
+	if err := db.conn.WithContext(ctx).
+		Table("bid").
+		Where("tender_id = ? AND status <> ?", tenderID, models.CreatedB).
+		Order("id").
+		Find(&bids).Error; err != nil {
+		return models.AwardSummary{}, fmt.Errorf("failed to get tender bids: %w", err)
+	}
+	voters, err := db.voters(ctx, summary.Tender.OrganizationID)
+	if err != nil {
+		return models.AwardSummary{}, err
+	}
+	policy := summary.Tender.ActivePolicy()
+	for _, bid := range bids {
+		votes, err := db.votes(ctx, bid.ID)
+		if err != nil {
+			return models.AwardSummary{}, err
+		}
+		ballot := models.Ballot{Bid: bid, Policy: policy, Voters: voters, Votes: votes}
+		summary.Candidates = append(summary.Candidates, models.Candidate{Bid: bid, Tally: ballot.Tally()})
+	}
+	return summary, nil
+}
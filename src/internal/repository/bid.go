@@ -2,39 +2,110 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
 )
 
-func (db *DBstorage) GetBidsByUser(username string) ([]models.Bid, error) {
+func (db *DBstorage) GetBidsByUser(username string, params models.ListParams) ([]models.Bid, models.Page, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+	if err := db.authorize(ctx, username, authz.ActionListOwnBids, authz.Resource{}); err != nil {
+		return nil, models.Page{}, err
+	}
 	var bids []models.Bid
-	err := db.conn.WithContext(ctx).
-		Table("bid").
-		Where("creator_username = ?", username).
-		Order("id ASC").
-		Find(&bids).Error
+	query := db.ownBids(ctx, db.conn.WithContext(ctx).Table("bid"), username)
+	query = filterBids(query, params)
+
+	query, total, err := paginate(query, "bid", params, "name")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get bids by user: %w", err)
+		return nil, models.Page{}, err
+	}
+	if err := query.Find(&bids).Error; err != nil {
+		return nil, models.Page{}, fmt.Errorf("failed to get bids by user: %w", err)
 	}
-	return bids, nil
+	bids, page := pageOf(bids, params, "name", total, bidKey)
+	return bids, page, nil
 }
 
-func (db *DBstorage) GetBidsForTender(tenderID int) ([]models.Bid, error) {
+func (db *DBstorage) GetBidsForTender(tenderID int, username string, params models.ListParams) ([]models.Bid, models.Page, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionListTenderBids, authz.Tender(tenderID)); err != nil {
+		return nil, models.Page{}, err
+	}
+	var tender models.Tender
+	if err := db.conn.WithContext(ctx).
+		Table("tender").
+		Where("id = ?", tenderID).
+		First(&tender).Error; err != nil {
+		return nil, models.Page{}, fmt.Errorf("failed to get tender: %w", err)
+	}
+
 	var bids []models.Bid
-	err := db.conn.WithContext(ctx).
+	query := db.conn.WithContext(ctx).
 		Table("bid").
-		Where("tender_id = ?", tenderID).
-		Find(&bids).Error
+		Where("tender_id = ?", tenderID)
+	// Участники видят только свои предложения, ответственные тендера - все, кроме черновиков
+	err := db.authorize(ctx, username, authz.ActionListAllTenderBids, authz.Tender(tenderID))
+	if errors.Is(err, authz.ErrForbidden) {
+		query = db.ownBids(ctx, query, username)
+	} else if err != nil {
+		return nil, models.Page{}, err
+	} else {
+		query = query.Where(db.conn.Where("status <> ?", models.CreatedB).Or(db.ownBids(ctx, db.conn, username)))
+	}
+	query = filterBids(query, params)
+
+	// Порядок по имени или цене раскрыл бы содержимое закрытых предложений
+	sealed := tender.BidsSealed(time.Now())
+	defaultSort := "name"
+	if sealed {
+		defaultSort = "id"
+		if params.SortBy != "" && params.SortBy != "id" && params.SortBy != "version" {
+			return nil, models.Page{}, apperr.Invalid("Bids of sealed tender %d cannot be sorted by %s", tenderID, params.SortBy)
+		}
+	}
+
+	query, total, err := paginate(query, "bid", params, defaultSort)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get bids for tender: %w", err)
+		return nil, models.Page{}, err
 	}
-	return bids, nil
+	if err := query.Find(&bids).Error; err != nil {
+		return nil, models.Page{}, fmt.Errorf("failed to get bids for tender: %w", err)
+	}
+	bids, page := pageOf(bids, params, defaultSort, total, bidKey)
+	if sealed {
+		if bids, err = db.sealForeign(ctx, bids, username); err != nil {
+			return nil, models.Page{}, err
+		}
+	}
+	return bids, page, nil
+}
+
+// ownBids оставляет свои предложения пользователя: созданные от своего имени и поданные
+// организациями, за которые он отвечает сейчас, кем бы из ответственных они ни были созданы
+func (db *DBstorage) ownBids(ctx context.Context, query *gorm.DB, username string) *gorm.DB {
+	return query.Where("((author_type = ? AND creator_username = ?) OR (author_type = ? AND organization_id IN (?)))",
+		models.UserAuthor, username, models.OrganizationAuthor, db.responsibleOrganizations(ctx, username))
+}
+
+// filterBids применяет фильтр по статусам предложений
+func filterBids(query *gorm.DB, params models.ListParams) *gorm.DB {
+	if len(params.Statuses) > 0 {
+		query = query.Where("status IN ?", params.Statuses)
+	}
+	return query
+}
+
+func bidKey(b models.Bid) sortKey {
+	return sortKey{Name: b.Name, ID: b.ID, Version: b.Version, Price: priceKey(b.Price)}
 }
 
 func (db *DBstorage) CreateBid(bid models.Bid, creatorUsername string) (models.Bid, error) {
@@ -44,164 +115,304 @@ func (db *DBstorage) CreateBid(bid models.Bid, creatorUsername string) (models.B
 	bid.Version = 1
 	bid.Status = "CREATED"
 
-	// Получение userID
-	userID, err := db.GetUserIDByUsername(creatorUsername)
+	//проверка прав пользователя
+	err := db.authorize(ctx, creatorUsername, authz.ActionCreateBid, authz.Tender(bid.TenderID))
 	if err != nil {
-		return models.Bid{}, fmt.Errorf("failed to get user ID: %w", err)
+		return models.Bid{}, fmt.Errorf("user %s does not have permission to create bid: %w", creatorUsername, err)
 	}
-	//проверка прав пользователя
-	if bid.OrganizationID != nil {
-		var count int64
-		err = db.conn.WithContext(ctx).
-			Table("organization_responsible").
-			Where("user_id = ? OR organization_id = ?", userID, bid.OrganizationID).
-			Count(&count).Error
-		if err != nil {
-			return models.Bid{}, fmt.Errorf("failed to check responsibility: %w", err)
+	if bid.AuthorType == "" {
+		bid.AuthorType = models.UserAuthor
+		if bid.OrganizationID != nil {
+			bid.AuthorType = models.OrganizationAuthor
 		}
-		if count == 0 {
-			return models.Bid{}, fmt.Errorf("user %s does not have permission to create bid", bid.CreatorUsername)
+	}
+	switch bid.AuthorType {
+	case models.UserAuthor:
+		bid.OrganizationID = nil
+	case models.OrganizationAuthor:
+		if bid.OrganizationID == nil {
+			return models.Bid{}, apperr.Invalid("Organization is required for bids of authorType Organization")
 		}
+		err = db.authorize(ctx, creatorUsername, authz.ActionBidOnBehalfOfOrg, authz.Organization(*bid.OrganizationID))
+		if err != nil {
+			return models.Bid{}, fmt.Errorf("user %s does not have permission to create bid: %w", creatorUsername, err)
+		}
+	default:
+		return models.Bid{}, apperr.Invalid("Invalid authorType %s", bid.AuthorType)
 	}
-	// Проверка существования тендера и его статуса
-	var tenderStatus string
-	err = db.conn.WithContext(ctx).
-		Table("tender").
-		Select("status").
-		Where("id = ?", bid.TenderID).
-		Scan(&tenderStatus).Error
+	//создание нового предложения и его первой версии
+	bid.Status = models.CreatedB
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Тендер блокируется до вставки: закрытие, отмена, аукцион и итоги по сроку
+		// не могут произойти между проверками и созданием предложения
+		tender, err := tx.lockTender(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+		if tender.Status != models.PublishedT {
+			return apperr.Conflict("Cannot create bid, tender is not published")
+		}
+		if !tender.SubmissionOpen(time.Now()) {
+			return apperr.Conflict("Submission deadline of tender %d has passed", tender.ID)
+		}
+		if err := tx.checkNotAuctioned(ctx, tender.ID); err != nil {
+			return err
+		}
+		if err := tx.checkBidAuthor(ctx, bid, tender); err != nil {
+			return err
+		}
+		if err := bid.ValidatePricing(); err != nil {
+			return err
+		}
+		if err := tender.CheckBid(bid); err != nil {
+			return err
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("bid").
+			Create(&bid).Error; err != nil {
+			return fmt.Errorf("error creating bid: %w", err)
+		}
+		if err := bidVersions.record(ctx, tx, bid, creatorUsername); err != nil {
+			return err
+		}
+		data := map[string]interface{}{"authorType": bid.AuthorType}
+		if bid.OrganizationID != nil {
+			data["organizationId"] = *bid.OrganizationID
+		}
+		return tx.emit(ctx, models.BidEvent(models.BidCreatedEvent, bid, creatorUsername, data))
+	})
 	if err != nil {
-		return models.Bid{}, fmt.Errorf("failed to check tender existence: %w", err)
+		return models.Bid{}, err
 	}
-	if tenderStatus == "" {
-		return models.Bid{}, fmt.Errorf("tender not found")
+	return bid, nil
+}
+
+// checkBidAuthor не дает организации подать предложение на собственный тендер:
+// ее ответственные принимали бы решение по своему предложению. По той же причине
+// ответственный за организацию тендера не подает на него пользовательское предложение.
+func (db *DBstorage) checkBidAuthor(ctx context.Context, bid models.Bid, tender models.Tender) error {
+	if bid.AuthorType == models.OrganizationAuthor {
+		if *bid.OrganizationID == tender.OrganizationID {
+			return apperr.Conflict("Organization %d cannot bid on its own tender %d", tender.OrganizationID, tender.ID)
+		}
+		return nil
 	}
-	if tenderStatus != "PUBLISHED" {
-		return models.Bid{}, fmt.Errorf("cannot create bid, tender is not in PUBLISHED status")
+	employee, err := db.GetEmployeeByUsername(bid.CreatorUsername)
+	if err != nil {
+		return err
 	}
-	//создание нового предложения
-	bid.Status = "CREATED"
-	if err := db.conn.WithContext(ctx).
-		Table("bid").
-		Create(&bid).Error; err != nil {
-		return models.Bid{}, fmt.Errorf("error creating bid: %w", err)
+	responsible, err := db.isResponsible(ctx, employee.ID, tender.OrganizationID)
+	if err != nil {
+		return err
 	}
-	return bid, nil
+	if responsible {
+		return apperr.Conflict("User %s is responsible for organization %d and cannot bid on its tender %d", bid.CreatorUsername, tender.OrganizationID, tender.ID)
+	}
+	return nil
 }
 
-func (db *DBstorage) SetBidStatus(id int, status string) error {
+func (db *DBstorage) GetBidStatus(id int, username string) (models.BidStatus, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	query := db.conn.WithContext(ctx).
+	if err := db.authorize(ctx, username, authz.ActionViewBid, authz.Bid(id)); err != nil {
+		return "", err
+	}
+
+	var status models.BidStatus
+	err := db.conn.WithContext(ctx).
 		Table("bid").
-		Model(&models.Bid{}).
+		Select("status").
 		Where("id = ?", id).
-		Update("status", status)
-	if query.Error != nil {
-		return query.Error
-	}
-	if query.RowsAffected == 0 {
-		return fmt.Errorf("no bid found with id %d", id)
+		Scan(&status).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to get bid status: %w", err)
 	}
-	return nil
+	return status, nil
 }
 
-func (db *DBstorage) EditBid(id int, name string, description string) (models.Bid, error) {
+// SetBidStatus меняет статус предложения по правилам жизненного цикла (models.BidStatus.CanTransitionTo)
+func (db *DBstorage) SetBidStatus(id int, status string, username string, expectedVersion int) (models.Bid, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	var bid models.Bid
-	var currentVersion int
-	err := db.conn.WithContext(ctx).
-		Table("bid").
-		Where("id =?", id).
-		First(&bid).Error
-	if err != nil {
-		return models.Bid{}, fmt.Errorf("failed to get bid: %w", err)
-	}
-	currentVersion = bid.Version
-
-	// Сохраняем старую запись в историю
-	history := models.BidHistory{
-		BidID:           bid.ID,
-		Name:            bid.Name,
-		Description:     bid.Description,
-		Status:          bid.Status,
-		OrganizationID:  bid.OrganizationID,
-		TenderID:        bid.TenderID,
-		CreatorUsername: bid.CreatorUsername,
-		Version:         currentVersion,
-	}
-	// Сохраняем запись в истории
-	err = db.conn.WithContext(ctx).
-		Table("bid_history").
-		Create(&history).Error
-	if err != nil {
+	if err := db.authorize(ctx, username, authz.ActionSetBidStatus, authz.Bid(id)); err != nil {
 		return models.Bid{}, err
 	}
-	// Обновляем текущую версию
-	rowsAffected := db.conn.WithContext(ctx).
-		Table("bid").
-		Where("id = ? AND version = ?", id, currentVersion).
-		Updates(map[string]interface{}{
-			"name":        name,
-			"description": description,
-			"version":     currentVersion + 1,
-		}).RowsAffected
 
-	if rowsAffected == 0 {
-		return models.Bid{}, fmt.Errorf("no bid found with id %d or version mismatch", id)
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Порядок блокировок тот же, что в SubmitDecision: тендер, затем предложение
+		tenderID, err := tx.GetTenderIDByBidID(id)
+		if err != nil {
+			return err
+		}
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		current, err := tx.lockBid(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := bidVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		to := models.BidStatus(status)
+		if to == models.PublishedB && tender.Status != models.PublishedT {
+			return apperr.Conflict("Cannot publish bid, tender is not published")
+		}
+		if to == models.PublishedB && !tender.SubmissionOpen(time.Now()) {
+			return apperr.Conflict("Submission deadline of tender %d has passed", tender.ID)
+		}
+		if to == models.PublishedB {
+			if err := tx.checkNotAuctioned(ctx, tender.ID); err != nil {
+				return err
+			}
+		}
+		return tx.transitionBid(ctx, current, to, username)
+	})
+	if err != nil {
+		return models.Bid{}, err
 	}
+	return db.getBid(ctx, id)
+}
 
+// getBid читает предложение без проверки прав
+func (db *DBstorage) getBid(ctx context.Context, id int) (models.Bid, error) {
+	var bid models.Bid
 	if err := db.conn.WithContext(ctx).
 		Table("bid").
 		Where("id = ?", id).
 		First(&bid).Error; err != nil {
-		return models.Bid{}, err
+		return models.Bid{}, fmt.Errorf("failed to get bid: %w", err)
 	}
 	return bid, nil
 }
 
-func (db *DBstorage) RollbackBid(id int, version int) (models.Bid, error) {
+func (db *DBstorage) EditBid(id int, update models.BidUpdate, username string, expectedVersion int) (models.Bid, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	var bidH models.BidHistory
-	if err := db.conn.WithContext(ctx).
-		Table("bid_history").
-		Where("bid_id =? AND version =?", id, version).
-		First(&bidH).Error; err != nil {
-		return models.Bid{}, fmt.Errorf("version %d for bid %d not found: %v", version, id, err)
+
+	if err := db.authorize(ctx, username, authz.ActionEditBid, authz.Bid(id)); err != nil {
+		return models.Bid{}, err
 	}
-	// Восстанавливаем предыдущую версию
-	err := db.conn.WithContext(ctx).
-		Table("bid").
-		Where("id =?", id).
-		Updates(map[string]interface{}{
-			"name":             bidH.Name,
-			"description":      bidH.Description,
-			"status":           bidH.Status,
-			"organization_id":  bidH.OrganizationID,
-			"tender_id":        bidH.TenderID,
-			"creator_username": bidH.CreatorUsername,
-			"version":          bidH.Version,
-		}).Error
+
+	var bid models.Bid
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockBid(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := bidVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Bid %d cannot be edited in status %s", id, current.Status.API())
+		}
+		tender, err := tx.tenderOfBid(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkRevisable(tender, current, time.Now()); err != nil {
+			return err
+		}
+
+		// Непереданные поля не меняются
+		changes := map[string]interface{}{}
+		if update.Name != nil {
+			changes["name"] = *update.Name
+		}
+		if update.Description != nil {
+			changes["description"] = *update.Description
+		}
+
+		// Цена и позиции проверяются вместе с теми, что остаются без изменений
+		if update.Price != nil || update.DeliveryDays != nil || update.Items != nil {
+			if err := tx.checkNotAuctioned(ctx, tender.ID); err != nil {
+				return err
+			}
+			next := current
+			if update.Price != nil {
+				next.Price = *update.Price
+			}
+			if update.DeliveryDays != nil {
+				next.DeliveryDays = *update.DeliveryDays
+			}
+			if update.Items != nil {
+				next.Items = *update.Items
+			}
+			if err := next.ValidatePricing(); err != nil {
+				return err
+			}
+			if err := tender.CheckBid(next); err != nil {
+				return err
+			}
+			for column, value := range pricingColumns(next.Price, next.DeliveryDays, next.Items) {
+				changes[column] = value
+			}
+		}
+		bid, err = bidVersions.commit(ctx, tx, current, changes, username)
+		return err
+	})
 	if err != nil {
-		return models.Bid{}, fmt.Errorf("error rollback bid: %w", err)
+		return models.Bid{}, err
 	}
-	err = db.conn.WithContext(ctx).
-		Table("bid_history").
-		Where("bid_id = ? AND version > ?", id, version).
-		Delete(&models.BidHistory{}).Error
-	if err != nil {
-		return models.Bid{}, fmt.Errorf("error deleting history: %w", err)
+	return bid, nil
+}
+
+func (db *DBstorage) RollbackBid(id int, version int, username string, expectedVersion int) (models.Bid, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionRollbackBid, authz.Bid(id)); err != nil {
+		return models.Bid{}, err
 	}
+
 	var updateBid models.Bid
-	if err := db.conn.WithContext(ctx).
-		Table("bid").
-		Where("id =?", id).
-		First(&updateBid).Error; err != nil {
-		return models.Bid{}, fmt.Errorf("error getting updated bid: %w", err)
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockBid(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := bidVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		// Откат меняет только содержимое. Статус из истории не восстанавливается:
+		// иначе отклоненное предложение можно было бы вернуть в CREATED.
+		if !current.Status.Editable() {
+			return apperr.Conflict("Bid %d cannot be rolled back in status %s", id, current.Status.API())
+		}
+		tender, err := tx.tenderOfBid(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkRevisable(tender, current, time.Now()); err != nil {
+			return err
+		}
+		// Откат вернул бы цену, действовавшую до ставок
+		if err := tx.checkNotAuctioned(ctx, tender.ID); err != nil {
+			return err
+		}
+
+		updateBid, err = bidVersions.rollback(ctx, tx, current, version, username)
+		return err
+	})
+	if err != nil {
+		return models.Bid{}, err
 	}
 	return updateBid, nil
 }
+
+// GetBidVersions возвращает все версии предложения
+func (db *DBstorage) GetBidVersions(id int, username string) ([]models.Version, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewBid, authz.Bid(id)); err != nil {
+		return nil, err
+	}
+	if err := db.checkUnsealed(ctx, id, username); err != nil {
+		return nil, err
+	}
+	return bidVersions.list(ctx, db, id)
+}
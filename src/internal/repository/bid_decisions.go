@@ -4,196 +4,213 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func (db *DBstorage) SubmitDecision(bid int, username string) error {
+func (db *DBstorage) SubmitDecision(bid int, username string) (models.Bid, error) {
+	return db.decide(bid, username, models.SubmittedD)
+}
+
+func (db *DBstorage) DeclineDecision(bid int, username string) (models.Bid, error) {
+	return db.decide(bid, username, models.DeclinedD)
+}
+
+// decide сохраняет голос и подводит итог по правилам голосования тендера
+// (models.DecisionPolicy). Пока итог не подведен, голос можно изменить.
+func (db *DBstorage) decide(bid int, username string, decision models.Decision) (models.Bid, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// Проверка прав пользователя
-	yes, err := db.CheckUserPermissionForBid(bid, username)
-	if err != nil {
-		return fmt.Errorf("failed to check user permission: %w", err)
-	}
-	if !yes {
-		return fmt.Errorf("user does not have permission to submit decision")
+	if err := db.authorize(ctx, username, authz.ActionDecideBid, authz.Bid(bid)); err != nil {
+		return models.Bid{}, fmt.Errorf("user does not have permission to submit decision: %w", err)
 	}
 
-	// Проверка статуса
-	var currentStatus string
-	err = db.conn.WithContext(ctx).
-		Table("bid").
-		Select("status").
-		Where("id = ?", bid).
-		Scan(&currentStatus).Error
-	if err != nil {
-		return fmt.Errorf("failed to get current bid status: %w", err)
-	}
-	if currentStatus != "PUBLISHED" {
-		return fmt.Errorf("bid must be in PUBLISHED status to submit decision")
-	}
-
-	// Проверка существующих решений со статусом "DECLINED"
-	var declinedCount int64
-	err = db.conn.WithContext(ctx).
-		Table("bid_decisions").
-		Where("bid_id = ? AND decision_status = ?", bid, "DECLINED").
-		Count(&declinedCount).Error
-	if err != nil {
-		return fmt.Errorf("failed to check for declined decisions: %w", err)
-	}
-	if declinedCount > 0 {
-		err = db.conn.WithContext(ctx).
-			Table("bid").
-			Where("id = ?", bid).
-			Update("status", "DECLINED").Error
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Блокируем тендер, затем предложение: параллельные решения по предложениям
+		// одного тендера выполняются по очереди и не закрывают тендер дважды
+		tenderID, err := tx.GetTenderIDByBidID(bid)
 		if err != nil {
-			return fmt.Errorf("failed to update bid status to DECLINED: %w", err)
+			return err
+		}
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		current, err := tx.lockBid(ctx, bid)
+		if err != nil {
+			return err
 		}
-		return nil
-	}
-
-	// Сохраняем новое решение "SUBMITTED"
-	err = db.conn.WithContext(ctx).
-		Table("bid_decisions").
-		Create(map[string]interface{}{
-			"bid_id":          bid,
-			"username":        username,
-			"decision_status": "SUBMITTED",
-		}).Error
-	if err != nil {
-		return fmt.Errorf("failed to save submitted decision: %w", err)
-	}
-
-	// Получаем количество ответственных за организацию
-	var responsibleCount int64
-	err = db.conn.WithContext(ctx).
-		Table("organization_responsible").
-		Where("organization_id = (SELECT organization_id FROM bid WHERE id = ?)", bid).
-		Count(&responsibleCount).Error
-	if err != nil {
-		return fmt.Errorf("failed to get responsible count for organization: %w", err)
-	}
-
-	// Вычисляем кворум
-	quorum := int64(3)
-	if responsibleCount < quorum {
-		quorum = responsibleCount
-	}
 
-	// Получаем количество решений "SUBMITTED"
-	var submittedCount int64
-	err = db.conn.WithContext(ctx).
-		Table("bid_decisions").
-		Where("bid_id = ? AND decision_status = ?", bid, "SUBMITTED").
-		Count(&submittedCount).Error
-	if err != nil {
-		return fmt.Errorf("failed to get submitted decisions: %w", err)
-	}
+		// Голосовать можно только по опубликованному предложению, итог по которому еще не подведен.
+		// Значения решений совпадают со статусами, в которые они переводят предложение.
+		if current.Status != models.PublishedB {
+			return &models.TransitionError{Entity: "bid", From: current.Status.API(), To: models.BidStatus(decision).API()}
+		}
+		if err := checkDecidable(tender, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.checkAuctionFinished(ctx, tender.ID, time.Now()); err != nil {
+			return err
+		}
 
-	// Проверяем, достигнут ли кворум
-	if submittedCount >= quorum {
-		// Обновляем статус предложения на "SUBMITTED"
-		err = db.conn.WithContext(ctx).
-			Table("bid").
-			Where("id = ?", bid).
-			Update("status", "SUBMITTED").Error
+		// У пользователя один голос по предложению: повторный голос заменяет прежний
+		err = tx.conn.WithContext(ctx).
+			Table("bid_decisions").
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "bid_id"}, {Name: "username"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"decision_status": decision,
+					"decision_time":   gorm.Expr("CURRENT_TIMESTAMP"),
+				}),
+			}).
+			Create(map[string]interface{}{
+				"bid_id":          bid,
+				"username":        username,
+				"decision_status": decision,
+			}).Error
 		if err != nil {
-			return fmt.Errorf("error updating bid status: %w", err)
+			return fmt.Errorf("failed to save decision: %w", err)
+		}
+		if err := tx.emit(ctx, models.BidEvent(models.DecisionMadeEvent, current, username, map[string]interface{}{
+			"decision": models.BidStatus(decision).API(),
+		})); err != nil {
+			return err
 		}
 
-		// Закрываем связанный тендер
-		err = db.conn.WithContext(ctx).
-			Table("tender").
-			Where("id = (SELECT tender_id FROM bid WHERE id = ?)", bid).
-			Update("status", "CLOSED").Error
+		ballot := models.Ballot{Bid: current, Policy: tender.ActivePolicy()}
+		if ballot.Voters, err = tx.voters(ctx, tender.OrganizationID); err != nil {
+			return err
+		}
+		if ballot.Votes, err = tx.votes(ctx, bid); err != nil {
+			return err
+		}
+
+		// Одобренное предложение становится кандидатом в победители тендера
+		switch ballot.Tally().Outcome {
+		case models.OutcomeApproved:
+			err = tx.transitionBid(ctx, current, models.SubmittedB, username)
+		case models.OutcomeRejected:
+			err = tx.transitionBid(ctx, current, models.DeclinedB, username)
+		default:
+			return nil
+		}
 		if err != nil {
-			return fmt.Errorf("error updating tender status: %w", err)
+			return err
 		}
+		return tx.awardSoleCandidate(ctx, tender, username)
+	})
+	if err != nil {
+		return models.Bid{}, err
 	}
-
-	return nil
+	return db.getBid(ctx, bid)
 }
-func (db *DBstorage) DeclineDecision(bid int, username string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
 
-	// Получаем ID тендера, связанного с предложением
-	var tenderID int
+// voters возвращает ответственных за организацию вместе с их ролями
+func (db *DBstorage) voters(ctx context.Context, organizationID int) ([]models.Voter, error) {
+	var voters []models.Voter
 	err := db.conn.WithContext(ctx).
-		Table("bid").
-		Select("tender_id").
-		Where("id = ?", bid).
-		Scan(&tenderID).Error
+		Table("organization_responsible").
+		Select("employee.username, organization_responsible.role").
+		Joins("JOIN employee ON employee.id = organization_responsible.user_id").
+		Where("organization_responsible.organization_id = ?", organizationID).
+		Scan(&voters).Error
 	if err != nil {
-		return fmt.Errorf("failed to get tender ID for bid: %w", err)
+		return nil, fmt.Errorf("failed to get responsible employees: %w", err)
 	}
+	return voters, nil
+}
 
-	// Проверка прав пользователя
-	hasPermission, err := db.CheckUserPermissionForBid(bid, username)
+// votes возвращает голоса по предложению
+func (db *DBstorage) votes(ctx context.Context, bid int) ([]models.BidDecision, error) {
+	var votes []models.BidDecision
+	err := db.conn.WithContext(ctx).
+		Table("bid_decisions").
+		Where("bid_id = ?", bid).
+		Order("decision_time, id").
+		Find(&votes).Error
 	if err != nil {
-		return fmt.Errorf("failed to check user permission: %w", err)
-	}
-	if !hasPermission {
-		return fmt.Errorf("user does not have permission to submit decision")
+		return nil, fmt.Errorf("failed to get decisions: %w", err)
 	}
+	return votes, nil
+}
 
-	// Проверка статуса
-	var currentStatus string
-	err = db.conn.WithContext(ctx).
-		Table("bid").
-		Select("status").
-		Where("id = ?", bid).
-		Scan(&currentStatus).Error
-	if err != nil {
-		return fmt.Errorf("failed to get current bid status: %w", err)
-	}
-	if currentStatus != "PUBLISHED" {
-		return fmt.Errorf("bid must be in PUBLISHED status to submit decision")
-	}
+// RetractDecision отзывает голос пользователя, пока итог голосования не подведен
+func (db *DBstorage) RetractDecision(bid int, username string) (models.Bid, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	// Проверка существующих решений "DECLINED"
-	var declinedCount int64
-	err = db.conn.WithContext(ctx).
-		Table("bid_decisions").
-		Where("bid_id = ? AND decision_status = ?", bid, "DECLINED").
-		Count(&declinedCount).Error
-	if err != nil {
-		return fmt.Errorf("failed to check for declined decisions: %w", err)
+	if err := db.authorize(ctx, username, authz.ActionDecideBid, authz.Bid(bid)); err != nil {
+		return models.Bid{}, err
 	}
 
-	// Если есть хотя бы одно решение "DECLINED", сразу отклоняем предложение
-	if declinedCount > 0 {
-		err = db.conn.WithContext(ctx).
-			Table("bid").
-			Where("id = ?", bid).
-			Update("status", "DECLINED").Error
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		tenderID, err := tx.GetTenderIDByBidID(bid)
 		if err != nil {
-			return fmt.Errorf("failed to update bid status to DECLINED: %w", err)
+			return err
+		}
+		if _, err := tx.lockTender(ctx, tenderID); err != nil {
+			return err
+		}
+		current, err := tx.lockBid(ctx, bid)
+		if err != nil {
+			return err
+		}
+		if current.Status != models.PublishedB {
+			return apperr.Conflict("Voting on bid %d is finished", bid)
 		}
-		return nil
-	}
 
-	// Добавляем решение "DECLINED" в таблицу решений
-	err = db.conn.WithContext(ctx).
-		Table("bid_decisions").
-		Create(map[string]interface{}{
-			"bid_id":          bid,
-			"username":        username,
-			"decision_status": "DECLINED",
-		}).Error
+		// Отзыв голоса не может привести к одобрению или отклонению, итог не пересчитывается
+		query := tx.conn.WithContext(ctx).
+			Table("bid_decisions").
+			Where("bid_id = ? AND username = ?", bid, username).
+			Delete(&models.BidDecision{})
+		if query.Error != nil {
+			return fmt.Errorf("failed to retract decision: %w", query.Error)
+		}
+		if query.RowsAffected == 0 {
+			return apperr.NotFound("User %s has not voted on bid %d", username, bid)
+		}
+		return tx.emit(ctx, models.BidEvent(models.DecisionRetractedEvent, current, username, nil))
+	})
 	if err != nil {
-		return fmt.Errorf("failed to save declined decision: %w", err)
+		return models.Bid{}, err
 	}
+	return db.getBid(ctx, bid)
+}
 
-	// Отклоняем предложение после первого решения "DECLINED"
-	err = db.conn.WithContext(ctx).
-		Table("bid").
-		Where("id = ?", bid).
-		Update("status", "DECLINED").Error
-	if err != nil {
-		return fmt.Errorf("failed to update bid status to DECLINED: %w", err)
+// GetBidDecisions возвращает голоса ответственных и правила, по которым подводится итог
+func (db *DBstorage) GetBidDecisions(bid int, username string) (models.Ballot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewDecisions, authz.Bid(bid)); err != nil {
+		return models.Ballot{}, err
 	}
 
-	return nil
+	var ballot models.Ballot
+	var err error
+	if ballot.Bid, err = db.getBid(ctx, bid); err != nil {
+		return models.Ballot{}, err
+	}
+	var tender models.Tender
+	if err := db.conn.WithContext(ctx).
+		Table("tender").
+		Where("id = ?", ballot.Bid.TenderID).
+		First(&tender).Error; err != nil {
+		return models.Ballot{}, fmt.Errorf("failed to get tender: %w", err)
+	}
+	ballot.Policy = tender.ActivePolicy()
+	if ballot.Voters, err = db.voters(ctx, tender.OrganizationID); err != nil {
+		return models.Ballot{}, err
+	}
+	if ballot.Votes, err = db.votes(ctx, bid); err != nil {
+		return models.Ballot{}, err
+	}
+	return ballot, nil
 }
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// transitionBid переводит заблокированное предложение в статус to. Все пути смены
+// статуса предложения (SetBidStatus, решения, итоги тендера) проходят через эту
+// проверку и публикуют событие перехода. Пустой actor - переход выполнил планировщик.
+func (db *DBstorage) transitionBid(ctx context.Context, bid models.Bid, to models.BidStatus, actor string) error {
+	if err := bid.Status.Transition(to); err != nil {
+		return err
+	}
+	if err := db.conn.WithContext(ctx).
+		Table("bid").
+		Where("id = ?", bid.ID).
+		Update("status", to).Error; err != nil {
+		return fmt.Errorf("failed to update bid status: %w", err)
+	}
+	return db.emit(ctx, models.BidStatusEvent(bid, to, actor))
+}
+
+// transitionBids переводит предложения тендера в статусах from, кроме except,
+// в статус to и возвращает их число
+func (db *DBstorage) transitionBids(ctx context.Context, tenderID int, from []models.BidStatus, except []int, to models.BidStatus, actor string) (int, error) {
+	var bids []models.Bid
+	query := db.conn.WithContext(ctx).
+		Table("bid").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("tender_id = ? AND status IN ?", tenderID, from)
+	if len(except) > 0 {
+		query = query.Where("id NOT IN ?", except)
+	}
+	if err := query.Order("id").Find(&bids).Error; err != nil {
+		return 0, fmt.Errorf("failed to lock bids: %w", err)
+	}
+	for _, bid := range bids {
+		if err := db.transitionBid(ctx, bid, to, actor); err != nil {
+			return 0, err
+		}
+	}
+	return len(bids), nil
+}
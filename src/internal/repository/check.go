@@ -2,8 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
 )
 
 // вспомогательные функции
@@ -21,69 +27,202 @@ func (db *DBstorage) GetUserIDByUsername(username string) (int, error) {
 	return userID, nil
 }
 
-func (db *DBstorage) CheckUserPermissionForTender(tenderID int, username string) (bool, error) {
+func (db *DBstorage) GetTenderIDByBidID(bidID int) (int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Получение userID по имени пользователя (CreatorUsername)
-	userID, err := db.GetUserIDByUsername(username)
-	if err != nil {
-		return false, fmt.Errorf("failed to get user ID: %w", err)
-	}
-
-	// Проверка: является ли пользователь автором предложения или ответственным за организацию
-	var count int64
-	err = db.conn.WithContext(ctx).
+	var tenderID int
+	err := db.conn.WithContext(ctx).
 		Table("bid").
-		Joins("JOIN organization_responsible ON bid.organization_id = organization_responsible.organization_id").
-		Where("(bid.tender_id = ? AND (bid.creator_username = ? OR organization_responsible.user_id = ?))", tenderID, username, userID).
-		Count(&count).Error
+		Select("tender_id").
+		Where("id = ?", bidID).
+		Scan(&tenderID).Error
 	if err != nil {
-		return false, fmt.Errorf("failed to check user permissions: %w", err)
+		return 0, fmt.Errorf("failed to get tender ID by bid ID: %w", err)
 	}
 
-	// Если count > 0, то пользователь имеет права
-	return count > 0, nil
+	return tenderID, nil
 }
 
-func (db *DBstorage) CheckUserPermissionForBid(bidID int, username string) (bool, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// authorize проверяет права пользователя по политикам пакета authz
+func (db *DBstorage) authorize(ctx context.Context, username string, action authz.Action, resource authz.Resource) error {
+	return db.authz.Authorize(ctx, username, action, resource)
+}
 
-	// Получение userID по имени пользователя (CreatorUsername)
-	userID, err := db.GetUserIDByUsername(username)
+// Roles определяет роли пользователя относительно ресурса (реализация authz.RoleResolver)
+func (db *DBstorage) Roles(ctx context.Context, username string, resource authz.Resource) (authz.RoleSet, error) {
+	roles := authz.NewRoleSet()
+
+	var employee struct {
+		ID      int
+		IsAdmin bool
+	}
+	err := db.conn.WithContext(ctx).
+		Table("employee").
+		Select("id, is_admin").
+		Where("username = ?", username).
+		Take(&employee).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return roles, nil
+	}
 	if err != nil {
-		return false, fmt.Errorf("failed to get user ID: %w", err)
+		return nil, fmt.Errorf("failed to get employee: %w", err)
+	}
+	if employee.IsAdmin {
+		roles[authz.RoleAdmin] = true
+	}
+
+	switch resource.Kind {
+	case authz.ResourceEmployee:
+		roles[authz.RoleSelf] = employee.ID == resource.ID
+
+	case authz.ResourceOrganization:
+		responsible, err := db.isResponsible(ctx, employee.ID, resource.ID)
+		if err != nil {
+			return nil, err
+		}
+		roles[authz.RoleOrganizationResponsible] = responsible
+
+	case authz.ResourceTender:
+		var tender struct {
+			OrganizationID int
+			Status         models.TenderStatus
+			Visibility     models.TenderVisibility
+		}
+		err := db.conn.WithContext(ctx).
+			Table("tender").
+			Select("organization_id, status, visibility").
+			Where("id = ?", resource.ID).
+			Take(&tender).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("Tender %d not found", resource.ID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tender: %w", err)
+		}
+		responsible, err := db.isResponsible(ctx, employee.ID, tender.OrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		roles[authz.RoleOrganizationResponsible] = responsible
+		viewer, err := db.canViewTender(ctx, username, resource.ID, tender.Status, tender.Visibility, responsible)
+		if err != nil {
+			return nil, err
+		}
+		roles[authz.RoleTenderViewer] = viewer
+
+	case authz.ResourceBid:
+		var bid struct {
+			CreatorUsername      string
+			Status               models.BidStatus
+			AuthorType           models.BidAuthorType
+			OrganizationID       *int
+			TenderID             int
+			TenderOrganizationID int
+			TenderStatus         models.TenderStatus
+			TenderVisibility     models.TenderVisibility
+		}
+		err := db.conn.WithContext(ctx).
+			Table("bid").
+			Select("bid.creator_username, bid.status, bid.author_type, bid.organization_id, bid.tender_id, "+
+				"tender.organization_id AS tender_organization_id, tender.status AS tender_status, tender.visibility AS tender_visibility").
+			Joins("JOIN tender ON tender.id = bid.tender_id").
+			Where("bid.id = ?", resource.ID).
+			Take(&bid).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperr.NotFound("Bid %d not found", resource.ID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bid: %w", err)
+		}
+		// Пользовательским предложением управляет только создатель, предложением
+		// организации - ее текущие ответственные, включая не создававших его
+		var author bool
+		switch bid.AuthorType {
+		case models.OrganizationAuthor:
+			if bid.OrganizationID != nil {
+				author, err = db.isResponsible(ctx, employee.ID, *bid.OrganizationID)
+				if err != nil {
+					return nil, err
+				}
+			}
+		default:
+			author = bid.CreatorUsername == username
+		}
+		roles[authz.RoleBidAuthor] = author
+		// Ответственный - за организацию тендера, на который подано предложение.
+		// Неопубликованное предложение - черновик автора, организации тендера оно не видно.
+		responsible, err := db.isResponsible(ctx, employee.ID, bid.TenderOrganizationID)
+		if err != nil {
+			return nil, err
+		}
+		roles[authz.RoleOrganizationResponsible] = responsible && bid.Status != models.CreatedB
+		viewer, err := db.canViewTender(ctx, username, bid.TenderID, bid.TenderStatus, bid.TenderVisibility, responsible)
+		if err != nil {
+			return nil, err
+		}
+		roles[authz.RoleTenderViewer] = viewer
 	}
 
-	// Проверка: является ли пользователь автором предложения или ответственным за организацию
+	return roles, nil
+}
+
+func (db *DBstorage) isResponsible(ctx context.Context, userID int, organizationID int) (bool, error) {
 	var count int64
-	err = db.conn.WithContext(ctx).
-		Table("bid").
-		Joins("JOIN organization_responsible ON bid.organization_id = organization_responsible.organization_id").
-		Where("(bid.id = ? AND (bid.creator_username = ? OR organization_responsible.user_id = ?))", bidID, username, userID).
+	err := db.conn.WithContext(ctx).
+		Table("organization_responsible").
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
 		Count(&count).Error
 	if err != nil {
-		return false, fmt.Errorf("failed to check user permissions: %w", err)
+		return false, fmt.Errorf("failed to check responsibility: %w", err)
 	}
-
-	// Если count > 0, то пользователь имеет права
 	return count > 0, nil
 }
 
-func (db *DBstorage) GetTenderIDByBidID(bidID int) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+// responsibleOrganizations - подзапрос организаций, за которые отвечает пользователь
+func (db *DBstorage) responsibleOrganizations(ctx context.Context, username string) *gorm.DB {
+	return db.conn.WithContext(ctx).
+		Table("organization_responsible").
+		Select("organization_responsible.organization_id").
+		Joins("JOIN employee ON employee.id = organization_responsible.user_id").
+		Where("employee.username = ?", username)
+}
 
-	var tenderID int
+// canViewTender применяет правила видимости: созданный тендер видят только ответственные,
+// опубликованный и завершенный - в зависимости от видимости
+func (db *DBstorage) canViewTender(ctx context.Context, username string, tenderID int, status models.TenderStatus, visibility models.TenderVisibility, responsible bool) (bool, error) {
+	if responsible {
+		return true, nil
+	}
+	if status == models.CreatedT {
+		return false, nil
+	}
+	switch visibility {
+	case models.InviteOnlyTender:
+		return db.isInvited(ctx, tenderID, username)
+	case models.InternalTender:
+		return false, nil
+	}
+	return true, nil
+}
+
+// isInvited сообщает, приглашен ли пользователь к тендеру лично или через организацию
+func (db *DBstorage) isInvited(ctx context.Context, tenderID int, username string) (bool, error) {
+	var count int64
 	err := db.conn.WithContext(ctx).
-		Table("bid").
-		Select("tender_id").
-		Where("id = ?", bidID).
-		Scan(&tenderID).Error
+		Table("tender_invitation").
+		Where("tender_id = ? AND (username = ? OR organization_id IN (?))", tenderID, username, db.responsibleOrganizations(ctx, username)).
+		Count(&count).Error
 	if err != nil {
-		return 0, fmt.Errorf("failed to get tender ID by bid ID: %w", err)
+		return false, fmt.Errorf("failed to check invitation: %w", err)
 	}
+	return count > 0, nil
+}
 
-	return tenderID, nil
+// invitedTenders - подзапрос тендеров, к которым приглашен пользователь
+func (db *DBstorage) invitedTenders(ctx context.Context, username string) *gorm.DB {
+	return db.conn.WithContext(ctx).
+		Table("tender_invitation").
+		Select("tender_id").
+		Where("username = ? OR organization_id IN (?)", username, db.responsibleOrganizations(ctx, username))
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"fmt"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -10,7 +11,10 @@ import (
 
 // TODO: Создать тестовую бд для тестирования
 type DBstorage struct {
-	conn *gorm.DB
+	conn  *gorm.DB
+	authz *authz.Authorizer
+	// Типы услуг, по тендерам которых можно открыть аукцион
+	auctionTypes []string
 }
 
 func NewDB(cfg config.Config) (*DBstorage, error) {
@@ -26,7 +30,10 @@ func NewDB(cfg config.Config) (*DBstorage, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	return &DBstorage{
-		conn: db,
-	}, nil
+	storage := &DBstorage{
+		conn:         db,
+		auctionTypes: cfg.AuctionServiceTypes,
+	}
+	storage.authz = authz.New(storage)
+	return storage, nil
 }
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+// deadlineLockKey - ключ advisory-блокировки прохода по срокам: одновременно
+// проход выполняет только одна реплика, остальные его пропускают
+const deadlineLockKey = 6105014
+
+// expiryBatch - сколько тендеров обрабатывается за один проход
+const expiryBatch = 50
+
+// ExpireDeadlines подводит итоги опубликованных тендеров, срок которых
+// (models.Tender.ClosingDeadline) истек к моменту now
+func (db *DBstorage) ExpireDeadlines(now time.Time) (models.ExpiryReport, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var report models.ExpiryReport
+	var errs []error
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Блокировка транзакционная и снимается вместе с ней, даже если реплика упала
+		var locked bool
+		if err := tx.conn.WithContext(ctx).
+			Raw("SELECT pg_try_advisory_xact_lock(?)", deadlineLockKey).
+			Scan(&locked).Error; err != nil {
+			return fmt.Errorf("failed to acquire deadline lock: %w", err)
+		}
+		if !locked {
+			report.Skipped = true
+			return nil
+		}
+
+		var ids []int
+		if err := tx.conn.WithContext(ctx).
+			Table("tender").
+			// То же условие, что в models.Tender.ClosingDeadline
+			Where("status = ?", models.PublishedT).
+			Where("(decision_deadline <= ? OR (decision_deadline IS NULL AND NOT sealed AND round <= 1 AND submission_deadline <= ?))", now, now).
+			// Итоги подводятся после окончания аукциона, даже если он продлен за срок
+			Where("NOT EXISTS (SELECT 1 FROM auction WHERE auction.tender_id = tender.id AND auction.ends_at > ?)", now).
+			Order("id").
+			Limit(expiryBatch).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("failed to find expired tenders: %w", err)
+		}
+
+		// Каждый тендер обрабатывается в своей точке сохранения:
+		// ошибка по одному тендеру не отменяет итоги остальных
+		for _, id := range ids {
+			if err := tx.unitOfWork(ctx, func(tx *DBstorage) error {
+				return tx.expireTender(ctx, id, now, &report)
+			}); err != nil {
+				errs = append(errs, fmt.Errorf("tender %d: %w", id, err))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ExpiryReport{}, err
+	}
+	return report, errors.Join(errs...)
+}
+
+// expireTender отклоняет предложения, не рассмотренные в срок, и закрывает тендер.
+// Из одобренных предложений победителем становится набравшее больше голосов "за"
+// (при равенстве - поданное раньше); если одобренных нет, тендер закрывается без победителя.
+func (db *DBstorage) expireTender(ctx context.Context, id int, now time.Time, report *models.ExpiryReport) error {
+	tender, err := db.lockTender(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Тендер могли закрыть или перенести срок, пока он ждал блокировки
+	if !tender.DueForClosing(now) {
+		return nil
+	}
+	auction, err := db.auctionOf(ctx, id)
+	if err != nil {
+		return err
+	}
+	if auction != nil && auction.Status(now) != models.AuctionFinished {
+		return nil
+	}
+
+	expired, err := db.transitionBids(ctx, id, []models.BidStatus{models.PublishedB}, nil, models.DeclinedB, "")
+	if err != nil {
+		return err
+	}
+
+	summary, err := db.awardSummary(ctx, id)
+	if err != nil {
+		return err
+	}
+	var winner *models.Candidate
+	for i, c := range summary.Candidates {
+		if c.Bid.Status != models.SubmittedB {
+			continue
+		}
+		if winner == nil || c.Tally.Approvals > winner.Tally.Approvals {
+			winner = &summary.Candidates[i]
+		}
+	}
+
+	if winner == nil {
+		if err := db.transitionTender(ctx, tender, models.ClosedT, "", "deadline passed"); err != nil {
+			return err
+		}
+		report.ClosedTenders++
+	} else {
+		if err := db.award(ctx, tender, winner.Bid, "deadline passed, most approvals", ""); err != nil {
+			return err
+		}
+		report.AwardedTenders++
+	}
+	report.ExpiredBids += expired
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// organizationPolicy возвращает правила голосования организации для новых тендеров
+func (db *DBstorage) organizationPolicy(ctx context.Context, organizationID int) (models.DecisionPolicy, error) {
+	var org models.Organization
+	err := db.conn.WithContext(ctx).
+		Table("organization").
+		Select("id, decision_policy").
+		Where("id = ?", organizationID).
+		Take(&org).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DecisionPolicy{}, apperr.NotFound("Organization %d not found", organizationID)
+	}
+	if err != nil {
+		return models.DecisionPolicy{}, fmt.Errorf("failed to get organization policy: %w", err)
+	}
+	if org.DecisionPolicy == nil {
+		return models.DefaultDecisionPolicy, nil
+	}
+	return *org.DecisionPolicy, nil
+}
+
+// SetOrganizationDecisionPolicy меняет правила голосования для будущих тендеров организации
+func (db *DBstorage) SetOrganizationDecisionPolicy(organizationID int, policy models.DecisionPolicy, username string) (models.DecisionPolicy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionSetOrganizationPolicy, authz.Organization(organizationID)); err != nil {
+		return models.DecisionPolicy{}, err
+	}
+	if err := policy.Validate(); err != nil {
+		return models.DecisionPolicy{}, err
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return models.DecisionPolicy{}, fmt.Errorf("failed to encode policy: %w", err)
+	}
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		query := tx.conn.WithContext(ctx).
+			Table("organization").
+			Where("id = ?", organizationID).
+			Update("decision_policy", string(data))
+		if query.Error != nil {
+			return fmt.Errorf("failed to update organization policy: %w", query.Error)
+		}
+		if query.RowsAffected == 0 {
+			return apperr.NotFound("Organization %d not found", organizationID)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.OrganizationPolicyChangedEvent, models.OrganizationAggregate, organizationID, username, map[string]interface{}{
+			"policy": policy,
+		}))
+	})
+	if err != nil {
+		return models.DecisionPolicy{}, err
+	}
+	return policy, nil
+}
+
+// SetTenderDecisionPolicy меняет правила голосования тендера, пока по его предложениям
+// никто не голосовал. Смена правил создает новую версию тендера.
+func (db *DBstorage) SetTenderDecisionPolicy(id int, policy models.DecisionPolicy, username string, expectedVersion int) (models.Tender, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionEditTender, authz.Tender(id)); err != nil {
+		return models.Tender{}, err
+	}
+	if err := policy.Validate(); err != nil {
+		return models.Tender{}, err
+	}
+
+	var tender models.Tender
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockTender(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tenderVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", id, current.Status.API())
+		}
+
+		if err := tx.checkNoDecisions(ctx, id); err != nil {
+			return err
+		}
+
+		// Новая версия меняет ETag: правка и выбор победителя с прежним If-Match
+		// не пройдут незаметно для клиента
+		tender, err = tenderVersions.apply(ctx, tx, current, map[string]interface{}{"decision_policy": policyColumn(&policy)}, username,
+			models.TenderPolicyChangedEvent, map[string]interface{}{"policy": policy})
+		return err
+	})
+	if err != nil {
+		return models.Tender{}, err
+	}
+	return tender, nil
+}
+
+// checkNoDecisions запрещает менять правила голосования тендера, по предложениям
+// которого уже голосовали: смена правил изменила бы итог поданных голосов
+func (db *DBstorage) checkNoDecisions(ctx context.Context, tenderID int) error {
+	var votes int64
+	if err := db.conn.WithContext(ctx).
+		Table("bid_decisions").
+		Joins("JOIN bid ON bid.id = bid_decisions.bid_id").
+		Where("bid.tender_id = ?", tenderID).
+		Count(&votes).Error; err != nil {
+		return fmt.Errorf("failed to count decisions: %w", err)
+	}
+	if votes > 0 {
+		return apperr.Conflict("Tender %d already has decisions, policy cannot be changed", tenderID)
+	}
+	return nil
+}
+
+// policyColumn - значение колонки decision_policy, nil - правила по умолчанию
+func policyColumn(policy *models.DecisionPolicy) interface{} {
+	if policy == nil {
+		return nil
+	}
+	data, _ := json.Marshal(policy)
+	return string(data)
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (db *DBstorage) GetEmployeeByUsername(username string) (models.Employee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var employee models.Employee
+	err := db.conn.WithContext(ctx).
+		Table("employee").
+		Where("username = ?", username).
+		First(&employee).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Employee{}, apperr.NotFound("Employee %s not found", username)
+	}
+	if err != nil {
+		return models.Employee{}, fmt.Errorf("failed to get employee: %w", err)
+	}
+	return employee, nil
+}
+
+func (db *DBstorage) GetEmployees(username string) ([]models.Employee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionListEmployees, authz.Resource{}); err != nil {
+		return nil, err
+	}
+	var employees []models.Employee
+	if err := db.conn.WithContext(ctx).
+		Table("employee").
+		Order("id").
+		Find(&employees).Error; err != nil {
+		return nil, fmt.Errorf("failed to get employees: %w", err)
+	}
+	return employees, nil
+}
+
+func (db *DBstorage) CreateEmployee(employee models.Employee, username string) (models.Employee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionCreateEmployee, authz.Resource{}); err != nil {
+		return models.Employee{}, err
+	}
+
+	var exists int64
+	if err := db.conn.WithContext(ctx).
+		Table("employee").
+		Where("username = ?", employee.Username).
+		Count(&exists).Error; err != nil {
+		return models.Employee{}, fmt.Errorf("failed to check username: %w", err)
+	}
+	if exists > 0 {
+		return models.Employee{}, apperr.Conflict("Employee %s already exists", employee.Username)
+	}
+
+	employee.ID = 0
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if err := tx.conn.WithContext(ctx).
+			Table("employee").
+			Omit("id", "created_at", "updated_at").
+			Create(&employee).Error; err != nil {
+			return fmt.Errorf("failed to create employee: %w", err)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.EmployeeCreatedEvent, models.EmployeeAggregate, employee.ID, username, map[string]interface{}{
+			"username": employee.Username,
+			"isAdmin":  employee.IsAdmin,
+		}))
+	})
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return db.GetEmployeeByUsername(employee.Username)
+}
+
+// EditEmployee меняет данные сотрудника. Сотрудник может менять свои имя и пароль,
+// права администратора назначает только администратор.
+func (db *DBstorage) EditEmployee(employeeUsername string, update models.EmployeeUpdate, username string) (models.Employee, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	employee, err := db.GetEmployeeByUsername(employeeUsername)
+	if err != nil {
+		return models.Employee{}, err
+	}
+	if err := db.authorize(ctx, username, authz.ActionEditEmployee, authz.Employee(employee.ID)); err != nil {
+		return models.Employee{}, err
+	}
+	if update.IsAdmin != nil {
+		if err := db.authorize(ctx, username, authz.ActionGrantAdmin, authz.Employee(employee.ID)); err != nil {
+			return models.Employee{}, err
+		}
+	}
+
+	// Непереданные поля не меняются. Событие перечисляет поля без значений: в них пароль
+	changes := map[string]interface{}{"updated_at": gorm.Expr("CURRENT_TIMESTAMP")}
+	fields := []string{}
+	if update.FirstName != nil {
+		changes["first_name"] = *update.FirstName
+		fields = append(fields, "firstName")
+	}
+	if update.LastName != nil {
+		changes["last_name"] = *update.LastName
+		fields = append(fields, "lastName")
+	}
+	if update.PasswordHash != nil {
+		changes["password_hash"] = *update.PasswordHash
+		fields = append(fields, "password")
+	}
+	if update.IsAdmin != nil {
+		changes["is_admin"] = *update.IsAdmin
+		fields = append(fields, "isAdmin")
+	}
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if err := tx.conn.WithContext(ctx).
+			Table("employee").
+			Where("id = ?", employee.ID).
+			Updates(changes).Error; err != nil {
+			return fmt.Errorf("failed to update employee: %w", err)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.EmployeeEditedEvent, models.EmployeeAggregate, employee.ID, username, map[string]interface{}{
+			"username": employee.Username,
+			"fields":   fields,
+		}))
+	})
+	if err != nil {
+		return models.Employee{}, err
+	}
+	return db.GetEmployeeByUsername(employeeUsername)
+}
+
+// DeleteEmployee удаляет сотрудника без тендеров и предложений: они удалились бы
+// каскадом. Последнего ответственного организации с открытыми тендерами удалить нельзя.
+func (db *DBstorage) DeleteEmployee(employeeUsername, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	employee, err := db.GetEmployeeByUsername(employeeUsername)
+	if err != nil {
+		return err
+	}
+	if err := db.authorize(ctx, username, authz.ActionDeleteEmployee, authz.Employee(employee.ID)); err != nil {
+		return err
+	}
+
+	return db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Блокируем организации сотрудника в одном порядке, как и при снятии ответственных
+		var organizations []int
+		if err := tx.conn.WithContext(ctx).
+			Table("organization").
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN (?)", tx.conn.Table("organization_responsible").Select("organization_id").Where("user_id = ?", employee.ID)).
+			Order("id").
+			Pluck("id", &organizations).Error; err != nil {
+			return fmt.Errorf("failed to lock organizations: %w", err)
+		}
+		for _, id := range organizations {
+			if err := tx.checkNotLastResponsible(ctx, id, employee.ID); err != nil {
+				return err
+			}
+		}
+
+		var authored int64
+		if err := tx.conn.WithContext(ctx).
+			Raw("SELECT (SELECT COUNT(*) FROM tender WHERE creator_username = ?) + (SELECT COUNT(*) FROM bid WHERE creator_username = ?)", employee.Username, employee.Username).
+			Scan(&authored).Error; err != nil {
+			return fmt.Errorf("failed to count employee tenders and bids: %w", err)
+		}
+		if authored > 0 {
+			return apperr.Conflict("Employee %s has created tenders or bids", employee.Username)
+		}
+
+		if err := tx.conn.WithContext(ctx).
+			Table("employee").
+			Where("id = ?", employee.ID).
+			Delete(&models.Employee{}).Error; err != nil {
+			return fmt.Errorf("failed to delete employee: %w", err)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.EmployeeDeletedEvent, models.EmployeeAggregate, employee.ID, username, map[string]interface{}{
+			"username": employee.Username,
+		}))
+	})
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+// EventVisible проверяет, можно ли передать событие подписчику: событие
+// предложения видят те, кому доступно само предложение, голоса - те, кому
+// доступны голоса, остальное - те, кому доступен тендер. Удаленные к моменту
+// проверки тендеры и предложения считаются невидимыми.
+func (db *DBstorage) EventVisible(event models.Event, username string, scope models.EventScope) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if event.Type == models.Resync {
+		return true, nil
+	}
+
+	var err error
+	switch {
+	case scope == models.OwnBidEvents:
+		// Голоса по своему предложению автору не показываются
+		if event.BidID == nil || event.Type == models.DecisionChanged {
+			return false, nil
+		}
+		err = db.authorize(ctx, username, authz.ActionWatchOwnBid, authz.Bid(*event.BidID))
+	case event.BidID == nil:
+		err = db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(event.TenderID))
+	case event.Type == models.DecisionChanged:
+		err = db.authorize(ctx, username, authz.ActionViewDecisions, authz.Bid(*event.BidID))
+	default:
+		err = db.authorize(ctx, username, authz.ActionViewBid, authz.Bid(*event.BidID))
+	}
+	if errors.Is(err, authz.ErrForbidden) || errors.Is(err, apperr.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
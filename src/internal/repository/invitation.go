@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm/clause"
+)
+
+// SetTenderVisibility меняет видимость тендера. Видимость не входит в версию,
+// поэтому номер версии не меняется, но If-Match проверяется как при правке.
+func (db *DBstorage) SetTenderVisibility(id int, visibility models.TenderVisibility, username string, expectedVersion int) (models.Tender, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionEditTender, authz.Tender(id)); err != nil {
+		return models.Tender{}, err
+	}
+
+	var tender models.Tender
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockTender(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tenderVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", id, current.Status.API())
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("tender").
+			Where("id = ?", id).
+			Update("visibility", visibility).Error; err != nil {
+			return fmt.Errorf("failed to update tender visibility: %w", err)
+		}
+		current.Visibility = visibility
+		tender = current
+		return tx.emit(ctx, models.TenderEvent(models.TenderVisibilityChangedEvent, id, username, map[string]interface{}{
+			"visibility": visibility,
+		}))
+	})
+	if err != nil {
+		return models.Tender{}, err
+	}
+	return tender, nil
+}
+
+func (db *DBstorage) GetInvitations(tenderID int, username string) ([]models.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewInvitations, authz.Tender(tenderID)); err != nil {
+		return nil, err
+	}
+	invitations := []models.Invitation{}
+	if err := db.conn.WithContext(ctx).
+		Table("tender_invitation").
+		Where("tender_id = ?", tenderID).
+		Order("id").
+		Find(&invitations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// Invite приглашает к тендеру организацию или пользователя. Приглашения можно
+// готовить заранее: они действуют, пока у тендера видимость InviteOnly.
+func (db *DBstorage) Invite(tenderID int, invitation models.Invitation, username string) (models.Invitation, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionInvite, authz.Tender(tenderID)); err != nil {
+		return models.Invitation{}, err
+	}
+	if err := invitation.Validate(); err != nil {
+		return models.Invitation{}, err
+	}
+	invitation.ID = 0
+	invitation.TenderID = tenderID
+	invitation.InvitedBy = username
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		// Блокировка тендера упорядочивает приглашения к нему и проверку на повтор
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if !tender.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", tenderID, tender.Status.API())
+		}
+
+		query := tx.conn.WithContext(ctx).
+			Table("tender_invitation").
+			Where("tender_id = ?", tenderID)
+		if invitation.OrganizationID != nil {
+			if *invitation.OrganizationID == tender.OrganizationID {
+				return apperr.Conflict("Organization %d owns tender %d", tender.OrganizationID, tenderID)
+			}
+			if _, err := tx.getOrganization(ctx, *invitation.OrganizationID); err != nil {
+				return err
+			}
+			query = query.Where("organization_id = ?", *invitation.OrganizationID)
+		} else {
+			if _, err := tx.GetEmployeeByUsername(*invitation.Username); err != nil {
+				return err
+			}
+			query = query.Where("username = ?", *invitation.Username)
+		}
+		var count int64
+		if err := query.Count(&count).Error; err != nil {
+			return fmt.Errorf("failed to check invitation: %w", err)
+		}
+		if count > 0 {
+			return apperr.Conflict("Already invited to tender %d", tenderID)
+		}
+
+		if err := tx.conn.WithContext(ctx).
+			Table("tender_invitation").
+			Omit("id", "created_at").
+			Create(&invitation).Error; err != nil {
+			return fmt.Errorf("failed to create invitation: %w", err)
+		}
+		return tx.emit(ctx, models.TenderEvent(models.InvitationSentEvent, tenderID, username, invitationData(invitation)))
+	})
+	if err != nil {
+		return models.Invitation{}, err
+	}
+	return db.getInvitation(ctx, tenderID, invitation.ID)
+}
+
+func (db *DBstorage) getInvitation(ctx context.Context, tenderID, id int) (models.Invitation, error) {
+	var invitation models.Invitation
+	err := db.conn.WithContext(ctx).
+		Table("tender_invitation").
+		Where("id = ? AND tender_id = ?", id, tenderID).
+		Take(&invitation).Error
+	if err != nil {
+		return models.Invitation{}, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return invitation, nil
+}
+
+// RevokeInvitation отзывает приглашение. Уже поданные предложения остаются,
+// их авторы продолжают видеть свои предложения, но не тендер.
+func (db *DBstorage) RevokeInvitation(tenderID, invitationID int, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionInvite, authz.Tender(tenderID)); err != nil {
+		return err
+	}
+	return db.unitOfWork(ctx, func(tx *DBstorage) error {
+		var invitation models.Invitation
+		query := tx.conn.WithContext(ctx).
+			Table("tender_invitation").
+			Clauses(clause.Returning{}).
+			Where("id = ? AND tender_id = ?", invitationID, tenderID).
+			Delete(&invitation)
+		if query.Error != nil {
+			return fmt.Errorf("failed to revoke invitation: %w", query.Error)
+		}
+		if query.RowsAffected == 0 {
+			return apperr.NotFound("Invitation %d not found", invitationID)
+		}
+		return tx.emit(ctx, models.TenderEvent(models.InvitationRevokedEvent, tenderID, username, invitationData(invitation)))
+	})
+}
+
+// invitationData - данные события о приглашении: кого приглашение касается
+func invitationData(invitation models.Invitation) map[string]interface{} {
+	data := map[string]interface{}{"invitationId": invitation.ID}
+	if invitation.OrganizationID != nil {
+		data["organizationId"] = *invitation.OrganizationID
+	}
+	if invitation.Username != nil {
+		data["username"] = *invitation.Username
+	}
+	return data
+}
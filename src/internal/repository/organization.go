@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openTenderStatuses - тендеры, которым еще нужны ответственные
+var openTenderStatuses = []models.TenderStatus{models.CreatedT, models.PublishedT}
+
+func (db *DBstorage) GetOrganizations(username string) ([]models.Organization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionListOrganizations, authz.Resource{}); err != nil {
+		return nil, err
+	}
+	var organizations []models.Organization
+	if err := db.conn.WithContext(ctx).
+		Table("organization").
+		Order("id").
+		Find(&organizations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+	return organizations, nil
+}
+
+func (db *DBstorage) GetOrganization(id int, username string) (models.Organization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewOrganization, authz.Organization(id)); err != nil {
+		return models.Organization{}, err
+	}
+	return db.getOrganization(ctx, id)
+}
+
+func (db *DBstorage) getOrganization(ctx context.Context, id int) (models.Organization, error) {
+	var organization models.Organization
+	err := db.conn.WithContext(ctx).
+		Table("organization").
+		Where("id = ?", id).
+		Take(&organization).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Organization{}, apperr.NotFound("Organization %d not found", id)
+	}
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return organization, nil
+}
+
+// lockOrganization блокирует организацию до конца транзакции: изменения состава
+// ответственных одной организации выполняются по очереди
+func (db *DBstorage) lockOrganization(ctx context.Context, id int) (models.Organization, error) {
+	var organization models.Organization
+	err := db.conn.WithContext(ctx).
+		Table("organization").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Take(&organization).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Organization{}, apperr.NotFound("Organization %d not found", id)
+	}
+	if err != nil {
+		return models.Organization{}, fmt.Errorf("failed to lock organization: %w", err)
+	}
+	return organization, nil
+}
+
+func (db *DBstorage) CreateOrganization(organization models.Organization, username string) (models.Organization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionCreateOrganization, authz.Resource{}); err != nil {
+		return models.Organization{}, err
+	}
+	organization.ID = 0
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if err := tx.conn.WithContext(ctx).
+			Table("organization").
+			Omit("id", "created_at", "updated_at").
+			Create(&organization).Error; err != nil {
+			return fmt.Errorf("failed to create organization: %w", err)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.OrganizationCreatedEvent, models.OrganizationAggregate, organization.ID, username, map[string]interface{}{
+			"name": organization.Name,
+			"type": organization.Type,
+		}))
+	})
+	if err != nil {
+		return models.Organization{}, err
+	}
+	return db.getOrganization(ctx, organization.ID)
+}
+
+func (db *DBstorage) EditOrganization(id int, update models.OrganizationUpdate, username string) (models.Organization, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionEditOrganization, authz.Organization(id)); err != nil {
+		return models.Organization{}, err
+	}
+
+	// Непереданные поля не меняются
+	changes := map[string]interface{}{"updated_at": gorm.Expr("CURRENT_TIMESTAMP")}
+	fields := []string{}
+	if update.Name != nil {
+		changes["name"] = *update.Name
+		fields = append(fields, "name")
+	}
+	if update.Description != nil {
+		changes["description"] = *update.Description
+		fields = append(fields, "description")
+	}
+	if update.Type != nil {
+		changes["type"] = *update.Type
+		fields = append(fields, "type")
+	}
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		query := tx.conn.WithContext(ctx).
+			Table("organization").
+			Where("id = ?", id).
+			Updates(changes)
+		if query.Error != nil {
+			return fmt.Errorf("failed to update organization: %w", query.Error)
+		}
+		if query.RowsAffected == 0 {
+			return apperr.NotFound("Organization %d not found", id)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.OrganizationEditedEvent, models.OrganizationAggregate, id, username, map[string]interface{}{
+			"fields": fields,
+		}))
+	})
+	if err != nil {
+		return models.Organization{}, err
+	}
+	return db.getOrganization(ctx, id)
+}
+
+// DeleteOrganization удаляет организацию без тендеров и предложений: удаление
+// каскадом стерло бы их вместе с историей и голосами
+func (db *DBstorage) DeleteOrganization(id int, username string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionDeleteOrganization, authz.Organization(id)); err != nil {
+		return err
+	}
+
+	return db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if _, err := tx.lockOrganization(ctx, id); err != nil {
+			return err
+		}
+		var used int64
+		if err := tx.conn.WithContext(ctx).
+			Raw("SELECT (SELECT COUNT(*) FROM tender WHERE organization_id = ?) + (SELECT COUNT(*) FROM bid WHERE organization_id = ?)", id, id).
+			Scan(&used).Error; err != nil {
+			return fmt.Errorf("failed to count organization tenders and bids: %w", err)
+		}
+		if used > 0 {
+			return apperr.Conflict("Organization %d has tenders or bids", id)
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("organization").
+			Where("id = ?", id).
+			Delete(&models.Organization{}).Error; err != nil {
+			return fmt.Errorf("failed to delete organization: %w", err)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.OrganizationDeletedEvent, models.OrganizationAggregate, id, username, nil))
+	})
+}
+
+func (db *DBstorage) GetResponsibles(organizationID int, username string) ([]models.Responsible, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewOrganization, authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+	if _, err := db.getOrganization(ctx, organizationID); err != nil {
+		return nil, err
+	}
+	return db.responsibles(ctx, organizationID)
+}
+
+func (db *DBstorage) responsibles(ctx context.Context, organizationID int) ([]models.Responsible, error) {
+	var rows []struct {
+		models.Employee
+		Role string
+	}
+	if err := db.conn.WithContext(ctx).
+		Table("organization_responsible").
+		Select("employee.*, organization_responsible.role").
+		Joins("JOIN employee ON employee.id = organization_responsible.user_id").
+		Where("organization_responsible.organization_id = ?", organizationID).
+		Order("employee.id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get responsibles: %w", err)
+	}
+	responsibles := make([]models.Responsible, 0, len(rows))
+	for _, r := range rows {
+		responsibles = append(responsibles, models.Responsible{Employee: r.Employee, Role: r.Role})
+	}
+	return responsibles, nil
+}
+
+// SetResponsible назначает сотрудника ответственным за организацию или меняет его роль
+func (db *DBstorage) SetResponsible(organizationID int, employeeUsername, role, username string) ([]models.Responsible, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionManageResponsibles, authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+	if role == "" {
+		role = models.DefaultRole
+	}
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if _, err := tx.lockOrganization(ctx, organizationID); err != nil {
+			return err
+		}
+		employee, err := tx.GetEmployeeByUsername(employeeUsername)
+		if err != nil {
+			return err
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("organization_responsible").
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "organization_id"}, {Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{"role": role}),
+			}).
+			Create(map[string]interface{}{
+				"organization_id": organizationID,
+				"user_id":         employee.ID,
+				"role":            role,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to save responsible: %w", err)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.ResponsibleAssignedEvent, models.OrganizationAggregate, organizationID, username, map[string]interface{}{
+			"username": employee.Username,
+			"role":     role,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db.responsibles(ctx, organizationID)
+}
+
+// RemoveResponsible снимает сотрудника с организации. Последнего ответственного
+// организации с открытыми тендерами снять нельзя: тендеры остались бы без управления.
+func (db *DBstorage) RemoveResponsible(organizationID int, employeeUsername, username string) ([]models.Responsible, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionManageResponsibles, authz.Organization(organizationID)); err != nil {
+		return nil, err
+	}
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if _, err := tx.lockOrganization(ctx, organizationID); err != nil {
+			return err
+		}
+		employee, err := tx.GetEmployeeByUsername(employeeUsername)
+		if err != nil {
+			return err
+		}
+		if err := tx.checkNotLastResponsible(ctx, organizationID, employee.ID); err != nil {
+			return err
+		}
+		query := tx.conn.WithContext(ctx).
+			Table("organization_responsible").
+			Where("organization_id = ? AND user_id = ?", organizationID, employee.ID).
+			Delete(&models.OrganizationResponsible{})
+		if query.Error != nil {
+			return fmt.Errorf("failed to remove responsible: %w", query.Error)
+		}
+		if query.RowsAffected == 0 {
+			return apperr.NotFound("Employee %s is not responsible for organization %d", employeeUsername, organizationID)
+		}
+		return tx.emit(ctx, models.NewDomainEvent(models.ResponsibleRemovedEvent, models.OrganizationAggregate, organizationID, username, map[string]interface{}{
+			"username": employee.Username,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return db.responsibles(ctx, organizationID)
+}
+
+// checkNotLastResponsible не дает оставить организацию с открытыми тендерами
+// без ответственных. Организация должна быть заблокирована.
+func (db *DBstorage) checkNotLastResponsible(ctx context.Context, organizationID, userID int) error {
+	var others int64
+	if err := db.conn.WithContext(ctx).
+		Table("organization_responsible").
+		Where("organization_id = ? AND user_id <> ?", organizationID, userID).
+		Count(&others).Error; err != nil {
+		return fmt.Errorf("failed to count responsibles: %w", err)
+	}
+	if others > 0 {
+		return nil
+	}
+	var open int64
+	if err := db.conn.WithContext(ctx).
+		Table("tender").
+		Where("organization_id = ? AND status IN ?", organizationID, openTenderStatuses).
+		Count(&open).Error; err != nil {
+		return fmt.Errorf("failed to count open tenders: %w", err)
+	}
+	if open > 0 {
+		return apperr.Conflict("Organization %d has %d open tenders and no other responsibles", organizationID, open)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// emit пишет доменное событие в outbox. Вызывается внутри unitOfWork: событие
+// фиксируется вместе с изменением и откатывается вместе с ним.
+func (db *DBstorage) emit(ctx context.Context, event models.DomainEvent) error {
+	if err := db.conn.WithContext(ctx).
+		Table("outbox").
+		Select("type", "aggregate", "aggregate_id", "actor", "data").
+		Create(&event).Error; err != nil {
+		return fmt.Errorf("failed to write %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// ClaimEvents выдает до limit недоставленных событий, срок попытки которых
+// наступил, и откладывает их повторную выдачу на lease. Реплики забирают разные
+// события (SKIP LOCKED); если реплика не подтвердила доставку за lease, событие
+// выдается снова.
+func (db *DBstorage) ClaimEvents(limit int, lease time.Duration) ([]models.DomainEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var events []models.DomainEvent
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if err := tx.conn.WithContext(ctx).
+			Table("outbox").
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("delivered_at IS NULL AND next_attempt_at <= CURRENT_TIMESTAMP").
+			Order("id").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return fmt.Errorf("failed to claim events: %w", err)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+		ids := make([]int, 0, len(events))
+		for i := range events {
+			events[i].Attempts++
+			ids = append(ids, events[i].ID)
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("outbox").
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": gorm.Expr("CURRENT_TIMESTAMP + make_interval(secs => ?)", lease.Seconds()),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to lease events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// AckEvent отмечает событие доставленным
+func (db *DBstorage) AckEvent(id int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.conn.WithContext(ctx).
+		Table("outbox").
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"delivered_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			"last_error":   "",
+		}).Error; err != nil {
+		return fmt.Errorf("failed to ack event %d: %w", id, err)
+	}
+	return nil
+}
+
+// RetryEvent откладывает доставку события на delay и запоминает причину неудачи
+func (db *DBstorage) RetryEvent(id int, delay time.Duration, reason string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.conn.WithContext(ctx).
+		Table("outbox").
+		Where("id = ? AND delivered_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"next_attempt_at": gorm.Expr("CURRENT_TIMESTAMP + make_interval(secs => ?)", delay.Seconds()),
+			"last_error":      reason,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to reschedule event %d: %w", id, err)
+	}
+	return nil
+}
+
+// PurgeEvents удаляет события, доставленные раньше чем age назад
+func (db *DBstorage) PurgeEvents(age time.Duration) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	query := db.conn.WithContext(ctx).
+		Table("outbox").
+		Where("delivered_at < CURRENT_TIMESTAMP - make_interval(secs => ?)", age.Seconds()).
+		Delete(&models.DomainEvent{})
+	if query.Error != nil {
+		return 0, fmt.Errorf("failed to purge delivered events: %w", query.Error)
+	}
+	return int(query.RowsAffected), nil
+}
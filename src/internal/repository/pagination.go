@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// выражения, по которым разрешена сортировка, %[1]s - имя таблицы.
+// Суммы в разных валютах несравнимы, поэтому цена сортируется по валюте, затем
+// по сумме: ключ "RUB:0000000000000150050" (см. priceKey). Предложения без цены
+// при сортировке по цене идут последними.
+var sortColumns = map[string]string{
+	"name":    "%[1]s.name",
+	"id":      "%[1]s.id",
+	"version": "%[1]s.version",
+	"price":   "(CASE WHEN %[1]s.price_currency = '' THEN '" + unpricedKey + "' ELSE %[1]s.price_currency || ':' || lpad(%[1]s.price_amount::text, 19, '0') END) COLLATE \"C\"",
+}
+
+// textSorts - поля сортировки, значения которых в курсоре сравниваются как строки
+var textSorts = map[string]bool{"name": true, "price": true}
+
+// unpricedKey - ключ сортировки по цене для предложений без цены, больше любого ключа с валютой
+const unpricedKey = "~"
+
+// priceKey возвращает ключ сортировки цены, совпадающий с выражением sortColumns["price"]
+func priceKey(m models.Money) string {
+	if m.IsZero() {
+		return unpricedKey
+	}
+	return fmt.Sprintf("%s:%019d", m.Currency, int64(m.Amount))
+}
+
+// sortKey - значения полей сортировки записи для курсора следующей страницы
+type sortKey struct {
+	Name    string
+	ID      int
+	Version int
+	Price   string
+}
+
+// cursor указывает на последнюю запись страницы: значение колонки сортировки и id
+type cursor struct {
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var c cursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, apperr.Wrap(apperr.ErrInvalid, err, "Invalid cursor")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return cursor{}, apperr.Wrap(apperr.ErrInvalid, err, "Invalid cursor")
+	}
+	return c, nil
+}
+
+// paginate считает общее число записей запроса и применяет к нему сортировку,
+// курсор (или смещение) и лимит. Запрашивается на одну запись больше лимита,
+// чтобы понять, есть ли следующая страница.
+func paginate(query *gorm.DB, table string, params models.ListParams, defaultSort string) (*gorm.DB, int64, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count records: %w", err)
+	}
+
+	sortBy := params.SortBy
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+	column, ok := sortColumns[sortBy]
+	if !ok {
+		return nil, 0, apperr.Invalid("Unsupported sort field %q", sortBy)
+	}
+	column = fmt.Sprintf(column, table)
+	direction, cmp := "ASC", ">"
+	if params.Desc {
+		direction, cmp = "DESC", "<"
+	}
+
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		var value interface{} = c.Value
+		if !textSorts[sortBy] {
+			if value, err = strconv.ParseInt(c.Value, 10, 64); err != nil {
+				return nil, 0, apperr.Wrap(apperr.ErrInvalid, err, "Invalid cursor value")
+			}
+		}
+		query = query.Where(fmt.Sprintf("(%s, %s.id) %s (?, ?)", column, table, cmp), value, c.ID)
+	} else {
+		query = query.Offset(params.Offset)
+	}
+
+	query = query.
+		Order(column + " " + direction).
+		Order(table + ".id " + direction).
+		Limit(params.Limit + 1)
+	return query, total, nil
+}
+
+// pageOf отрезает лишнюю запись, запрошенную paginate, и строит курсор следующей страницы
+func pageOf[T any](items []T, params models.ListParams, defaultSort string, total int64, key func(T) sortKey) ([]T, models.Page) {
+	page := models.Page{Total: total}
+	if len(items) <= params.Limit {
+		return items, page
+	}
+	items = items[:params.Limit]
+	if len(items) == 0 {
+		return items, page
+	}
+
+	last := key(items[len(items)-1])
+	sortBy := params.SortBy
+	if sortBy == "" {
+		sortBy = defaultSort
+	}
+	value := strconv.Itoa(last.ID)
+	switch sortBy {
+	case "name":
+		value = last.Name
+	case "version":
+		value = strconv.Itoa(last.Version)
+	case "price":
+		value = last.Price
+	}
+	page.NextCursor = encodeCursor(cursor{Value: value, ID: last.ID})
+	return items, page
+}
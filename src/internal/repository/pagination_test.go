@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"testing"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageOf(t *testing.T) {
+	tenders := []models.Tender{
+		{ID: 3, Name: "a", Version: 1},
+		{ID: 1, Name: "b", Version: 2},
+		{ID: 2, Name: "c", Version: 1},
+	}
+
+	// Запрошено на одну запись больше лимита - есть следующая страница
+	items, page := pageOf(tenders, models.ListParams{Limit: 2}, "name", 10, tenderKey)
+	assert.Len(t, items, 2)
+	assert.Equal(t, int64(10), page.Total)
+	c, err := decodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor{Value: "b", ID: 1}, c)
+
+	items, page = pageOf(tenders, models.ListParams{Limit: 2, SortBy: "version"}, "name", 10, tenderKey)
+	assert.Len(t, items, 2)
+	c, err = decodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor{Value: "2", ID: 1}, c)
+
+	// Последняя страница - курсора нет
+	items, page = pageOf(tenders, models.ListParams{Limit: 5}, "name", 3, tenderKey)
+	assert.Len(t, items, 3)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestPageOfByPrice(t *testing.T) {
+	bids := []models.Bid{
+		{ID: 2, Name: "a", Price: models.Money{Amount: 100000, Currency: "RUB"}},
+		{ID: 1, Name: "b", Price: models.Money{Amount: 150050, Currency: "RUB"}},
+		{ID: 3, Name: "c"},
+	}
+
+	_, page := pageOf(bids, models.ListParams{Limit: 2, SortBy: "price"}, "name", 3, bidKey)
+	c, err := decodeCursor(page.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor{Value: "RUB:0000000000000150050", ID: 1}, c)
+
+	// Цены группируются по валюте, внутри валюты сравниваются суммы, а не строки;
+	// предложения без цены сортируются последними
+	keys := []string{
+		priceKey(models.Money{Amount: 100000, Currency: "RUB"}),
+		priceKey(models.Money{Amount: 100000, Currency: "USD"}),
+		priceKey(models.Money{Amount: 900, Currency: "RUB"}),
+		bidKey(bids[2]).Price,
+	}
+	assert.True(t, keys[2] < keys[0])
+	assert.True(t, keys[0] < keys[1])
+	assert.True(t, keys[1] < keys[3])
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	_, err := decodeCursor("%%%")
+	assert.Error(t, err)
+}
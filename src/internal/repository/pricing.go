@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+// pricingColumns - значения колонок цены и позиций предложения для Updates.
+// Сериализатор gorm к map не применяется, поэтому позиции пишутся JSON-строкой.
+func pricingColumns(price models.Money, deliveryDays int, items []models.LineItem) map[string]interface{} {
+	data, _ := json.Marshal(items)
+	return map[string]interface{}{
+		"price_amount":   int64(price.Amount),
+		"price_currency": string(price.Currency),
+		"delivery_days":  deliveryDays,
+		"items":          string(data),
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// AskQuestion публикует вопрос участника к опубликованному тендеру
+func (db *DBstorage) AskQuestion(tenderID int, text string, username string) (models.Question, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionAskQuestion, authz.Tender(tenderID)); err != nil {
+		return models.Question{}, err
+	}
+	// Ответственные организации отвечают на вопросы, а не задают их
+	err := db.authorize(ctx, username, authz.ActionAnswerQuestion, authz.Tender(tenderID))
+	if err == nil {
+		return models.Question{}, apperr.Conflict("User %s is responsible for tender %d and cannot ask questions about it", username, tenderID)
+	}
+	if !errors.Is(err, authz.ErrForbidden) {
+		return models.Question{}, err
+	}
+
+	question := models.Question{TenderID: tenderID, Text: text, AskedBy: username}
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if tender.Status != models.PublishedT {
+			return apperr.Conflict("Questions cannot be asked about tender %d in status %s", tenderID, tender.Status.API())
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("tender_question").
+			Omit("id", "created_at").
+			Create(&question).Error; err != nil {
+			return fmt.Errorf("failed to create question: %w", err)
+		}
+		return tx.emit(ctx, models.TenderEvent(models.QuestionAskedEvent, tenderID, username, map[string]interface{}{
+			"questionId": question.ID,
+		}))
+	})
+	if err != nil {
+		return models.Question{}, err
+	}
+	return db.getQuestion(ctx, tenderID, question.ID)
+}
+
+// GetQuestions возвращает вопросы к тендеру. Участники видят вопросы с ответами
+// и свои вопросы; авторы чужих вопросов им не показываются.
+func (db *DBstorage) GetQuestions(tenderID int, username string) ([]models.Question, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(tenderID)); err != nil {
+		return nil, err
+	}
+	all := true
+	err := db.authorize(ctx, username, authz.ActionViewAllQuestions, authz.Tender(tenderID))
+	if errors.Is(err, authz.ErrForbidden) {
+		all = false
+	} else if err != nil {
+		return nil, err
+	}
+
+	questions := []models.Question{}
+	query := db.conn.WithContext(ctx).
+		Table("tender_question").
+		Where("tender_id = ?", tenderID)
+	if !all {
+		query = query.Where("(answer IS NOT NULL OR asked_by = ?)", username)
+	}
+	if err := query.Order("id").Find(&questions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	if !all {
+		for i := range questions {
+			if questions[i].AskedBy != username {
+				questions[i].AskedBy = ""
+			}
+		}
+	}
+	return questions, nil
+}
+
+// AnswerQuestion публикует ответ на вопрос; повторный ответ заменяет прежний
+func (db *DBstorage) AnswerQuestion(tenderID, questionID int, answer string, username string) (models.Question, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionAnswerQuestion, authz.Tender(tenderID)); err != nil {
+		return models.Question{}, err
+	}
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if tender.Status.Final() {
+			return apperr.Conflict("Questions about tender %d cannot be answered in status %s", tenderID, tender.Status.API())
+		}
+		query := tx.conn.WithContext(ctx).
+			Table("tender_question").
+			Where("id = ? AND tender_id = ?", questionID, tenderID).
+			Updates(map[string]interface{}{
+				"answer":      answer,
+				"answered_by": username,
+				"answered_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			})
+		if query.Error != nil {
+			return fmt.Errorf("failed to answer question: %w", query.Error)
+		}
+		if query.RowsAffected == 0 {
+			return apperr.NotFound("Question %d not found", questionID)
+		}
+		return tx.emit(ctx, models.TenderEvent(models.QuestionAnsweredEvent, tenderID, username, map[string]interface{}{
+			"questionId": questionID,
+		}))
+	})
+	if err != nil {
+		return models.Question{}, err
+	}
+	return db.getQuestion(ctx, tenderID, questionID)
+}
+
+func (db *DBstorage) getQuestion(ctx context.Context, tenderID, id int) (models.Question, error) {
+	var question models.Question
+	err := db.conn.WithContext(ctx).
+		Table("tender_question").
+		Where("id = ? AND tender_id = ?", id, tenderID).
+		Take(&question).Error
+	if err != nil {
+		return models.Question{}, fmt.Errorf("failed to get question: %w", err)
+	}
+	return question, nil
+}
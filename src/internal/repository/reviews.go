@@ -5,69 +5,74 @@ import (
 	"fmt"
 	"time"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
 )
 
-func (db *DBstorage) GetReviewsByAuthorAndTender(tenderID int, username string, organizationID int) ([]models.Review, error) {
+func (db *DBstorage) GetReviewsByAuthorAndTender(tenderID int, authorUsername string, requesterUsername string, params models.ListParams) ([]models.Review, models.Page, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Получение userID по имени пользователя (CreatorUsername)
-	var userID int
-	err := db.conn.WithContext(ctx).
-		Table("employee").
-		Select("id").
-		Where("username = ?", username).
-		Scan(&userID).Error
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user ID: %w", err)
-	}
-
-	// Проверка: является ли пользователь ответственным за организацию
-	var count int64
-	err = db.conn.WithContext(ctx).
-		Table("organization_responsible").
-		Where("organization_id = ? AND user_id = ?", organizationID, userID).
-		Count(&count).Error
-	if err != nil {
-		return nil, fmt.Errorf("failed to check user responsibilities: %w", err)
-	}
-
-	// Если count == 0, то пользователь не является ответственным за организацию
-	if count == 0 {
-		return nil, fmt.Errorf("user does not have permission to view reviews")
+	// Проверка: является ли запрашивающий ответственным за организацию тендера
+	if err := db.authorize(ctx, requesterUsername, authz.ActionViewAuthorReviews, authz.Tender(tenderID)); err != nil {
+		return nil, models.Page{}, fmt.Errorf("user does not have permission to view reviews: %w", err)
 	}
 
 	// Получение отзывов на предложения, созданные автором, для указанного тендера
 	var reviews []models.Review
-	err = db.conn.WithContext(ctx).
+	query := db.conn.WithContext(ctx).
 		Table("reviews").
+		Select("reviews.*").
 		Joins("JOIN bid ON reviews.bid_id = bid.id").
-		Where("bid.tender_id = ? AND bid.creator_username = ? AND bid.organization_id = ?", tenderID, username, organizationID).
-		Find(&reviews).Error
+		Where("bid.tender_id = ? AND bid.creator_username = ?", tenderID, authorUsername)
+
+	query, total, err := paginate(query, "reviews", params, "id")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviews: %w", err)
+		return nil, models.Page{}, err
 	}
-
-	return reviews, nil
+	if err := query.Find(&reviews).Error; err != nil {
+		return nil, models.Page{}, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	reviews, page := pageOf(reviews, params, "id", total, func(r models.Review) sortKey {
+		return sortKey{ID: r.ID}
+	})
+	return reviews, page, nil
 }
 
-func (db *DBstorage) AddFeedback(reviews models.Review, username string) error {
+func (db *DBstorage) AddFeedback(review models.Review, username string) (models.Bid, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	// Проверка прав пользователя
-	hasPermission, err := db.CheckUserPermissionForTender(reviews.BidID, username)
-	if err != nil {
-		return fmt.Errorf("failed to check user permissions: %w", err)
-	}
-	if !hasPermission {
-		return fmt.Errorf("user does not have permission to add feedback")
+	if err := db.authorize(ctx, username, authz.ActionAddFeedback, authz.Bid(review.BidID)); err != nil {
+		return models.Bid{}, fmt.Errorf("user does not have permission to add feedback: %w", err)
 	}
 
-	err = db.conn.WithContext(ctx).Table("reviews").Create(&reviews).Error
+	// Отзыв оставляется от имени организации тендера
+	err := db.conn.WithContext(ctx).
+		Table("bid").
+		Select("tender.organization_id").
+		Joins("JOIN tender ON tender.id = bid.tender_id").
+		Where("bid.id = ?", review.BidID).
+		Scan(&review.OrganizationID).Error
 	if err != nil {
-		return fmt.Errorf("failed to add feedback: %w", err)
+		return models.Bid{}, fmt.Errorf("failed to get tender organization: %w", err)
 	}
 
-	return nil
+	bid, err := db.getBid(ctx, review.BidID)
+	if err != nil {
+		return models.Bid{}, err
+	}
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if err := tx.conn.WithContext(ctx).Table("reviews").Create(&review).Error; err != nil {
+			return fmt.Errorf("failed to add feedback: %w", err)
+		}
+		return tx.emit(ctx, models.BidEvent(models.ReviewAddedEvent, bid, username, map[string]interface{}{
+			"reviewId":       review.ID,
+			"organizationId": review.OrganizationID,
+		}))
+	})
+	if err != nil {
+		return models.Bid{}, err
+	}
+	return bid, nil
 }
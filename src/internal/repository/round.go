@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+func roundError(tender models.Tender) error {
+	return apperr.Conflict("Round %d of tender %d is open until %s", tender.Round, tender.ID, formatDeadline(tender.RoundDeadline))
+}
+
+// checkRevisable не дает менять предложение вне окна пересмотра: после окончания
+// подачи, после окончания раунда и в раундах, в шорт-лист которых оно не вошло.
+// Для закрытого тендера окно закрывается в момент раскрытия предложений.
+func checkRevisable(tender models.Tender, bid models.Bid, now time.Time) error {
+	if tender.Round > 1 && bid.Round != tender.Round {
+		return apperr.Conflict("Bid %d is not shortlisted for round %d of tender %d", bid.ID, tender.Round, tender.ID)
+	}
+	if tender.RevisionOpen(now) {
+		return nil
+	}
+	if tender.Round > 1 {
+		return apperr.Conflict("Round %d of tender %d ended at %s", tender.Round, tender.ID, formatDeadline(tender.RoundDeadline))
+	}
+	return apperr.Conflict("Bids of tender %d cannot be changed after the submission deadline", tender.ID)
+}
+
+// checkDecidable не дает голосовать и оценивать, пока предложения скрыты или пересматриваются
+func checkDecidable(tender models.Tender, now time.Time) error {
+	if tender.BidsSealed(now) {
+		return sealedError(tender)
+	}
+	if tender.InRevisionRound(now) {
+		return roundError(tender)
+	}
+	return nil
+}
+
+// OpenRound открывает следующий раунд тендера (best and final offer). Предложения
+// из шорт-листа получают новую версию с номером раунда, голоса и оценки по ним
+// сбрасываются: они относились к прежним условиям. Остальные опубликованные
+// предложения отклоняются. Новые предложения после этого не принимаются.
+func (db *DBstorage) OpenRound(tenderID int, request models.RoundRequest, username string) (models.Round, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionOpenRound, authz.Tender(tenderID)); err != nil {
+		return models.Round{}, err
+	}
+
+	now := time.Now()
+	shortlist := slices.Clone(request.BidIDs)
+	slices.Sort(shortlist)
+	shortlist = slices.Compact(shortlist)
+
+	var round models.Round
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if tender.Status != models.PublishedT {
+			return apperr.Conflict("Tender %d cannot open a round in status %s", tenderID, tender.Status.API())
+		}
+		// Без срока подачи первый раунд заканчивается открытием второго
+		if tender.RevisionOpen(now) && tender.RevisionDeadline() != nil {
+			if tender.Round > 1 {
+				return roundError(tender)
+			}
+			return apperr.Conflict("Submission to tender %d is open until %s", tenderID, formatDeadline(tender.SubmissionDeadline))
+		}
+		if err := request.Validate(tender, now); err != nil {
+			return err
+		}
+		if err := tx.checkAuctionFinished(ctx, tenderID, now); err != nil {
+			return err
+		}
+
+		var published []int
+		if err := tx.conn.WithContext(ctx).
+			Table("bid").
+			Where("tender_id = ? AND status = ?", tenderID, models.PublishedB).
+			Pluck("id", &published).Error; err != nil {
+			return fmt.Errorf("failed to get published bids: %w", err)
+		}
+		for _, id := range shortlist {
+			if !slices.Contains(published, id) {
+				return apperr.Conflict("Bid %d is not a published bid of tender %d", id, tenderID)
+			}
+		}
+
+		round = models.Round{
+			TenderID: tenderID,
+			Number:   tender.Round + 1,
+			Deadline: request.Deadline.UTC(),
+			OpenedBy: username,
+			// Момент открытия совпадает с моментом, на который проверялись сроки
+			CreatedAt: now,
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("tender").
+			Where("id = ?", tenderID).
+			Updates(map[string]interface{}{"round": round.Number, "round_deadline": round.Deadline}).Error; err != nil {
+			return fmt.Errorf("failed to update tender round: %w", err)
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("tender_round").
+			Omit("id").
+			Create(&round).Error; err != nil {
+			return fmt.Errorf("failed to create round: %w", err)
+		}
+
+		for _, id := range shortlist {
+			current, err := tx.lockBid(ctx, id)
+			if err != nil {
+				return err
+			}
+			if err := tx.conn.WithContext(ctx).
+				Table("bid_decisions").
+				Where("bid_id = ?", id).
+				Delete(&models.BidDecision{}).Error; err != nil {
+				return fmt.Errorf("failed to reset decisions: %w", err)
+			}
+			if err := tx.conn.WithContext(ctx).
+				Table("bid_score").
+				Where("bid_id = ?", id).
+				Delete(&models.Score{}).Error; err != nil {
+				return fmt.Errorf("failed to reset scores: %w", err)
+			}
+			if _, err := bidVersions.commit(ctx, tx, current, map[string]interface{}{"round": round.Number}, username); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.transitionBids(ctx, tenderID, []models.BidStatus{models.PublishedB}, shortlist, models.DeclinedB, username); err != nil {
+			return err
+		}
+		return tx.emit(ctx, models.TenderEvent(models.RoundOpenedEvent, tenderID, username, map[string]interface{}{
+			"round":    round.Number,
+			"deadline": round.Deadline,
+			"bidIds":   shortlist,
+		}))
+	})
+	if err != nil {
+		return models.Round{}, err
+	}
+	round.BidIDs = shortlist
+	return round, nil
+}
+
+// GetRounds возвращает раунды пересмотра тендера. Участники видят в шорт-листах
+// только свои предложения.
+func (db *DBstorage) GetRounds(tenderID int, username string) ([]models.Round, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(tenderID)); err != nil {
+		return nil, err
+	}
+	rounds := []models.Round{}
+	if err := db.conn.WithContext(ctx).
+		Table("tender_round").
+		Where("tender_id = ?", tenderID).
+		Order("number").
+		Find(&rounds).Error; err != nil {
+		return nil, fmt.Errorf("failed to get rounds: %w", err)
+	}
+	if len(rounds) == 0 {
+		return rounds, nil
+	}
+
+	// В шорт-лист раунда N входят предложения, пересмотренные в раунде N или позже
+	var bids []models.Bid
+	query := db.conn.WithContext(ctx).
+		Table("bid").
+		Where("tender_id = ? AND round > 1", tenderID)
+	err := db.authorize(ctx, username, authz.ActionListAllTenderBids, authz.Tender(tenderID))
+	if errors.Is(err, authz.ErrForbidden) {
+		query = db.ownBids(ctx, query, username)
+	} else if err != nil {
+		return nil, err
+	}
+	if err := query.Order("id").Select("id", "round").Find(&bids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get shortlisted bids: %w", err)
+	}
+	for i := range rounds {
+		rounds[i].BidIDs = []int{}
+		for _, b := range bids {
+			if b.Round >= rounds[i].Number {
+				rounds[i].BidIDs = append(rounds[i].BidIDs, b.ID)
+			}
+		}
+	}
+	return rounds, nil
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// scoredBidStatuses - предложения, которые оценивают ответственные
+var scoredBidStatuses = []models.BidStatus{models.PublishedB, models.SubmittedB}
+
+// SetTenderCriteria заменяет критерии оценки тендера, пока по ним никто не ставил оценок
+func (db *DBstorage) SetTenderCriteria(tenderID int, criteria []models.Criterion, username string) ([]models.Criterion, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionEditTender, authz.Tender(tenderID)); err != nil {
+		return nil, err
+	}
+	if err := models.ValidateCriteria(criteria); err != nil {
+		return nil, err
+	}
+
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		tender, err := tx.lockTender(ctx, tenderID)
+		if err != nil {
+			return err
+		}
+		if !tender.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", tenderID, tender.Status.API())
+		}
+
+		var scored int64
+		if err := tx.conn.WithContext(ctx).
+			Table("bid_score").
+			Joins("JOIN tender_criterion ON tender_criterion.id = bid_score.criterion_id").
+			Where("tender_criterion.tender_id = ?", tenderID).
+			Count(&scored).Error; err != nil {
+			return fmt.Errorf("failed to count scores: %w", err)
+		}
+		if scored > 0 {
+			return apperr.Conflict("Tender %d already has scores, criteria cannot be changed", tenderID)
+		}
+
+		if err := tx.conn.WithContext(ctx).
+			Table("tender_criterion").
+			Where("tender_id = ?", tenderID).
+			Delete(&models.Criterion{}).Error; err != nil {
+			return fmt.Errorf("failed to delete criteria: %w", err)
+		}
+		for i := range criteria {
+			criteria[i].ID = 0
+			criteria[i].TenderID = tenderID
+		}
+		if err := tx.conn.WithContext(ctx).
+			Table("tender_criterion").
+			Create(&criteria).Error; err != nil {
+			return fmt.Errorf("failed to save criteria: %w", err)
+		}
+		codes := make([]string, 0, len(criteria))
+		for _, c := range criteria {
+			codes = append(codes, c.Code)
+		}
+		return tx.emit(ctx, models.TenderEvent(models.TenderCriteriaChangedEvent, tenderID, username, map[string]interface{}{
+			"criteria": codes,
+		}))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return criteria, nil
+}
+
+func (db *DBstorage) criteria(ctx context.Context, tenderID int) ([]models.Criterion, error) {
+	var criteria []models.Criterion
+	if err := db.conn.WithContext(ctx).
+		Table("tender_criterion").
+		Where("tender_id = ?", tenderID).
+		Order("id").
+		Find(&criteria).Error; err != nil {
+		return nil, fmt.Errorf("failed to get criteria: %w", err)
+	}
+	return criteria, nil
+}
+
+// ScoreBid сохраняет оценки пользователя по критериям тендера. Повторная оценка
+// по критерию заменяет прежнюю, остальные оценки пользователя не меняются.
+func (db *DBstorage) ScoreBid(bidID int, values map[string]int, username string) ([]models.Score, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionScoreBid, authz.Bid(bidID)); err != nil {
+		return nil, err
+	}
+
+	var scores []models.Score
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		bid, err := tx.lockBid(ctx, bidID)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(scoredBidStatuses, bid.Status) {
+			return apperr.Conflict("Bid %d cannot be scored in status %s", bidID, bid.Status.API())
+		}
+		tender, err := tx.tenderOfBid(ctx, bidID)
+		if err != nil {
+			return err
+		}
+		if err := checkDecidable(tender, time.Now()); err != nil {
+			return err
+		}
+		if err := tx.checkAuctionFinished(ctx, tender.ID, time.Now()); err != nil {
+			return err
+		}
+		criteria, err := tx.criteria(ctx, bid.TenderID)
+		if err != nil {
+			return err
+		}
+		updates, err := models.NewScores(criteria, bidID, username, values)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.conn.WithContext(ctx).
+			Table("bid_score").
+			Omit("id", "updated_at").
+			Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "bid_id"}, {Name: "criterion_id"}, {Name: "username"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"score":      gorm.Expr("excluded.score"),
+					"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+				}),
+			}).
+			Create(&updates).Error; err != nil {
+			return fmt.Errorf("failed to save scores: %w", err)
+		}
+		if err := tx.emit(ctx, models.BidEvent(models.BidScoredEvent, bid, username, map[string]interface{}{
+			"scores": values,
+		})); err != nil {
+			return err
+		}
+
+		return tx.conn.WithContext(ctx).
+			Table("bid_score").
+			Select("bid_score.*, tender_criterion.code AS criterion").
+			Joins("JOIN tender_criterion ON tender_criterion.id = bid_score.criterion_id").
+			Where("bid_score.bid_id = ? AND bid_score.username = ?", bidID, username).
+			Order("bid_score.criterion_id").
+			Find(&scores).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// GetTenderLeaderboard возвращает критерии тендера и рейтинг его предложений по оценкам
+func (db *DBstorage) GetTenderLeaderboard(tenderID int, username string) (models.Leaderboard, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewScores, authz.Tender(tenderID)); err != nil {
+		return models.Leaderboard{}, err
+	}
+
+	var tender models.Tender
+	err := db.conn.WithContext(ctx).
+		Table("tender").
+		Where("id = ?", tenderID).
+		First(&tender).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Leaderboard{}, apperr.NotFound("Tender %d not found", tenderID)
+	}
+	if err != nil {
+		return models.Leaderboard{}, fmt.Errorf("failed to get tender: %w", err)
+	}
+
+	criteria, err := db.criteria(ctx, tenderID)
+	if err != nil {
+		return models.Leaderboard{}, err
+	}
+	// Отклоненные предложения остаются в рейтинге, чтобы было видно, как их оценили
+	var bids []models.Bid
+	if err := db.conn.WithContext(ctx).
+		Table("bid").
+		Where("tender_id = ? AND status IN ?", tenderID, []models.BidStatus{models.PublishedB, models.SubmittedB, models.DeclinedB}).
+		Find(&bids).Error; err != nil {
+		return models.Leaderboard{}, fmt.Errorf("failed to get tender bids: %w", err)
+	}
+	var scores []models.Score
+	if err := db.conn.WithContext(ctx).
+		Table("bid_score").
+		Joins("JOIN bid ON bid.id = bid_score.bid_id").
+		Where("bid.tender_id = ?", tenderID).
+		Select("bid_score.*").
+		Find(&scores).Error; err != nil {
+		return models.Leaderboard{}, fmt.Errorf("failed to get scores: %w", err)
+	}
+
+	standings := models.Rank(criteria, bids, scores)
+	// До раскрытия оценок нет, но и содержимое предложений показывать нельзя
+	if tender.BidsSealed(time.Now()) {
+		for i := range standings {
+			standings[i].Bid = standings[i].Bid.Seal()
+		}
+	}
+	return models.Leaderboard{
+		Criteria:  criteria,
+		Standings: standings,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// tenderOfBid возвращает тендер, на который подано предложение
+func (db *DBstorage) tenderOfBid(ctx context.Context, bidID int) (models.Tender, error) {
+	var tender models.Tender
+	err := db.conn.WithContext(ctx).
+		Table("tender").
+		Joins("JOIN bid ON bid.tender_id = tender.id").
+		Where("bid.id = ?", bidID).
+		Select("tender.*").
+		Take(&tender).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Tender{}, apperr.NotFound("Bid %d not found", bidID)
+	}
+	if err != nil {
+		return models.Tender{}, fmt.Errorf("failed to get tender of bid: %w", err)
+	}
+	return tender, nil
+}
+
+func sealedError(tender models.Tender) error {
+	return apperr.Conflict("Bids of tender %d are sealed until %s", tender.ID, formatDeadline(tender.RevisionDeadline()))
+}
+
+// checkUnsealed не дает читать содержимое предложения закрытого тендера никому,
+// кроме его авторов, до окончания подачи
+func (db *DBstorage) checkUnsealed(ctx context.Context, bidID int, username string) error {
+	tender, err := db.tenderOfBid(ctx, bidID)
+	if err != nil {
+		return err
+	}
+	if !tender.BidsSealed(time.Now()) {
+		return nil
+	}
+	err = db.authorize(ctx, username, authz.ActionViewSealedBid, authz.Bid(bidID))
+	if errors.Is(err, authz.ErrForbidden) {
+		return sealedError(tender)
+	}
+	return err
+}
+
+// checkNotResealed не дает снова скрыть раскрытые предложения, перенеся срок подачи
+func checkNotResealed(before, after models.Tender, now time.Time) error {
+	if before.Sealed && !before.BidsSealed(now) && after.BidsSealed(now) {
+		return apperr.Conflict("Bids of tender %d are already revealed, submissionDeadline cannot be moved", before.ID)
+	}
+	return nil
+}
+
+// sealForeign скрывает содержимое предложений, автором которых пользователь не является
+func (db *DBstorage) sealForeign(ctx context.Context, bids []models.Bid, username string) ([]models.Bid, error) {
+	var organizations []int
+	if err := db.responsibleOrganizations(ctx, username).
+		Pluck("organization_responsible.organization_id", &organizations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get responsible organizations: %w", err)
+	}
+	for i, b := range bids {
+		own := b.AuthorType == models.UserAuthor && b.CreatorUsername == username
+		if b.AuthorType == models.OrganizationAuthor && b.OrganizationID != nil {
+			own = slices.Contains(organizations, *b.OrganizationID)
+		}
+		if !own {
+			bids[i] = b.Seal()
+		}
+	}
+	return bids, nil
+}
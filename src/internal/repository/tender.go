@@ -5,42 +5,78 @@ import (
 	"fmt"
 	"time"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
 )
 
-func (db *DBstorage) GetAllTenders(serviceType string) ([]models.Tender, error) {
+func (db *DBstorage) GetAllTenders(username string, params models.ListParams) ([]models.Tender, models.Page, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	var tenders []models.Tender
 
-	query := db.conn.WithContext(ctx).Table("tender").Model(&models.Tender{}).Where("status=?", "PUBLISHED")
-
-	if serviceType != "" {
-		query = query.Where("service_type=?", serviceType)
+	// Неопубликованные тендеры в общий список не попадают. Из остальных пользователь видит
+	// публичные, тендеры по приглашениям, к которым приглашен, и все тендеры своих организаций.
+	query := db.conn.WithContext(ctx).Table("tender").Model(&models.Tender{}).
+		Where("status <> ?", "CREATED").
+		Where("(visibility = ? OR (visibility = ? AND id IN (?)) OR organization_id IN (?))",
+			models.PublicTender, models.InviteOnlyTender, db.invitedTenders(ctx, username), db.responsibleOrganizations(ctx, username))
+	if len(params.Statuses) == 0 {
+		query = query.Where("status = ?", "PUBLISHED")
 	}
-	err := query.Order("id ASC").Find(&tenders).Error
+	query = filterTenders(query, params)
+
+	query, total, err := paginate(query, "tender", params, "name")
 	if err != nil {
-		return nil, err
+		return nil, models.Page{}, err
 	}
-	return tenders, nil
+	if err := query.Find(&tenders).Error; err != nil {
+		return nil, models.Page{}, err
+	}
+	tenders, page := pageOf(tenders, params, "name", total, tenderKey)
+	return tenders, page, nil
 }
 
-func (db *DBstorage) GetTendersByUser(username string) ([]models.Tender, error) {
+func (db *DBstorage) GetTendersByUser(username string, params models.ListParams) ([]models.Tender, models.Page, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	if err := db.authorize(ctx, username, authz.ActionListOwnTenders, authz.Resource{}); err != nil {
+		return nil, models.Page{}, err
+	}
+
 	var tenders []models.Tender
 
-	err := db.conn.WithContext(ctx).Table("tender").
-		Where("creator_username = ?", username).
-		Order("id ASC").
-		Find(&tenders).Error
+	query := db.conn.WithContext(ctx).Table("tender").
+		Where("creator_username = ?", username)
+	query = filterTenders(query, params)
+
+	query, total, err := paginate(query, "tender", params, "name")
 	if err != nil {
-		return nil, err
+		return nil, models.Page{}, err
+	}
+	if err := query.Find(&tenders).Error; err != nil {
+		return nil, models.Page{}, err
 	}
+	tenders, page := pageOf(tenders, params, "name", total, tenderKey)
+	return tenders, page, nil
+}
+
+// filterTenders применяет фильтры по видам услуг и статусам
+func filterTenders(query *gorm.DB, params models.ListParams) *gorm.DB {
+	if len(params.ServiceTypes) > 0 {
+		query = query.Where("service_type IN ?", params.ServiceTypes)
+	}
+	if len(params.Statuses) > 0 {
+		query = query.Where("status IN ?", params.Statuses)
+	}
+	return query
+}
 
-	return tenders, nil
+func tenderKey(t models.Tender) sortKey {
+	return sortKey{Name: t.Name, ID: t.ID, Version: t.Version}
 }
 
 func (db *DBstorage) CreateTender(tender models.Tender) (models.Tender, error) {
@@ -49,165 +85,224 @@ func (db *DBstorage) CreateTender(tender models.Tender) (models.Tender, error) {
 
 	// Устанавливаем начальные значения
 	tender.Version = 1
-	tender.Status = "CREATED" // Устанавливаем статус
+	tender.Status = models.CreatedT
+	if tender.Visibility == "" {
+		tender.Visibility = models.PublicTender
+	}
 
 	// Проверка, ответственный ли пользователь за организацию
-	var count int64
-	err := db.conn.WithContext(ctx).
-		Table("organization_responsible").
-		Joins("JOIN employee ON employee.id = organization_responsible.user_id").
-		Where("organization_responsible.organization_id = ? AND employee.username = ?", tender.OrganizationID, tender.CreatorUsername).
-		Count(&count).Error
-
+	err := db.authorize(ctx, tender.CreatorUsername, authz.ActionCreateTender, authz.Organization(tender.OrganizationID))
 	if err != nil {
-		return models.Tender{}, fmt.Errorf("failed to check responsibility: %w", err)
+		return models.Tender{}, fmt.Errorf("user %s is not responsible for organization %d: %w", tender.CreatorUsername, tender.OrganizationID, err)
 	}
 
-	if count == 0 {
-		return models.Tender{}, fmt.Errorf("user %s is not responsible for organization %d", tender.CreatorUsername, tender.OrganizationID)
+	// Без явно заданных правил голосования тендер получает правила организации
+	if tender.DecisionPolicy == nil {
+		policy, err := db.organizationPolicy(ctx, tender.OrganizationID)
+		if err != nil {
+			return models.Tender{}, err
+		}
+		tender.DecisionPolicy = &policy
+	} else if err := tender.DecisionPolicy.Validate(); err != nil {
+		return models.Tender{}, err
+	}
+	if err := tender.ValidateDeadlines(); err != nil {
+		return models.Tender{}, err
+	}
+	if err := tender.ValidatePricing(); err != nil {
+		return models.Tender{}, err
 	}
 
-	// Создание нового тендера
-	tender.Status = "CREATED"
-	if err := db.conn.WithContext(ctx).
-		Table("tender").
-		Create(&tender).Error; err != nil {
-		return models.Tender{}, fmt.Errorf("failed to create tender: %w", err)
+	// Создание нового тендера, его первой версии и первой записи журнала статусов
+	err = db.unitOfWork(ctx, func(tx *DBstorage) error {
+		if err := tx.conn.WithContext(ctx).
+			Table("tender").
+			Create(&tender).Error; err != nil {
+			return fmt.Errorf("failed to create tender: %w", err)
+		}
+		if err := tenderVersions.record(ctx, tx, tender, tender.CreatorUsername); err != nil {
+			return err
+		}
+		if err := tx.logTenderTransition(ctx, tender.ID, nil, tender.Status, tender.CreatorUsername, "created"); err != nil {
+			return err
+		}
+		return tx.emit(ctx, models.TenderEvent(models.TenderCreatedEvent, tender.ID, tender.CreatorUsername, map[string]interface{}{
+			"organizationId": tender.OrganizationID,
+			"serviceType":    tender.ServiceType,
+			"visibility":     tender.Visibility,
+		}))
+	})
+	if err != nil {
+		return models.Tender{}, err
 	}
 
 	return tender, nil
 }
 
-func (db *DBstorage) SetTenderStatus(id int, status string) error {
+func (db *DBstorage) GetTenderStatus(id int, username string) (models.TenderStatus, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	query := db.conn.WithContext(ctx).
+	if err := db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(id)); err != nil {
+		return "", err
+	}
+
+	var status models.TenderStatus
+	err := db.conn.WithContext(ctx).
 		Table("tender").
-		Model(&models.Tender{}).
+		Select("status").
 		Where("id = ?", id).
-		Update("status", status)
-	if query.Error != nil {
-		return query.Error
-	}
-	if query.RowsAffected == 0 {
-		return fmt.Errorf("no tender found with id %d", id)
+		Scan(&status).Error
+	if err != nil {
+		return "", fmt.Errorf("failed to get tender status: %w", err)
 	}
-	return nil
+	return status, nil
 }
 
-func (db *DBstorage) EditTender(id int, name string, description string) (models.Tender, error) {
+// SetTenderStatus меняет статус тендера по правилам жизненного цикла (models.TenderStatus.CanTransitionTo)
+func (db *DBstorage) SetTenderStatus(id int, status string, username string, expectedVersion int) (models.Tender, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	var tender models.Tender
-	var currentVersion int
-
-	// Получаем текущую версию тендера
-	if err := db.conn.WithContext(ctx).
-		Table("tender").
-		Where("id = ?", id).
-		First(&tender).Error; err != nil {
+	if err := db.authorize(ctx, username, authz.ActionSetTenderStatus, authz.Tender(id)); err != nil {
 		return models.Tender{}, err
 	}
 
-	currentVersion = tender.Version
-
-	// Сохраняем старую запись в историю
-	history := models.TenderHistory{
-		TenderID:        tender.ID,
-		Name:            tender.Name,
-		Description:     tender.Description,
-		ServiceType:     tender.ServiceType,
-		Status:          tender.Status,
-		OrganizationID:  tender.OrganizationID,
-		CreatorUsername: tender.CreatorUsername,
-		Version:         currentVersion,
-	}
+	var tender models.Tender
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockTender(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tenderVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		to := models.TenderStatus(status)
+		if to == models.ClosedT {
+			if err := tx.checkBidsResolved(ctx, id); err != nil {
+				return err
+			}
+		}
+		if err := tx.transitionTender(ctx, current, to, username, "status changed by user"); err != nil {
+			return err
+		}
 
-	// Сохраняем запись в истории
-	err := db.conn.WithContext(ctx).
-		Table("tender_history").
-		Create(&history).Error
+		return tx.conn.WithContext(ctx).
+			Table("tender").
+			Where("id = ?", id).
+			First(&tender).Error
+	})
 	if err != nil {
 		return models.Tender{}, err
 	}
+	return tender, nil
+}
 
-	// Обновляем текущую версию
-	rowsAffected := db.conn.WithContext(ctx).
-		Table("tender").
-		Where("id = ? AND version = ?", id, currentVersion).
-		Updates(map[string]interface{}{
-			"name":        name,
-			"description": description,
-			"version":     currentVersion + 1,
-		}).RowsAffected
+func (db *DBstorage) EditTender(id int, update models.TenderUpdate, username string, expectedVersion int) (models.Tender, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	if rowsAffected == 0 {
-		return models.Tender{}, fmt.Errorf("no tender found with id %d or version mismatch", id)
+	if err := db.authorize(ctx, username, authz.ActionEditTender, authz.Tender(id)); err != nil {
+		return models.Tender{}, err
 	}
 
-	// Получаем обновленный тендер
-	if err := db.conn.WithContext(ctx).
-		Table("tender").
-		Where("id = ?", id).
-		First(&tender).Error; err != nil {
+	var tender models.Tender
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockTender(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tenderVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", id, current.Status.API())
+		}
+
+		// Непереданные поля не меняются
+		changes := map[string]interface{}{}
+		if update.Name != nil {
+			changes["name"] = *update.Name
+		}
+		if update.Description != nil {
+			changes["description"] = *update.Description
+		}
+		if update.ServiceType != nil {
+			changes["service_type"] = *update.ServiceType
+		}
+		// Сроки проверяются вместе с теми, что остаются без изменений
+		deadlines := current
+		if update.SubmissionDeadline != nil {
+			changes["submission_deadline"] = *update.SubmissionDeadline
+			deadlines.SubmissionDeadline = update.SubmissionDeadline
+		}
+		if update.DecisionDeadline != nil {
+			changes["decision_deadline"] = *update.DecisionDeadline
+			deadlines.DecisionDeadline = update.DecisionDeadline
+		}
+		if err := deadlines.ValidateDeadlines(); err != nil {
+			return err
+		}
+		tender, err = tenderVersions.commit(ctx, tx, current, changes, username)
+		if err != nil {
+			return err
+		}
+		return checkNotResealed(current, tender, time.Now())
+	})
+	if err != nil {
 		return models.Tender{}, err
 	}
 
 	return tender, nil
 }
 
-func (db *DBstorage) RollbackTender(id int, version int) (models.Tender, error) {
+func (db *DBstorage) RollbackTender(id int, version int, username string, expectedVersion int) (models.Tender, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	var tenderH models.TenderHistory
-	if err := db.conn.WithContext(ctx).
-		Table("tender_history").
-		Where("tender_id = ? AND version = ?", id, version).
-		First(&tenderH).Error; err != nil {
-		return models.Tender{}, fmt.Errorf("version %d for tender %d not found: %v", version, id, err)
-	}
-	// Обновляем текущий тендер с данными из истории
-	err := db.conn.WithContext(ctx).
-		Table("tender").
-		Where("id = ?", id).
-		Updates(map[string]interface{}{
-			"name":             tenderH.Name,
-			"description":      tenderH.Description,
-			"service_type":     tenderH.ServiceType,
-			"status":           tenderH.Status,
-			"organization_id":  tenderH.OrganizationID,
-			"creator_username": tenderH.CreatorUsername,
-			"version":          tenderH.Version,
-		}).Error
-	if err != nil {
-		return models.Tender{}, fmt.Errorf("failed to rollback tender: %v", err)
-	}
-	// Создаем новую запись в истории
-	newVersion := tenderH.Version + 1
-	newTenderH := models.TenderHistory{
-		TenderID:        id,
-		Version:         newVersion,
-		Name:            tenderH.Name,
-		Description:     tenderH.Description,
-		ServiceType:     tenderH.ServiceType,
-		Status:          tenderH.Status,
-		OrganizationID:  tenderH.OrganizationID,
-		CreatorUsername: tenderH.CreatorUsername,
-	}
-	if err := db.conn.WithContext(ctx).
-		Table("tender_history").
-		Create(&newTenderH).Error; err != nil {
-		return models.Tender{}, fmt.Errorf("failed to create new history entry: %w", err)
+
+	if err := db.authorize(ctx, username, authz.ActionRollbackTender, authz.Tender(id)); err != nil {
+		return models.Tender{}, err
 	}
 
 	var updateTender models.Tender
-	if err := db.conn.WithContext(ctx).
-		Table("tender").
-		Where("id = ?", id).
-		First(&updateTender).Error; err != nil {
-		return models.Tender{}, fmt.Errorf("failed to fetch updated tender: %v", err)
+	err := db.unitOfWork(ctx, func(tx *DBstorage) error {
+		current, err := tx.lockTender(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tenderVersions.precondition(current, expectedVersion); err != nil {
+			return err
+		}
+		if !current.Status.Editable() {
+			return apperr.Conflict("Tender %d cannot be edited in status %s", id, current.Status.API())
+		}
+
+		// Откат создает новую версию с содержимым старой. Статус меняется только
+		// через жизненный цикл, поэтому откат его не затрагивает.
+		updateTender, err = tenderVersions.rollback(ctx, tx, current, version, username)
+		if err != nil {
+			return err
+		}
+		if formatPolicy(current.DecisionPolicy) != formatPolicy(updateTender.DecisionPolicy) {
+			if err := tx.checkNoDecisions(ctx, id); err != nil {
+				return err
+			}
+		}
+		return checkNotResealed(current, updateTender, time.Now())
+	})
+	if err != nil {
+		return models.Tender{}, err
 	}
 	return updateTender, nil
 }
+
+// GetTenderVersions возвращает все версии тендера
+func (db *DBstorage) GetTenderVersions(id int, username string) ([]models.Version, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(id)); err != nil {
+		return nil, err
+	}
+	return tenderVersions.list(ctx, db, id)
+}
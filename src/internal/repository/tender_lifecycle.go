@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+)
+
+// openBidStatuses - предложения, по которым еще не принято итоговое решение
+var openBidStatuses = []models.BidStatus{models.CreatedB, models.PublishedB}
+
+// transitionTender переводит заблокированный тендер в статус to. Вызывается внутри
+// unitOfWork: проверяет допустимость перехода, выполняет побочные действия над
+// предложениями, пишет переход в журнал tender_transition и публикует событие.
+func (db *DBstorage) transitionTender(ctx context.Context, tender models.Tender, to models.TenderStatus, username, reason string) error {
+	if err := tender.Status.Transition(to); err != nil {
+		return err
+	}
+
+	if err := db.conn.WithContext(ctx).
+		Table("tender").
+		Where("id = ?", tender.ID).
+		Update("status", to).Error; err != nil {
+		return fmt.Errorf("failed to update tender status: %w", err)
+	}
+
+	// Закрытый или отмененный тендер больше не рассматривает открытые предложения
+	if to == models.ClosedT || to == models.CancelledT {
+		if _, err := db.transitionBids(ctx, tender.ID, openBidStatuses, nil, models.CanceledB, username); err != nil {
+			return err
+		}
+	}
+	// У отмененного тендера нет победителя, одобренные предложения отклоняются
+	if to == models.CancelledT {
+		if err := db.declineApproved(ctx, tender.ID, 0, username); err != nil {
+			return err
+		}
+	}
+
+	from := tender.Status
+	if err := db.logTenderTransition(ctx, tender.ID, &from, to, username, reason); err != nil {
+		return err
+	}
+	return db.emit(ctx, models.TenderStatusEvent(tender, to, username))
+}
+
+// logTenderTransition добавляет запись в журнал смены статусов
+func (db *DBstorage) logTenderTransition(ctx context.Context, tenderID int, from *models.TenderStatus, to models.TenderStatus, username, reason string) error {
+	transition := models.TenderTransition{
+		TenderID:   tenderID,
+		FromStatus: from,
+		ToStatus:   to,
+		Username:   username,
+		Reason:     reason,
+	}
+	// Переходы, выполненные планировщиком, пишутся без пользователя
+	omit := []string{"created_at"}
+	if username == "" {
+		omit = append(omit, "username")
+	}
+	if err := db.conn.WithContext(ctx).
+		Table("tender_transition").
+		Omit(omit...).
+		Create(&transition).Error; err != nil {
+		return fmt.Errorf("failed to log tender transition: %w", err)
+	}
+	return nil
+}
+
+// checkBidsResolved не дает закрыть тендер вручную, пока по опубликованным
+// предложениям идет голосование или среди одобренных не выбран победитель
+func (db *DBstorage) checkBidsResolved(ctx context.Context, tenderID int) error {
+	var pending int64
+	err := db.conn.WithContext(ctx).
+		Table("bid").
+		Where("tender_id = ? AND status = ?", tenderID, models.PublishedB).
+		Where("EXISTS (SELECT 1 FROM bid_decisions WHERE bid_decisions.bid_id = bid.id)").
+		Count(&pending).Error
+	if err != nil {
+		return fmt.Errorf("failed to count bids under review: %w", err)
+	}
+	if pending > 0 {
+		return apperr.Conflict("Tender %d has %d bids under review", tenderID, pending)
+	}
+
+	var approved int64
+	err = db.conn.WithContext(ctx).
+		Table("bid").
+		Where("tender_id = ? AND status = ?", tenderID, models.SubmittedB).
+		Count(&approved).Error
+	if err != nil {
+		return fmt.Errorf("failed to count approved bids: %w", err)
+	}
+	if approved > 0 {
+		return apperr.Conflict("Tender %d has %d approved bids, award one of them", tenderID, approved)
+	}
+	return nil
+}
+
+func (db *DBstorage) GetTenderTransitions(id int, username string) ([]models.TenderTransition, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.authorize(ctx, username, authz.ActionViewTender, authz.Tender(id)); err != nil {
+		return nil, err
+	}
+
+	var transitions []models.TenderTransition
+	if err := db.conn.WithContext(ctx).
+		Table("tender_transition").
+		Where("tender_id = ?", id).
+		Order("id").
+		Find(&transitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch tender transitions: %w", err)
+	}
+	return transitions, nil
+}
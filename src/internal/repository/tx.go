@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// unitOfWork выполняет fn в одной транзакции. Все запросы внутри fn должны идти
+// через переданный tx: при ошибке или панике изменения откатываются целиком.
+func (db *DBstorage) unitOfWork(ctx context.Context, fn func(tx *DBstorage) error) error {
+	return db.conn.WithContext(ctx).Transaction(func(conn *gorm.DB) error {
+		tx := &DBstorage{conn: conn, auctionTypes: db.auctionTypes}
+		tx.authz = authz.New(tx)
+		return fn(tx)
+	})
+}
+
+// lockTender читает тендер с блокировкой строки (SELECT ... FOR UPDATE) до конца транзакции
+func (db *DBstorage) lockTender(ctx context.Context, id int) (models.Tender, error) {
+	var tender models.Tender
+	err := db.conn.WithContext(ctx).
+		Table("tender").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&tender).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Tender{}, apperr.NotFound("Tender %d not found", id)
+	}
+	if err != nil {
+		return models.Tender{}, fmt.Errorf("failed to lock tender: %w", err)
+	}
+	return tender, nil
+}
+
+// lockBid читает предложение с блокировкой строки (SELECT ... FOR UPDATE) до конца транзакции
+func (db *DBstorage) lockBid(ctx context.Context, id int) (models.Bid, error) {
+	var bid models.Bid
+	err := db.conn.WithContext(ctx).
+		Table("bid").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		First(&bid).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Bid{}, apperr.NotFound("Bid %d not found", id)
+	}
+	if err != nil {
+		return models.Bid{}, fmt.Errorf("failed to lock bid: %w", err)
+	}
+	return bid, nil
+}
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"gorm.io/gorm"
+)
+
+// versioning - общий механизм версий тендеров и предложений. История хранит
+// снимок каждой версии, включая текущую, и только дополняется: правка и откат
+// создают версию с номером текущей + 1, старые снимки не удаляются.
+type versioning[T, H any] struct {
+	entity  string // для сообщений об ошибках
+	title   string // то же для сообщений клиенту
+	table   string
+	history string
+	key     string // колонка ссылки на сущность в таблице истории
+
+	id       func(T) int
+	version  func(T) int
+	snapshot func(live T, attachments []models.Attachment, changedBy string) H
+	// restore - значения колонок, которые откат возвращает из снимка
+	restore     func(H) map[string]interface{}
+	attachments func(H) []models.Attachment
+	toVersion   func(H) models.Version
+	// event - доменное событие о новой версии (правке или откате)
+	event func(live T, t models.DomainEventType, changedBy string, data map[string]interface{}) models.DomainEvent
+
+	edited, rolledBack models.DomainEventType
+}
+
+var tenderVersions = versioning[models.Tender, models.TenderHistory]{
+	entity:  "tender",
+	title:   "Tender",
+	table:   "tender",
+	history: "tender_history",
+	key:     "tender_id",
+	id:      func(t models.Tender) int { return t.ID },
+	version: func(t models.Tender) int { return t.Version },
+	snapshot: func(t models.Tender, attachments []models.Attachment, changedBy string) models.TenderHistory {
+		return models.TenderHistory{
+			TenderID:        t.ID,
+			Name:            t.Name,
+			Description:     t.Description,
+			ServiceType:     t.ServiceType,
+			Status:          t.Status,
+			OrganizationID:  t.OrganizationID,
+			CreatorUsername: t.CreatorUsername,
+			Version:         t.Version,
+			ChangedBy:       changedBy,
+
+			SubmissionDeadline: t.SubmissionDeadline,
+			DecisionDeadline:   t.DecisionDeadline,
+			DecisionPolicy:     t.DecisionPolicy,
+			Attachments:        attachments,
+		}
+	},
+	restore: func(h models.TenderHistory) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                h.Name,
+			"description":         h.Description,
+			"service_type":        h.ServiceType,
+			"submission_deadline": h.SubmissionDeadline,
+			"decision_deadline":   h.DecisionDeadline,
+			"decision_policy":     policyColumn(h.DecisionPolicy),
+		}
+	},
+	attachments: func(h models.TenderHistory) []models.Attachment { return h.Attachments },
+	event: func(t models.Tender, e models.DomainEventType, changedBy string, data map[string]interface{}) models.DomainEvent {
+		return models.TenderEvent(e, t.ID, changedBy, data)
+	},
+	edited:     models.TenderEditedEvent,
+	rolledBack: models.TenderRolledBackEvent,
+	toVersion: func(h models.TenderHistory) models.Version {
+		return models.Version{
+			Version: h.Version,
+			Fields: map[string]string{
+				"name":               h.Name,
+				"description":        h.Description,
+				"serviceType":        h.ServiceType,
+				"submissionDeadline": formatDeadline(h.SubmissionDeadline),
+				"decisionDeadline":   formatDeadline(h.DecisionDeadline),
+				"decisionPolicy":     formatPolicy(h.DecisionPolicy),
+				"attachments":        models.FormatAttachments(h.Attachments),
+			},
+			ChangedBy: h.ChangedBy,
+			CreatedAt: h.CreatedAt,
+		}
+	},
+}
+
+var bidVersions = versioning[models.Bid, models.BidHistory]{
+	entity:  "bid",
+	title:   "Bid",
+	table:   "bid",
+	history: "bid_history",
+	key:     "bid_id",
+	id:      func(b models.Bid) int { return b.ID },
+	version: func(b models.Bid) int { return b.Version },
+	snapshot: func(b models.Bid, attachments []models.Attachment, changedBy string) models.BidHistory {
+		return models.BidHistory{
+			BidID:           b.ID,
+			Name:            b.Name,
+			Description:     b.Description,
+			Status:          b.Status,
+			TenderID:        b.TenderID,
+			OrganizationID:  b.OrganizationID,
+			CreatorUsername: b.CreatorUsername,
+			Version:         b.Version,
+			ChangedBy:       changedBy,
+			Round:           b.Round,
+
+			Price:        b.Price,
+			DeliveryDays: b.DeliveryDays,
+			Items:        b.Items,
+			Attachments:  attachments,
+		}
+	},
+	restore: func(h models.BidHistory) map[string]interface{} {
+		restored := pricingColumns(h.Price, h.DeliveryDays, h.Items)
+		restored["name"] = h.Name
+		restored["description"] = h.Description
+		return restored
+	},
+	attachments: func(h models.BidHistory) []models.Attachment { return h.Attachments },
+	event: func(b models.Bid, e models.DomainEventType, changedBy string, data map[string]interface{}) models.DomainEvent {
+		return models.BidEvent(e, b, changedBy, data)
+	},
+	edited:     models.BidEditedEvent,
+	rolledBack: models.BidRolledBackEvent,
+	toVersion: func(h models.BidHistory) models.Version {
+		price := ""
+		if !h.Price.IsZero() {
+			price = h.Price.String()
+		}
+		items := ""
+		if len(h.Items) > 0 {
+			data, _ := json.Marshal(h.Items)
+			items = string(data)
+		}
+		return models.Version{
+			Version: h.Version,
+			Fields: map[string]string{
+				"name":         h.Name,
+				"description":  h.Description,
+				"price":        price,
+				"deliveryDays": strconv.Itoa(h.DeliveryDays),
+				"items":        items,
+				"attachments":  models.FormatAttachments(h.Attachments),
+				"round":        strconv.Itoa(h.Round),
+			},
+			ChangedBy: h.ChangedBy,
+			CreatedAt: h.CreatedAt,
+		}
+	},
+}
+
+// formatDeadline представляет срок в истории версий, пустая строка - срок не задан
+func formatDeadline(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatPolicy представляет правила голосования в истории версий, пустая строка - правила по умолчанию
+func formatPolicy(p *models.DecisionPolicy) string {
+	if p == nil {
+		return ""
+	}
+	data, _ := json.Marshal(p)
+	return string(data)
+}
+
+// precondition сверяет версию из If-Match с текущей версией заблокированной
+// сущности. expected = 0 означает, что клиент версию не передал.
+func (v versioning[T, H]) precondition(current T, expected int) error {
+	if expected != 0 && expected != v.version(current) {
+		return apperr.PreconditionFailed("%s %d has version %d, expected %d", v.title, v.id(current), v.version(current), expected)
+	}
+	return nil
+}
+
+// record сохраняет снимок текущего состояния сущности вместе с ее вложениями
+func (v versioning[T, H]) record(ctx context.Context, db *DBstorage, live T, changedBy string) error {
+	attachments, err := db.attachments(ctx, v.key, v.id(live))
+	if err != nil {
+		return err
+	}
+	snapshot := v.snapshot(live, attachments, changedBy)
+	if err := db.conn.WithContext(ctx).
+		Table(v.history).
+		Omit("id", "created_at").
+		Create(&snapshot).Error; err != nil {
+		return fmt.Errorf("failed to save %s version: %w", v.entity, err)
+	}
+	return nil
+}
+
+// commit применяет changes к заблокированной сущности current как новую версию,
+// дописывает ее снимок в историю и публикует событие правки. Вызывается внутри unitOfWork.
+func (v versioning[T, H]) commit(ctx context.Context, db *DBstorage, current T, changes map[string]interface{}, changedBy string) (T, error) {
+	return v.apply(ctx, db, current, changes, changedBy, v.edited, nil)
+}
+
+func (v versioning[T, H]) apply(ctx context.Context, db *DBstorage, current T, changes map[string]interface{}, changedBy string, event models.DomainEventType, data map[string]interface{}) (T, error) {
+	id, version := v.id(current), v.version(current)
+
+	updates := make(map[string]interface{}, len(changes)+1)
+	for k, val := range changes {
+		updates[k] = val
+	}
+	updates["version"] = version + 1
+
+	var live T
+	query := db.conn.WithContext(ctx).
+		Table(v.table).
+		Where("id = ? AND version = ?", id, version).
+		Updates(updates)
+	if query.Error != nil {
+		return live, fmt.Errorf("failed to update %s: %w", v.entity, query.Error)
+	}
+	if query.RowsAffected == 0 {
+		return live, apperr.Conflict("%s %d was modified concurrently", v.title, id)
+	}
+
+	if err := db.conn.WithContext(ctx).
+		Table(v.table).
+		Where("id = ?", id).
+		First(&live).Error; err != nil {
+		return live, fmt.Errorf("failed to fetch updated %s: %w", v.entity, err)
+	}
+	if err := v.record(ctx, db, live, changedBy); err != nil {
+		return live, err
+	}
+
+	// Событие перечисляет изменившиеся колонки, но не их значения
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	if _, ok := data["fields"]; !ok {
+		fields := make([]string, 0, len(changes))
+		for k := range changes {
+			fields = append(fields, k)
+		}
+		slices.Sort(fields)
+		data["fields"] = fields
+	}
+	data["version"] = v.version(live)
+	return live, db.emit(ctx, v.event(live, event, changedBy, data))
+}
+
+// rollback создает новую версию с содержимым и вложениями версии number
+func (v versioning[T, H]) rollback(ctx context.Context, db *DBstorage, current T, number int, changedBy string) (T, error) {
+	var zero T
+	snapshot, err := v.get(ctx, db, v.id(current), number)
+	if err != nil {
+		return zero, err
+	}
+	if err := db.replaceAttachments(ctx, v.key, v.id(current), v.attachments(snapshot)); err != nil {
+		return zero, err
+	}
+	return v.apply(ctx, db, current, v.restore(snapshot), changedBy, v.rolledBack, map[string]interface{}{"restoredVersion": number})
+}
+
+func (v versioning[T, H]) get(ctx context.Context, db *DBstorage, id, number int) (H, error) {
+	var snapshot H
+	err := db.conn.WithContext(ctx).
+		Table(v.history).
+		Where(v.key+" = ? AND version = ?", id, number).
+		First(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return snapshot, apperr.NotFound("Version %d of %s %d not found", number, v.entity, id)
+	}
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to get %s version: %w", v.entity, err)
+	}
+	return snapshot, nil
+}
+
+// list возвращает все версии сущности по возрастанию номера
+func (v versioning[T, H]) list(ctx context.Context, db *DBstorage, id int) ([]models.Version, error) {
+	var snapshots []H
+	if err := db.conn.WithContext(ctx).
+		Table(v.history).
+		Where(v.key+" = ?", id).
+		Order("version").
+		Find(&snapshots).Error; err != nil {
+		return nil, fmt.Errorf("failed to list %s versions: %w", v.entity, err)
+	}
+	if len(snapshots) == 0 {
+		return nil, apperr.NotFound("%s %d not found", v.title, id)
+	}
+
+	versions := make([]models.Version, 0, len(snapshots))
+	for _, s := range snapshots {
+		versions = append(versions, v.toVersion(s))
+	}
+	return versions, nil
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/rs/zerolog"
+)
+
+// Expirer подводит итоги тендеров с истекшими сроками. Реализация сама
+// обеспечивает, что при нескольких репликах проход выполняет только одна.
+type Expirer interface {
+	ExpireDeadlines(now time.Time) (models.ExpiryReport, error)
+}
+
+// Scheduler периодически закрывает тендеры с истекшими сроками
+type Scheduler struct {
+	repo     Expirer
+	interval time.Duration
+	log      *zerolog.Logger
+	now      func() time.Time
+}
+
+func New(repo Expirer, interval time.Duration, log *zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		repo:     repo,
+		interval: interval,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// Run выполняет проходы с заданным интервалом, пока не отменен ctx
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.tick()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick()
+		}
+	}
+}
+
+func (s *Scheduler) tick() {
+	report, err := s.repo.ExpireDeadlines(s.now())
+	// Ошибка по отдельным тендерам не отменяет итоги остальных, поэтому отчет пишется в лог в любом случае
+	if err != nil {
+		s.log.Error().Err(err).Msg("Deadline expiry failed")
+	}
+	if report.Skipped {
+		s.log.Debug().Msg("Deadline expiry is running on another replica")
+		return
+	}
+	if report.ClosedTenders+report.AwardedTenders+report.ExpiredBids > 0 {
+		s.log.Info().
+			Int("closed_tenders", report.ClosedTenders).
+			Int("awarded_tenders", report.AwardedTenders).
+			Int("expired_bids", report.ExpiredBids).
+			Msg("Deadlines expired")
+	}
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeExpirer struct {
+	mu    sync.Mutex
+	calls []time.Time
+	err   error
+}
+
+func (f *fakeExpirer) ExpireDeadlines(now time.Time) (models.ExpiryReport, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, now)
+	return models.ExpiryReport{ClosedTenders: 1}, f.err
+}
+
+func (f *fakeExpirer) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls)
+}
+
+func TestSchedulerRun(t *testing.T) {
+	zlog := zerolog.New(os.Stdout)
+	now := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "Successful passes"},
+		// Ошибка прохода не останавливает планировщик
+		{name: "Failed passes", err: errors.New("db error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeExpirer{err: tt.err}
+			s := New(repo, 10*time.Millisecond, &zlog)
+			s.now = func() time.Time { return now }
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				s.Run(ctx)
+				close(done)
+			}()
+
+			assert.Eventually(t, func() bool { return repo.count() >= 3 }, time.Second, 5*time.Millisecond)
+			cancel()
+			<-done
+
+			calls := repo.count()
+			time.Sleep(30 * time.Millisecond)
+			assert.Equal(t, calls, repo.count(), "scheduler must stop after cancel")
+			assert.Equal(t, now, repo.calls[0])
+		})
+	}
+}
+
+// tenderExpirer закрывает тендеры по тому же правилу, что и репозиторий
+type tenderExpirer struct {
+	tenders []models.Tender
+}
+
+func (f *tenderExpirer) ExpireDeadlines(now time.Time) (models.ExpiryReport, error) {
+	var report models.ExpiryReport
+	for i, tender := range f.tenders {
+		if tender.DueForClosing(now) {
+			f.tenders[i].Status = models.ClosedT
+			report.ClosedTenders++
+		}
+	}
+	return report, nil
+}
+
+// Закрытый тендер без срока решения не закрывается в момент раскрытия
+// предложений: до него по ним нельзя было голосовать
+func TestSchedulerSealedTender(t *testing.T) {
+	zlog := zerolog.New(os.Stdout)
+	submission := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	decision := submission.Add(24 * time.Hour)
+
+	repo := &tenderExpirer{tenders: []models.Tender{
+		{ID: 1, Status: models.PublishedT, Sealed: true, SubmissionDeadline: &submission},
+		{ID: 2, Status: models.PublishedT, Sealed: true, SubmissionDeadline: &submission, DecisionDeadline: &decision},
+		{ID: 3, Status: models.PublishedT, SubmissionDeadline: &submission},
+	}}
+	s := New(repo, time.Minute, &zlog)
+
+	s.now = func() time.Time { return submission }
+	s.tick()
+	assert.Equal(t, models.PublishedT, repo.tenders[0].Status)
+	assert.Equal(t, models.PublishedT, repo.tenders[1].Status)
+	assert.Equal(t, models.ClosedT, repo.tenders[2].Status)
+
+	s.now = func() time.Time { return decision }
+	s.tick()
+	assert.Equal(t, models.PublishedT, repo.tenders[0].Status)
+	assert.Equal(t, models.ClosedT, repo.tenders[1].Status)
+}
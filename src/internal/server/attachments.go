@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead - запас на заголовки multipart сверх размера файла
+const multipartOverhead = 64 << 10
+
+// attachmentOwner читает тендер или предложение, которому принадлежат вложения, из пути
+func attachmentOwner(ctx *gin.Context, kind models.AttachmentOwnerKind) (models.AttachmentOwner, bool) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		if kind == models.TenderAttachments {
+			fail(ctx, apperr.Invalid("Invalid tender ID"))
+		} else {
+			fail(ctx, apperr.Invalid("Invalid bid ID"))
+		}
+		return models.AttachmentOwner{}, false
+	}
+	return models.AttachmentOwner{Kind: kind, ID: id}, true
+}
+
+// UploadAttachmentHandler принимает файл из поля file формы multipart/form-data
+func (s *Server) UploadAttachmentHandler(kind models.AttachmentOwnerKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		owner, ok := attachmentOwner(ctx, kind)
+		if !ok {
+			return
+		}
+		expectedVersion, err := ifMatch(ctx)
+		if err != nil {
+			fail(ctx, err)
+			return
+		}
+
+		ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, s.Limits.MaxSize+multipartOverhead)
+		header, err := ctx.FormFile("file")
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				fail(ctx, apperr.TooLarge("File exceeds %d bytes", s.Limits.MaxSize))
+				return
+			}
+			fail(ctx, apperr.Invalid("File is required"))
+			return
+		}
+		if header.Size > s.Limits.MaxSize {
+			fail(ctx, apperr.TooLarge("File exceeds %d bytes", s.Limits.MaxSize))
+			return
+		}
+		if header.Filename == "" || len([]rune(header.Filename)) > 255 {
+			fail(ctx, apperr.Invalid("Invalid file name"))
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			fail(ctx, fmt.Errorf("failed to open uploaded file: %w", err))
+			return
+		}
+		defer file.Close()
+
+		// Тип определяется по содержимому: заявленному клиентом Content-Type не доверяем
+		contentType, err := sniffContentType(file)
+		if err != nil {
+			fail(ctx, err)
+			return
+		}
+		if !slices.Contains(s.Limits.Types, contentType) {
+			fail(ctx, apperr.Unsupported("File type %s is not allowed", contentType))
+			return
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			fail(ctx, fmt.Errorf("failed to rewind uploaded file: %w", err))
+			return
+		}
+		checksum, size, err := storage.Checksum(file)
+		if err != nil {
+			fail(ctx, fmt.Errorf("failed to hash uploaded file: %w", err))
+			return
+		}
+
+		attachment := models.Attachment{
+			Name:        header.Filename,
+			ContentType: contentType,
+			Size:        size,
+			Checksum:    checksum,
+		}
+		// Содержимое записывается после проверки прав, до фиксации описания в базе
+		put := func(c context.Context) error {
+			if _, err := file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			return s.Blobs.Put(c, checksum, file)
+		}
+		attachment, err = s.Db.AddAttachment(owner, attachment, put, currentUsername(ctx), expectedVersion)
+		if err != nil {
+			fail(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusCreated, newAttachmentResponse(attachment))
+	}
+}
+
+func sniffContentType(r io.Reader) (string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	// DetectContentType добавляет параметры (charset), ограничения задаются без них
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if err != nil {
+		return "", fmt.Errorf("failed to detect file type: %w", err)
+	}
+	return mediaType, nil
+}
+
+func (s *Server) GetAttachmentsHandler(kind models.AttachmentOwnerKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		owner, ok := attachmentOwner(ctx, kind)
+		if !ok {
+			return
+		}
+		attachments, err := s.Db.GetAttachments(owner, currentUsername(ctx))
+		if err != nil {
+			fail(ctx, err)
+			return
+		}
+		ctx.JSON(http.StatusOK, newAttachmentResponses(attachments))
+	}
+}
+
+// DownloadAttachmentHandler отдает содержимое вложения как файл
+func (s *Server) DownloadAttachmentHandler(kind models.AttachmentOwnerKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		owner, ok := attachmentOwner(ctx, kind)
+		if !ok {
+			return
+		}
+		attachmentID, ok := pathID(ctx, "attachmentId")
+		if !ok {
+			fail(ctx, apperr.Invalid("Invalid attachment ID"))
+			return
+		}
+		attachment, err := s.Db.GetAttachment(owner, attachmentID, currentUsername(ctx))
+		if err != nil {
+			fail(ctx, err)
+			return
+		}
+
+		content, err := s.Blobs.Open(ctx.Request.Context(), attachment.Checksum)
+		if err != nil {
+			// Описание без содержимого - потеря данных хранилища, а не ошибка клиента
+			fail(ctx, fmt.Errorf("failed to open attachment %d: %w", attachment.ID, err))
+			return
+		}
+		defer content.Close()
+
+		ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+			"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}),
+			"X-Content-Type-Options": "nosniff",
+			"X-Checksum-Sha256":      attachment.Checksum,
+		})
+	}
+}
+
+func (s *Server) DeleteAttachmentHandler(kind models.AttachmentOwnerKind) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		owner, ok := attachmentOwner(ctx, kind)
+		if !ok {
+			return
+		}
+		attachmentID, ok := pathID(ctx, "attachmentId")
+		if !ok {
+			fail(ctx, apperr.Invalid("Invalid attachment ID"))
+			return
+		}
+		expectedVersion, err := ifMatch(ctx)
+		if err != nil {
+			fail(ctx, err)
+			return
+		}
+		if err := s.Db.DeleteAttachment(owner, attachmentID, currentUsername(ctx), expectedVersion); err != nil {
+			fail(ctx, err)
+			return
+		}
+		ctx.Status(http.StatusNoContent)
+	}
+}
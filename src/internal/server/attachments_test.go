@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/storage"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	blobs, err := storage.NewLocal(t.TempDir())
+	require.NoError(t, err)
+	srv := &Server{
+		Db:     m,
+		Blobs:  blobs,
+		Limits: AttachmentLimits{MaxSize: 64, Types: []string{"text/plain", "application/pdf"}},
+		log:    zerolog.New(os.Stdout),
+		Valid:  validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.POST("/api/tenders/:id/attachments", asUser("user1"), srv.UploadAttachmentHandler(models.TenderAttachments))
+	r.GET("/api/tenders/:id/attachments/:attachmentId", asUser("user1"), srv.DownloadAttachmentHandler(models.TenderAttachments))
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	content := "technical specification"
+	checksum, size, err := storage.Checksum(strings.NewReader(content))
+	require.NoError(t, err)
+	uploadedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	saved := models.Attachment{
+		ID:          7,
+		Name:        "spec.txt",
+		ContentType: "text/plain",
+		Size:        size,
+		Checksum:    checksum,
+		UploadedBy:  "user1",
+		CreatedAt:   uploadedAt,
+	}
+
+	m.EXPECT().
+		AddAttachment(models.TenderOwner(1), models.Attachment{Name: "spec.txt", ContentType: "text/plain", Size: size, Checksum: checksum}, gomock.Any(), "user1", 3).
+		DoAndReturn(func(_ models.AttachmentOwner, _ models.Attachment, put func(context.Context) error, _ string, _ int) (models.Attachment, error) {
+			return saved, put(context.Background())
+		})
+
+	upload := func(name, body string) *resty.Response {
+		resp, err := resty.New().R().
+			SetHeader("If-Match", `"3"`).
+			SetFileReader("file", name, strings.NewReader(body)).
+			Post(httpSrv.URL + "/api/tenders/1/attachments")
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := upload("spec.txt", content)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode())
+	assert.JSONEq(t, `{"id":"7","name":"spec.txt","contentType":"text/plain","size":23,
+		"checksum":"`+checksum+`","uploadedBy":"user1","createdAt":"2024-09-01T12:00:00Z"}`, string(resp.Body()))
+
+	// Тип определяется по содержимому, а не по имени файла
+	resp = upload("page.pdf", "<html><body>not a pdf</body></html>")
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode())
+
+	resp = upload("big.txt", strings.Repeat("a", 65))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode())
+
+	resp, err = resty.New().R().Post(httpSrv.URL + "/api/tenders/1/attachments")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+	// Скачивание отдает записанное в хранилище содержимое
+	m.EXPECT().GetAttachment(models.TenderOwner(1), 7, "user1").Return(saved, nil)
+	m.EXPECT().GetAttachment(models.TenderOwner(1), 8, "user1").Return(models.Attachment{}, apperr.NotFound("Attachment 8 not found"))
+
+	resp, err = resty.New().R().Get(httpSrv.URL + "/api/tenders/1/attachments/7")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, content, string(resp.Body()))
+	assert.Equal(t, "text/plain", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=spec.txt`, resp.Header().Get("Content-Disposition"))
+
+	resp, err = resty.New().R().Get(httpSrv.URL + "/api/tenders/1/attachments/8")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
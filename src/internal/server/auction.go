@@ -0,0 +1,144 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAuctionExtension - окно и величина продления аукциона по умолчанию, в секундах
+const defaultAuctionExtension = 60
+
+// OpenAuctionHandler назначает аукцион на понижение цены по тендеру
+func (s *Server) OpenAuctionHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var req auctionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	settings := models.AuctionSettings{
+		StartsAt:     time.Now(),
+		EndsAt:       req.EndsAt,
+		MinStep:      req.MinStep,
+		ExtendWithin: defaultAuctionExtension,
+		ExtendBy:     defaultAuctionExtension,
+	}
+	if req.StartsAt != nil {
+		settings.StartsAt = *req.StartsAt
+	}
+	if req.ExtendWithin != nil {
+		settings.ExtendWithin = *req.ExtendWithin
+	}
+	if req.ExtendBy != nil {
+		settings.ExtendBy = *req.ExtendBy
+	}
+	auction, err := s.Db.OpenAuction(id, settings, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newAuctionResponse(auction, time.Now()))
+}
+
+func (s *Server) GetAuctionHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	auction, err := s.Db.GetAuction(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newAuctionResponse(auction, time.Now()))
+}
+
+// SubmitAuctionPriceHandler принимает ставку по предложению ({"price": {"amount": "1400", "currency": "RUB"}})
+func (s *Server) SubmitAuctionPriceHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	var req auctionOfferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	auction, err := s.Db.SubmitAuctionPrice(id, req.Price, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newAuctionResponse(auction, time.Now()))
+}
+
+// AuctionStreamHandler передает состояние аукциона как server-sent events: событие
+// auction отправляется при подключении, после каждой ставки, продления и смены
+// статуса. Поток закрывается после события со статусом Finished. Состояние
+// перечитывается из базы по таймеру и по событиям AuctionUpdated, поэтому ставки,
+// принятые другими репликами, тоже видны.
+func (s *Server) AuctionStreamHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	username := currentUsername(ctx)
+	auction, err := s.Db.GetAuction(id, username)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+
+	ticker := time.NewTicker(s.AuctionPoll)
+	defer ticker.Stop()
+	var updates <-chan models.Event
+	if s.Events != nil {
+		sub := s.Events.Subscribe(func(e models.Event) bool {
+			return e.Type == models.AuctionUpdated && e.TenderID == id
+		})
+		defer sub.Close()
+		updates = sub.Events
+	}
+	var sent *auctionResponse
+	ctx.Stream(func(w io.Writer) bool {
+		resp := newAuctionResponse(auction, time.Now())
+		if sent == nil || resp.Offers != sent.Offers || resp.EndsAt != sent.EndsAt || resp.Status != sent.Status {
+			ctx.SSEvent("auction", resp)
+			sent = &resp
+		}
+		if resp.Status == string(models.AuctionFinished) {
+			return false
+		}
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		case _, ok := <-updates:
+			// Отставшую подписку хаб закрывает, остается опрос по таймеру
+			if !ok {
+				updates = nil
+			}
+		}
+		if auction, err = s.Db.GetAuction(id, username); err != nil {
+			s.log.Error().Err(err).Int("tender", id).Msg("Auction stream stopped")
+			return false
+		}
+		return true
+	})
+}
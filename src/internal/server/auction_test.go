@@ -0,0 +1,181 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuctionHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.POST("/api/tenders/:id/auction", asUser("user1"), srv.OpenAuctionHandler)
+	r.POST("/api/bids/:id/auction_offer", asUser("user2"), srv.SubmitAuctionPriceHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	start := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	step := models.Money{Amount: 1000, Currency: "RUB"}
+	price := models.Money{Amount: 140000, Currency: "RUB"}
+	bidID := 4
+
+	type test struct {
+		name   string
+		method string
+		path   string
+		body   string
+		mock   func()
+		code   int
+		answer string
+	}
+	tests := []test{
+		{
+			name:   "Open auction",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/auction",
+			body:   `{"startsAt":"2024-09-01T12:00:00Z","endsAt":"2024-09-01T13:00:00Z","minStep":{"amount":"10","currency":"RUB"},"extendBy":120}`,
+			mock: func() {
+				m.EXPECT().OpenAuction(1, models.AuctionSettings{StartsAt: start, EndsAt: end, MinStep: step, ExtendWithin: 60, ExtendBy: 120}, "user1").
+					Return(models.Auction{TenderID: 1, StartsAt: start, EndsAt: end, MinStep: step, ExtendWithin: 60, ExtendBy: 120}, nil)
+			},
+			code: http.StatusCreated,
+			answer: `{"tenderId":"1","status":"Finished","startsAt":"2024-09-01T12:00:00Z","endsAt":"2024-09-01T13:00:00Z",
+				"minStep":{"amount":"10.00","currency":"RUB"},"extendWithin":60,"extendBy":120,"bestPrice":null,"leading":false,"offers":0}`,
+		},
+		{
+			name:   "Open auction without end",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/auction",
+			body:   `{"minStep":{"amount":"10","currency":"RUB"}}`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "Leading offer",
+			method: http.MethodPost,
+			path:   "/api/bids/4/auction_offer",
+			body:   `{"price":{"amount":"1400","currency":"RUB"}}`,
+			mock: func() {
+				m.EXPECT().SubmitAuctionPrice(4, price, "user2").
+					Return(models.Auction{TenderID: 1, StartsAt: start, EndsAt: end, MinStep: step, ExtendWithin: 60, ExtendBy: 60,
+						BestPrice: price, BestBidID: &bidID, Leading: true, Offers: 3}, nil)
+			},
+			code: http.StatusOK,
+			answer: `{"tenderId":"1","status":"Finished","startsAt":"2024-09-01T12:00:00Z","endsAt":"2024-09-01T13:00:00Z",
+				"minStep":{"amount":"10.00","currency":"RUB"},"extendWithin":60,"extendBy":60,"bestPrice":{"amount":"1400.00","currency":"RUB"},
+				"bestBidId":"4","leading":true,"offers":3}`,
+		},
+		{
+			name:   "Offer above ceiling",
+			method: http.MethodPost,
+			path:   "/api/bids/4/auction_offer",
+			body:   `{"price":{"amount":"1400","currency":"RUB"}}`,
+			mock: func() {
+				m.EXPECT().SubmitAuctionPrice(4, price, "user2").
+					Return(models.Auction{}, apperr.Conflict("Price must be at most 1390.00 RUB"))
+			},
+			code:   http.StatusConflict,
+			answer: `{"reason":"Price must be at most 1390.00 RUB"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			req := resty.New().R()
+			if tt.body != "" {
+				req.SetHeader("Content-Type", "application/json").SetBody(tt.body)
+			}
+			resp, err := req.Execute(tt.method, httpSrv.URL+tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			if tt.answer != "" {
+				assert.JSONEq(t, tt.answer, string(resp.Body()))
+			}
+		})
+	}
+}
+
+func TestAuctionStreamHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:          m,
+		log:         zerolog.New(os.Stdout),
+		Valid:       validator.New(),
+		AuctionPoll: 10 * time.Millisecond,
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/:id/auction/stream", asUser("user2"), srv.AuctionStreamHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	now := time.Now().UTC()
+	running := models.Auction{TenderID: 1, StartsAt: now.Add(-time.Minute), EndsAt: now.Add(time.Hour), MinStep: models.Money{Amount: 1000, Currency: "RUB"}}
+	offered := running
+	offered.BestPrice, offered.Offers = models.Money{Amount: 140000, Currency: "RUB"}, 1
+	finished := offered
+	finished.EndsAt = now.Add(-time.Second)
+
+	// Повторное чтение без изменений не дает нового события
+	gomock.InOrder(
+		m.EXPECT().GetAuction(1, "user2").Return(running, nil),
+		m.EXPECT().GetAuction(1, "user2").Return(running, nil),
+		m.EXPECT().GetAuction(1, "user2").Return(offered, nil),
+		m.EXPECT().GetAuction(1, "user2").Return(finished, nil),
+	)
+
+	resp, err := resty.New().R().Get(httpSrv.URL + "/api/tenders/1/auction/stream")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, "text/event-stream", resp.Header().Get("Content-Type"))
+	body := string(resp.Body())
+	assert.Equal(t, 3, strings.Count(body, "event:auction"))
+	assert.Contains(t, body, `"status":"Finished"`)
+	assert.Contains(t, body, `"offers":1`)
+}
+
+func TestAuctionStreamNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{Db: m, log: zerolog.New(os.Stdout), AuctionPoll: time.Second}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/:id/auction/stream", asUser("user2"), srv.AuctionStreamHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	m.EXPECT().GetAuction(1, "user2").Return(models.Auction{}, apperr.NotFound("Tender 1 has no auction"))
+	resp, err := resty.New().R().Get(httpSrv.URL + "/api/tenders/1/auction/stream")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+	assert.JSONEq(t, `{"reason":"Tender 1 has no auction"}`, string(resp.Body()))
+}
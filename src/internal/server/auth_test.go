@@ -0,0 +1,150 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/auth"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		Auth:  auth.NewManager("secret", time.Hour),
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/whoami", srv.AuthMiddleware(), func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"username": currentUsername(ctx)})
+	})
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	employee := models.Employee{ID: 1, Username: "user1"}
+	token, _, err := srv.Auth.Issue(employee)
+	assert.NoError(t, err)
+
+	type want struct {
+		code   int
+		answer string
+	}
+	type test struct {
+		name   string
+		header string
+		query  string
+		dbFlag bool
+		err    error
+		want   want
+	}
+	tests := []test{
+		{
+			name:   "Test 'AuthMiddleware' #1; Valid token",
+			header: "Bearer " + token,
+			dbFlag: true,
+			want: want{
+				code:   http.StatusOK,
+				answer: `{"username":"user1"}`,
+			},
+		},
+		{
+			name:   "Test 'AuthMiddleware' #2; Missing token",
+			header: "",
+			want: want{
+				code:   http.StatusUnauthorized,
+				answer: `{"reason":"Missing bearer token"}`,
+			},
+		},
+		{
+			name:   "Test 'AuthMiddleware' #3; Invalid token",
+			header: "Bearer invalid",
+			want: want{
+				code:   http.StatusUnauthorized,
+				answer: `{"reason":"Invalid token"}`,
+			},
+		},
+		{
+			name:   "Test 'AuthMiddleware' #4; Employee was deleted",
+			header: "Bearer " + token,
+			dbFlag: true,
+			err:    errors.New("employee user1 not found"),
+			want: want{
+				code:   http.StatusUnauthorized,
+				answer: `{"reason":"User does not exist"}`,
+			},
+		},
+		{
+			name:   "Test 'AuthMiddleware' #5; Username param differs from token",
+			header: "Bearer " + token,
+			query:  "?username=user2",
+			dbFlag: true,
+			want: want{
+				code:   http.StatusForbidden,
+				answer: `{"reason":"username does not match the token owner"}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.dbFlag {
+				m.EXPECT().GetEmployeeByUsername("user1").Return(employee, tt.err)
+			}
+			req := resty.New().R()
+			if tt.header != "" {
+				req.SetHeader("Authorization", tt.header)
+			}
+			req.Method = http.MethodGet
+			req.URL = httpSrv.URL + "/api/whoami" + tt.query
+			resp, err := req.Send()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want.code, resp.StatusCode())
+			assert.JSONEq(t, tt.want.answer, string(resp.Body()))
+		})
+	}
+}
+
+// Отклоненный запрос пишется в лог вместе с действием эндпоинта
+func TestRequireActionLogsAction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var buf bytes.Buffer
+	srv := &Server{log: zerolog.New(&buf)}
+	r := gin.New()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/:tenderId/edit", func(ctx *gin.Context) {
+		ctx.Set(usernameKey, "user1")
+	}, RequireAction(authz.ActionEditTender), func(ctx *gin.Context) {
+		fail(ctx, apperr.Invalid("Invalid tender"))
+	})
+
+	resp := httptest.NewRecorder()
+	r.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/api/tenders/1/edit", nil))
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, string(authz.ActionEditTender), entry["action"])
+	assert.Equal(t, "/api/tenders/:tenderId/edit", entry["path"])
+}
@@ -1,232 +1,298 @@
 package server
 
 import (
-	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
 func (s *Server) GetBidsByUserHandler(ctx *gin.Context) {
-	username := ctx.Query("username")
-	bids, err := s.Db.GetBidsByUser(username)
+	username := currentUsername(ctx)
+	params, err := parseBidListParams(ctx)
 	if err != nil {
-		s.log.Error().Err(err).Msg("Invalid username")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid params"})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, bids)
+	bids, page, err := s.Db.GetBidsByUser(username, params)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	setPageHeaders(ctx, page)
+	ctx.JSON(http.StatusOK, newBidResponses(bids))
 }
 
 func (s *Server) GetBidsForTenderHandler(ctx *gin.Context) {
-	idStr := ctx.Param("tenderID")
-	s.log.Info().Msgf("Received tenderId: %s", idStr)
-
-	tenderID, err := strconv.Atoi(idStr)
-
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tender ID"})
+	tenderID, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
 		return
 	}
-	bids, err := s.Db.GetBidsForTender(tenderID)
+	params, err := parseBidListParams(ctx)
 	if err != nil {
-		s.log.Error().Err(err).Msg("Failed to get bids for tender")
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to get bids for tender", "error": err})
+		fail(ctx, err)
 		return
 	}
-	// Если предложений нет
-	if len(bids) == 0 {
-		ctx.JSON(http.StatusOK, gin.H{"message": "No bids found for this tender"})
+	bids, page, err := s.Db.GetBidsForTender(tenderID, currentUsername(ctx), params)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, bids)
+	setPageHeaders(ctx, page)
+	ctx.JSON(http.StatusOK, newBidResponses(bids))
 }
+
 func (s *Server) CreateBidHandler(ctx *gin.Context) {
-	var bid models.Bid
-	if err := ctx.ShouldBindJSON(&bid); err != nil {
-		s.log.Error().Err(err).Msg("Invalid JSON payload")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid JSON payload", "error": err.Error()})
+	var req createBidRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid JSON payload"))
+		return
+	}
+	// Автором предложения всегда является владелец токена
+	if req.CreatorUsername != "" && req.CreatorUsername != currentUsername(ctx) {
+		fail(ctx, apperr.Forbidden("creatorUsername does not match the token owner"))
+		return
+	}
+	bid := models.Bid{
+		Name:            req.Name,
+		Description:     req.Description,
+		TenderID:        int(req.TenderID),
+		CreatorUsername: currentUsername(ctx),
+		DeliveryDays:    req.DeliveryDays,
+		Items:           req.Items,
+	}
+	if req.Price != nil {
+		bid.Price = *req.Price
+	}
+	if err := setBidAuthor(&bid, req, currentUsername(ctx)); err != nil {
+		fail(ctx, err)
 		return
 	}
 	if err := s.Valid.Struct(bid); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		fail(ctx, apperr.Invalid("%v", err))
 		return
 	}
 
-	creatorUsername := bid.CreatorUsername
-	id, err := s.Db.CreateBid(bid, creatorUsername)
+	bid, err := s.Db.CreateBid(bid, bid.CreatorUsername)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("user %s does not have permission to create bid", bid.CreatorUsername) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "user does not have permission to create bid"})
-			return
-		}
-		s.log.Error().Err(err).Msg("Failed to add bid")
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "failed to add bid", "error": err})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, id)
+	writeBid(ctx, bid)
 }
 
-func (s *Server) SetBidStatusHandler(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.Atoi(idStr)
+// setBidAuthor определяет автора нового предложения. Пользовательское предложение
+// подается только от своего имени, предложение организации - от организации authorId.
+func setBidAuthor(bid *models.Bid, req createBidRequest, username string) error {
+	if req.AuthorType == "" {
+		if req.OrganizationID == 0 {
+			bid.AuthorType = models.UserAuthor
+			return nil
+		}
+		req.AuthorType = string(models.OrganizationAuthor)
+	}
+	authorType, ok := models.ParseBidAuthorType(req.AuthorType)
+	if !ok {
+		return apperr.Invalid("Invalid authorType %s", req.AuthorType)
+	}
+	bid.AuthorType = authorType
+
+	switch authorType {
+	case models.UserAuthor:
+		if req.AuthorID != "" && req.AuthorID != username {
+			return apperr.Forbidden("authorId does not match the token owner")
+		}
+		if req.OrganizationID != 0 {
+			return apperr.Invalid("organizationId is not allowed for bids of authorType User")
+		}
+	case models.OrganizationAuthor:
+		orgID := int(req.OrganizationID)
+		if req.AuthorID != "" {
+			id, err := strconv.Atoi(req.AuthorID)
+			if err != nil || id <= 0 {
+				return apperr.Invalid("Invalid authorId %s", req.AuthorID)
+			}
+			if orgID != 0 && orgID != id {
+				return apperr.Invalid("authorId does not match organizationId")
+			}
+			orgID = id
+		}
+		if orgID == 0 {
+			return apperr.Invalid("Organization is required for bids of authorType Organization")
+		}
+		bid.OrganizationID = &orgID
+	}
+	return nil
+}
+
+func (s *Server) GetBidStatusHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	status, err := s.Db.GetBidStatus(id, currentUsername(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bid ID"})
+		fail(ctx, err)
+		return
 	}
-	var requestBody struct {
-		Status string `json:"status"`
+	ctx.JSON(http.StatusOK, status.API())
+}
+
+func (s *Server) SetBidStatusHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
 	}
-	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	// Новый статус передается в параметре запроса. Approved и Rejected выставляются
+	// только голосованием, остальные переходы проверяет репозиторий.
+	status, ok := models.ParseBidStatus(ctx.Query("status"))
+	if !ok || (status != models.PublishedB && status != models.CanceledB) {
+		fail(ctx, apperr.Invalid("Invalid status"))
 		return
 	}
-	if requestBody.Status != "PUBLISHED" && requestBody.Status != "CANCELED" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+	expected, err := ifMatch(ctx)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
-	err = s.Db.SetBidStatus(id, requestBody.Status)
+	bid, err := s.Db.SetBidStatus(id, string(status), currentUsername(ctx), expected)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "Bid status updated successfully"})
+	writeBid(ctx, bid)
 }
 
 func (s *Server) EditBidHandler(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bid ID"})
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
 		return
 	}
 
-	var requestBody struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+	var update models.BidUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
 	}
-
-	if err := s.Valid.Struct(requestBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := s.Valid.Struct(update); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
 		return
 	}
 
-	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	expected, err := ifMatch(ctx)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
-
-	query, err := s.Db.EditBid(id, requestBody.Name, requestBody.Description)
+	bid, err := s.Db.EditBid(id, update, currentUsername(ctx), expected)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, query)
+	writeBid(ctx, bid)
 }
 
 func (s *Server) RollbackBidHandler(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bid ID"})
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		fail(ctx, apperr.Invalid("Invalid version"))
 		return
 	}
-	versionStr := ctx.Param("version")
-	log.Println("version:", versionStr)
-	version, err := strconv.Atoi(versionStr)
+	expected, err := ifMatch(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		fail(ctx, err)
 		return
 	}
-	updateBid, err := s.Db.RollbackBid(id, version)
+	updateBid, err := s.Db.RollbackBid(id, version, currentUsername(ctx), expected)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, updateBid)
+	writeBid(ctx, updateBid)
 }
 
+// SubmitDecisionHandler принимает решение Approved или Rejected в параметре decision
 func (s *Server) SubmitDecisionHandler(ctx *gin.Context) {
-	bidIDStr := ctx.Param("id")
-	bidID, err := strconv.Atoi(bidIDStr)
-	if err != nil {
-		s.log.Error().Err(err).Msg("Invalid bid ID")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid bid ID", "error": err.Error()})
+	bidID, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
 		return
 	}
-
-	// Получаем имя пользователя из тела запроса
-	var requestBody struct {
-		Username string `json:"username" validate:"required"`
-	}
-	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		s.log.Error().Err(err).Msg("Invalid JSON payload")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid JSON payload", "error": err.Error()})
+	decision, ok := models.ParseDecision(ctx.Query("decision"))
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid decision"))
 		return
 	}
 
-	// Проверка прав пользователя и согласование предложения
-	err = s.Db.SubmitDecision(bidID, requestBody.Username)
+	// Проверка прав пользователя и согласование или отклонение предложения
+	var bid models.Bid
+	var err error
+	if decision == models.SubmittedD {
+		bid, err = s.Db.SubmitDecision(bidID, currentUsername(ctx))
+	} else {
+		bid, err = s.Db.DeclineDecision(bidID, currentUsername(ctx))
+	}
 	if err != nil {
-		// Обработка ошибок связанных с проверкой статуса
-		if err.Error() == "bid must be in PUBLISHED status to submit decision" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-
-		if err.Error() == "user does not have permission to approve or decline this bid" {
-			s.log.Error().Err(err).Msg("User does not have permission")
-			ctx.JSON(http.StatusForbidden, gin.H{"message": "User does not have permission"})
-			return
-		}
-		s.log.Error().Err(err).Msg("Failed to approve bid")
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to approve bid", "error": err.Error()})
+		fail(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Bid approved"})
+	writeBid(ctx, bid)
 }
 
-func (s *Server) SubmitDeclinedHandler(ctx *gin.Context) {
-	// Получаем идентификатор предложения (bidID) из параметров URL
-	bidIDStr := ctx.Param("id")
-	bidID, err := strconv.Atoi(bidIDStr)
+// RetractDecisionHandler отзывает голос текущего пользователя, пока итог не подведен
+func (s *Server) RetractDecisionHandler(ctx *gin.Context) {
+	bidID, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	bid, err := s.Db.RetractDecision(bidID, currentUsername(ctx))
 	if err != nil {
-		s.log.Error().Err(err).Msg("Invalid bid ID")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid bid ID", "error": err.Error()})
+		fail(ctx, err)
 		return
 	}
+	writeBid(ctx, bid)
+}
 
-	// Получаем имя пользователя из тела запроса (например, переданное в JSON)
-	var requestBody struct {
-		Username string `json:"username" validate:"required"`
+// GetBidDecisionsHandler возвращает голоса ответственных и промежуточный итог
+func (s *Server) GetBidDecisionsHandler(ctx *gin.Context) {
+	bidID, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
 	}
-	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		s.log.Error().Err(err).Msg("Invalid JSON payload")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid JSON payload", "error": err.Error()})
+	ballot, err := s.Db.GetBidDecisions(bidID, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
+	ctx.JSON(http.StatusOK, newBallotResponse(ballot))
+}
 
-	// Проверка прав пользователя и отклонение предложения
-	err = s.Db.DeclineDecision(bidID, requestBody.Username)
+// GetBidVersionsHandler возвращает историю версий предложения с изменениями между ними
+func (s *Server) GetBidVersionsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	versions, err := s.Db.GetBidVersions(id, currentUsername(ctx))
 	if err != nil {
-		// Обработка ошибок связанных с проверкой статуса
-		if err.Error() == "bid must be in PUBLISHED status to submit decision" {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-		if err.Error() == "user does not have permission to approve or decline this bid" {
-			s.log.Error().Err(err).Msg("User does not have permission")
-			ctx.JSON(http.StatusForbidden, gin.H{"message": "User does not have permission"})
-			return
-		}
-		s.log.Error().Err(err).Msg("Failed to decline bid")
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to decline bid", "error": err.Error()})
+		fail(ctx, err)
 		return
 	}
-
-	ctx.JSON(http.StatusOK, gin.H{"message": "Bid declined"})
+	ctx.JSON(http.StatusOK, newVersionResponses(versions))
 }
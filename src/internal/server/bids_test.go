@@ -0,0 +1,261 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBidDecisionsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/bids/:id/decisions", asUser("user1"), srv.GetBidDecisionsHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	votedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	ballot := models.Ballot{
+		Bid:    models.Bid{ID: 1, Status: models.PublishedB},
+		Policy: models.DefaultDecisionPolicy,
+		Voters: []models.Voter{
+			{Username: "user1", Role: models.DefaultRole},
+			{Username: "user2", Role: models.DefaultRole},
+			{Username: "user3", Role: models.DefaultRole},
+		},
+		Votes: []models.BidDecision{
+			{BidID: 1, Username: "user1", DecisionStatus: models.SubmittedD, DecisionTime: votedAt},
+		},
+	}
+	m.EXPECT().GetBidDecisions(1, "user1").Return(ballot, nil)
+	m.EXPECT().GetBidDecisions(2, "user1").Return(models.Ballot{}, apperr.Forbidden("Access denied"))
+
+	resp, err := resty.New().R().Get(httpSrv.URL + "/api/bids/1/decisions")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.JSONEq(t, `{
+		"bidId":"1","status":"Published","decisionPolicy":{"rule":"QUORUM","veto":true},
+		"votes":[
+			{"username":"user1","role":"member","decision":"Approved","decisionTime":"2024-09-01T12:00:00Z"},
+			{"username":"user2","role":"member","decision":null,"decisionTime":null},
+			{"username":"user3","role":"member","decision":null,"decisionTime":null}
+		],
+		"summary":{"approvals":1,"rejections":0,"required":3,"remaining":2,"outcome":"Pending"}
+	}`, string(resp.Body()))
+
+	resp, err = resty.New().R().Get(httpSrv.URL + "/api/bids/2/decisions")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode())
+
+	resp, err = resty.New().R().Get(httpSrv.URL + "/api/bids/abc/decisions")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
+}
+
+func TestScoreBidHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.PUT("/api/bids/:id/scores", asUser("user1"), srv.ScoreBidHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	scoredAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	m.EXPECT().ScoreBid(1, map[string]int{"price": 8, "quality": 6}, "user1").Return([]models.Score{
+		{BidID: 1, CriterionID: 1, Criterion: "price", Username: "user1", Value: 8, UpdatedAt: scoredAt},
+		{BidID: 1, CriterionID: 2, Criterion: "quality", Username: "user1", Value: 6, UpdatedAt: scoredAt},
+	}, nil)
+	m.EXPECT().ScoreBid(1, map[string]int{"speed": 5}, "user1").Return(nil, apperr.Invalid("unknown criterion speed"))
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		want   string
+	}{
+		{
+			name:   "scored",
+			path:   "/api/bids/1/scores",
+			body:   `{"price":8,"quality":6}`,
+			status: http.StatusOK,
+			want: `[
+				{"criterion":"price","score":8,"updatedAt":"2024-09-01T12:00:00Z"},
+				{"criterion":"quality","score":6,"updatedAt":"2024-09-01T12:00:00Z"}
+			]`,
+		},
+		{name: "unknown criterion", path: "/api/bids/1/scores", body: `{"speed":5}`, status: http.StatusBadRequest},
+		{name: "not a number", path: "/api/bids/1/scores", body: `{"price":"high"}`, status: http.StatusBadRequest},
+		{name: "invalid id", path: "/api/bids/abc/scores", body: `{"price":8}`, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := resty.New().R().
+				SetHeader("Content-Type", "application/json").
+				SetBody(tt.body).
+				Put(httpSrv.URL + tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode())
+			if tt.want != "" {
+				assert.JSONEq(t, tt.want, string(resp.Body()))
+			}
+		})
+	}
+}
+
+func TestCreateBidHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.POST("/api/bids/new", asUser("user1"), srv.CreateBidHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	orgID := 2
+	userBid := models.Bid{Name: "bid", Description: "new", TenderID: 1, CreatorUsername: "user1", AuthorType: models.UserAuthor}
+	orgBid := models.Bid{Name: "bid", Description: "new", TenderID: 1, CreatorUsername: "user1", AuthorType: models.OrganizationAuthor, OrganizationID: &orgID}
+
+	tests := []struct {
+		name   string
+		body   string
+		want   *models.Bid
+		code   int
+		answer string
+	}{
+		{
+			name:   "organizationId without authorType",
+			body:   `{"name":"bid","description":"new","tenderId":"1","organizationId":"2","creatorUsername":"user1"}`,
+			want:   &orgBid,
+			code:   http.StatusOK,
+			answer: `{"id":"1","name":"bid","description":"new","status":"Created","tenderId":"1","authorType":"Organization","authorId":"2","version":1,"createdAt":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:   "user bid",
+			body:   `{"name":"bid","description":"new","tenderId":"1","authorType":"User","authorId":"user1"}`,
+			want:   &userBid,
+			code:   http.StatusOK,
+			answer: `{"id":"1","name":"bid","description":"new","status":"Created","tenderId":"1","authorType":"User","authorId":"user1","version":1,"createdAt":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "organization from authorId",
+			body: `{"name":"bid","description":"new","tenderId":"1","authorType":"Organization","authorId":"2"}`,
+			want: &orgBid,
+			code: http.StatusOK,
+		},
+		{
+			name: "user bid on behalf of another user",
+			body: `{"name":"bid","description":"new","tenderId":"1","authorType":"User","authorId":"user2"}`,
+			code: http.StatusForbidden,
+		},
+		{
+			name: "user bid with organization",
+			body: `{"name":"bid","description":"new","tenderId":"1","authorType":"User","organizationId":"2"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "authorId differs from organizationId",
+			body: `{"name":"bid","description":"new","tenderId":"1","authorType":"Organization","authorId":"3","organizationId":"2"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "organization bid without organization",
+			body: `{"name":"bid","description":"new","tenderId":"1","authorType":"Organization"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "unknown authorType",
+			body: `{"name":"bid","description":"new","tenderId":"1","authorType":"Team"}`,
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.want != nil {
+				created := *tt.want
+				created.ID, created.Status, created.Version = 1, models.CreatedB, 1
+				m.EXPECT().CreateBid(*tt.want, "user1").Return(created, nil)
+			}
+			resp, err := resty.New().R().SetBody(tt.body).Post(httpSrv.URL + "/api/bids/new")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			if tt.answer != "" {
+				assert.JSONEq(t, tt.answer, string(resp.Body()))
+			}
+		})
+	}
+}
+
+func TestGetBidsForSealedTender(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/bids/:id/list", asUser("user1"), srv.GetBidsForTenderHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	orgID := 2
+	own := models.Bid{ID: 1, Name: "bid #1", Description: "terms", Status: models.PublishedB, TenderID: 1,
+		AuthorType: models.UserAuthor, CreatorUsername: "user1", Version: 1}
+	foreign := models.Bid{ID: 2, Name: "bid #2", Description: "terms", Status: models.PublishedB, TenderID: 1,
+		AuthorType: models.OrganizationAuthor, OrganizationID: &orgID, Version: 2}
+	m.EXPECT().GetBidsForTender(1, "user1", gomock.Any()).Return([]models.Bid{own, foreign.Seal()}, models.Page{Total: 2}, nil)
+	m.EXPECT().GetBidsForTender(1, "user1", gomock.Any()).
+		Return(nil, models.Page{}, apperr.Invalid("Bids of sealed tender 1 cannot be sorted by price"))
+
+	resp, err := resty.New().R().Get(httpSrv.URL + "/api/bids/1/list")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.JSONEq(t, `[
+		{"id":"1","name":"bid #1","description":"terms","status":"Published","tenderId":"1","authorType":"User","authorId":"user1","version":1,"createdAt":"0001-01-01T00:00:00Z"},
+		{"id":"2","name":"","description":"","status":"Published","tenderId":"1","authorType":"Organization","authorId":"2","version":2,"createdAt":"0001-01-01T00:00:00Z","sealed":true}
+	]`, string(resp.Body()))
+
+	resp, err = resty.New().R().SetQueryParam("sort", "price").Get(httpSrv.URL + "/api/bids/1/list")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode())
+}
@@ -0,0 +1,783 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// Представления ресурсов в формате задание/openapi.yml:
+// идентификаторы передаются строками, статусы - в виде Published, время - в RFC3339
+
+type tenderResponse struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	ServiceType    string `json:"serviceType"`
+	Status         string `json:"status"`
+	OrganizationID string `json:"organizationId"`
+	Version        int    `json:"version"`
+	CreatedAt      string `json:"createdAt"`
+	// Правила голосования по предложениям и сроки, в спецификации не описаны
+	DecisionPolicy     models.DecisionPolicy `json:"decisionPolicy"`
+	SubmissionDeadline *string               `json:"submissionDeadline,omitempty"`
+	DecisionDeadline   *string               `json:"decisionDeadline,omitempty"`
+	Budget             *models.Money         `json:"budget,omitempty"`
+	RequiredItems      []models.RequiredItem `json:"requiredItems,omitempty"`
+	Visibility         string                `json:"visibility,omitempty"`
+	Sealed             bool                  `json:"sealed,omitempty"`
+	// Раунд пересмотра предложений и его окончание, для первого раунда не передаются
+	Round         int     `json:"round,omitempty"`
+	RoundDeadline *string `json:"roundDeadline,omitempty"`
+}
+
+func newTenderResponse(t models.Tender) tenderResponse {
+	resp := tenderResponse{
+		ID:             strconv.Itoa(t.ID),
+		Name:           t.Name,
+		Description:    t.Description,
+		ServiceType:    t.ServiceType,
+		Status:         t.Status.API(),
+		OrganizationID: strconv.Itoa(t.OrganizationID),
+		Version:        t.Version,
+		CreatedAt:      t.CreatedAt.Format(time.RFC3339),
+		DecisionPolicy: t.ActivePolicy(),
+
+		SubmissionDeadline: formatTime(t.SubmissionDeadline),
+		DecisionDeadline:   formatTime(t.DecisionDeadline),
+		Budget:             optionalMoney(t.Budget),
+		RequiredItems:      t.RequiredItems,
+		Visibility:         t.Visibility.API(),
+		Sealed:             t.Sealed,
+	}
+	if t.Round > 1 {
+		resp.Round = t.Round
+		resp.RoundDeadline = formatTime(t.RoundDeadline)
+	}
+	return resp
+}
+
+// optionalMoney возвращает nil для незаданной суммы
+func optionalMoney(m models.Money) *models.Money {
+	if m.IsZero() {
+		return nil
+	}
+	return &m
+}
+
+// formatTime форматирует необязательное время в RFC3339
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	v := t.Format(time.RFC3339)
+	return &v
+}
+
+func newTenderResponses(tenders []models.Tender) []tenderResponse {
+	resp := make([]tenderResponse, 0, len(tenders))
+	for _, t := range tenders {
+		resp = append(resp, newTenderResponse(t))
+	}
+	return resp
+}
+
+type bidResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+	TenderID    string `json:"tenderId"`
+	AuthorType  string `json:"authorType"`
+	AuthorID    string `json:"authorId"`
+	Version     int    `json:"version"`
+	CreatedAt   string `json:"createdAt"`
+	// Цена и позиции, в спецификации не описаны
+	Price        *models.Money     `json:"price,omitempty"`
+	DeliveryDays int               `json:"deliveryDays,omitempty"`
+	Items        []models.LineItem `json:"items,omitempty"`
+	// Содержимое скрыто до окончания подачи предложений к закрытому тендеру
+	Sealed bool `json:"sealed,omitempty"`
+	// Раунд последнего пересмотра, для первого раунда не передается
+	Round int `json:"round,omitempty"`
+}
+
+func newBidResponse(b models.Bid) bidResponse {
+	// Предложение организации принадлежит ей, пользовательское - создателю
+	authorID := b.CreatorUsername
+	if b.AuthorType == models.OrganizationAuthor && b.OrganizationID != nil {
+		authorID = strconv.Itoa(*b.OrganizationID)
+	}
+	resp := bidResponse{
+		ID:          strconv.Itoa(b.ID),
+		Name:        b.Name,
+		Description: b.Description,
+		Status:      b.Status.API(),
+		TenderID:    strconv.Itoa(b.TenderID),
+		AuthorType:  string(b.AuthorType),
+		AuthorID:    authorID,
+		Version:     b.Version,
+		CreatedAt:   b.CreatedAt.Format(time.RFC3339),
+
+		Price:        optionalMoney(b.Price),
+		DeliveryDays: b.DeliveryDays,
+		Items:        b.Items,
+		Sealed:       b.Sealed,
+	}
+	if b.Round > 1 {
+		resp.Round = b.Round
+	}
+	return resp
+}
+
+func newBidResponses(bids []models.Bid) []bidResponse {
+	resp := make([]bidResponse, 0, len(bids))
+	for _, b := range bids {
+		resp = append(resp, newBidResponse(b))
+	}
+	return resp
+}
+
+type invitationResponse struct {
+	ID             string  `json:"id"`
+	TenderID       string  `json:"tenderId"`
+	OrganizationID *string `json:"organizationId"`
+	Username       *string `json:"username"`
+	InvitedBy      string  `json:"invitedBy"`
+	CreatedAt      string  `json:"createdAt"`
+}
+
+func newInvitationResponse(i models.Invitation) invitationResponse {
+	resp := invitationResponse{
+		ID:        strconv.Itoa(i.ID),
+		TenderID:  strconv.Itoa(i.TenderID),
+		Username:  i.Username,
+		InvitedBy: i.InvitedBy,
+		CreatedAt: i.CreatedAt.Format(time.RFC3339),
+	}
+	if i.OrganizationID != nil {
+		id := strconv.Itoa(*i.OrganizationID)
+		resp.OrganizationID = &id
+	}
+	return resp
+}
+
+func newInvitationResponses(invitations []models.Invitation) []invitationResponse {
+	resp := make([]invitationResponse, 0, len(invitations))
+	for _, i := range invitations {
+		resp = append(resp, newInvitationResponse(i))
+	}
+	return resp
+}
+
+type questionResponse struct {
+	ID         string  `json:"id"`
+	TenderID   string  `json:"tenderId"`
+	Question   string  `json:"question"`
+	AskedBy    string  `json:"askedBy,omitempty"`
+	Answer     *string `json:"answer"`
+	AnsweredBy *string `json:"answeredBy,omitempty"`
+	AnsweredAt *string `json:"answeredAt,omitempty"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+func newQuestionResponse(q models.Question) questionResponse {
+	return questionResponse{
+		ID:         strconv.Itoa(q.ID),
+		TenderID:   strconv.Itoa(q.TenderID),
+		Question:   q.Text,
+		AskedBy:    q.AskedBy,
+		Answer:     q.Answer,
+		AnsweredBy: q.AnsweredBy,
+		AnsweredAt: formatTime(q.AnsweredAt),
+		CreatedAt:  q.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func newQuestionResponses(questions []models.Question) []questionResponse {
+	resp := make([]questionResponse, 0, len(questions))
+	for _, q := range questions {
+		resp = append(resp, newQuestionResponse(q))
+	}
+	return resp
+}
+
+type roundResponse struct {
+	Number    int      `json:"number"`
+	Deadline  string   `json:"deadline"`
+	OpenedBy  string   `json:"openedBy"`
+	BidIDs    []string `json:"bidIds"`
+	CreatedAt string   `json:"createdAt"`
+}
+
+func newRoundResponse(r models.Round) roundResponse {
+	resp := roundResponse{
+		Number:    r.Number,
+		Deadline:  r.Deadline.Format(time.RFC3339),
+		OpenedBy:  r.OpenedBy,
+		BidIDs:    make([]string, 0, len(r.BidIDs)),
+		CreatedAt: r.CreatedAt.Format(time.RFC3339),
+	}
+	for _, id := range r.BidIDs {
+		resp.BidIDs = append(resp.BidIDs, strconv.Itoa(id))
+	}
+	return resp
+}
+
+func newRoundResponses(rounds []models.Round) []roundResponse {
+	resp := make([]roundResponse, 0, len(rounds))
+	for _, r := range rounds {
+		resp = append(resp, newRoundResponse(r))
+	}
+	return resp
+}
+
+type auctionResponse struct {
+	TenderID     string        `json:"tenderId"`
+	Status       string        `json:"status"`
+	StartsAt     string        `json:"startsAt"`
+	EndsAt       string        `json:"endsAt"`
+	MinStep      models.Money  `json:"minStep"`
+	ExtendWithin int           `json:"extendWithin"`
+	ExtendBy     int           `json:"extendBy"`
+	BestPrice    *models.Money `json:"bestPrice"`
+	// Предложение с лучшей ценой видно ответственным тендера и его авторам
+	BestBidID *string `json:"bestBidId,omitempty"`
+	Leading   bool    `json:"leading"`
+	Offers    int     `json:"offers"`
+}
+
+// newAuctionResponse показывает аукцион в состоянии на момент now
+func newAuctionResponse(a models.Auction, now time.Time) auctionResponse {
+	resp := auctionResponse{
+		TenderID:     strconv.Itoa(a.TenderID),
+		Status:       string(a.Status(now)),
+		StartsAt:     a.StartsAt.Format(time.RFC3339),
+		EndsAt:       a.EndsAt.Format(time.RFC3339),
+		MinStep:      a.MinStep,
+		ExtendWithin: a.ExtendWithin,
+		ExtendBy:     a.ExtendBy,
+		BestPrice:    optionalMoney(a.BestPrice),
+		Leading:      a.Leading,
+		Offers:       a.Offers,
+	}
+	if a.BestBidID != nil {
+		id := strconv.Itoa(*a.BestBidID)
+		resp.BestBidID = &id
+	}
+	return resp
+}
+
+// eventResponse - событие активности в потоке; за содержимым клиент идет в API
+type eventResponse struct {
+	Type     string  `json:"type"`
+	TenderID string  `json:"tenderId,omitempty"`
+	BidID    *string `json:"bidId,omitempty"`
+	Status   string  `json:"status,omitempty"`
+	Version  int     `json:"version,omitempty"`
+}
+
+func newEventResponse(e models.Event) eventResponse {
+	resp := eventResponse{Type: string(e.Type), Version: e.Version}
+	if e.Type == models.Resync {
+		return resp
+	}
+	resp.TenderID = strconv.Itoa(e.TenderID)
+	if e.BidID != nil {
+		id := strconv.Itoa(*e.BidID)
+		resp.BidID = &id
+	}
+	if e.Status != "" {
+		if e.BidID != nil {
+			resp.Status = models.BidStatus(e.Status).API()
+		} else {
+			resp.Status = models.TenderStatus(e.Status).API()
+		}
+	}
+	return resp
+}
+
+type reviewResponse struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+func newReviewResponses(reviews []models.Review) []reviewResponse {
+	resp := make([]reviewResponse, 0, len(reviews))
+	for _, r := range reviews {
+		resp = append(resp, reviewResponse{
+			ID:          strconv.Itoa(r.ID),
+			Description: r.Comment,
+			CreatedAt:   r.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
+
+type transitionResponse struct {
+	From      *string `json:"from"`
+	To        string  `json:"to"`
+	Username  string  `json:"username"`
+	Reason    string  `json:"reason"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+func newTransitionResponses(transitions []models.TenderTransition) []transitionResponse {
+	resp := make([]transitionResponse, 0, len(transitions))
+	for _, t := range transitions {
+		var from *string
+		if t.FromStatus != nil {
+			v := t.FromStatus.API()
+			from = &v
+		}
+		resp = append(resp, transitionResponse{
+			From:      from,
+			To:        t.ToStatus.API(),
+			Username:  t.Username,
+			Reason:    t.Reason,
+			CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
+
+// versionResponse - версия с изменениями относительно предыдущей
+type versionResponse struct {
+	Version   int                  `json:"version"`
+	Fields    map[string]string    `json:"fields"`
+	Changes   []models.FieldChange `json:"changes"`
+	ChangedBy string               `json:"changedBy"`
+	CreatedAt string               `json:"createdAt"`
+}
+
+func newVersionResponses(versions []models.Version) []versionResponse {
+	resp := make([]versionResponse, 0, len(versions))
+	var prev models.Version
+	for _, v := range versions {
+		resp = append(resp, versionResponse{
+			Version:   v.Version,
+			Fields:    v.Fields,
+			Changes:   models.Diff(prev, v),
+			ChangedBy: v.ChangedBy,
+			CreatedAt: v.CreatedAt.Format(time.RFC3339),
+		})
+		prev = v
+	}
+	return resp
+}
+
+// voteResponse - голос ответственного; decision и decisionTime равны null, если он не голосовал
+type voteResponse struct {
+	Username     string  `json:"username"`
+	Role         string  `json:"role"`
+	Decision     *string `json:"decision"`
+	DecisionTime *string `json:"decisionTime"`
+}
+
+type tallyResponse struct {
+	Approvals  int    `json:"approvals"`
+	Rejections int    `json:"rejections"`
+	Required   int    `json:"required"`
+	Remaining  int    `json:"remaining"`
+	Outcome    string `json:"outcome"`
+}
+
+func newTallyResponse(t models.Tally) tallyResponse {
+	return tallyResponse{
+		Approvals:  t.Approvals,
+		Rejections: t.Rejections,
+		Required:   t.Required,
+		Remaining:  t.Remaining(),
+		Outcome:    t.Outcome.String(),
+	}
+}
+
+type ballotResponse struct {
+	BidID   string                `json:"bidId"`
+	Status  string                `json:"status"`
+	Policy  models.DecisionPolicy `json:"decisionPolicy"`
+	Votes   []voteResponse        `json:"votes"`
+	Summary tallyResponse         `json:"summary"`
+}
+
+func newBallotResponse(b models.Ballot) ballotResponse {
+	votes := make(map[string]models.BidDecision, len(b.Votes))
+	for _, v := range b.Votes {
+		votes[v.Username] = v
+	}
+	resp := ballotResponse{
+		BidID:  strconv.Itoa(b.Bid.ID),
+		Status: b.Bid.Status.API(),
+		Policy: b.Policy,
+		Votes:  make([]voteResponse, 0, len(b.Voters)),
+	}
+	for _, voter := range b.Voters {
+		vote := voteResponse{Username: voter.Username, Role: voter.Role}
+		if v, ok := votes[voter.Username]; ok {
+			// Значения решений совпадают со статусами предложения, в которые они переводят
+			decision := models.BidStatus(v.DecisionStatus).API()
+			decisionTime := v.DecisionTime.Format(time.RFC3339)
+			vote.Decision, vote.DecisionTime = &decision, &decisionTime
+		}
+		resp.Votes = append(resp.Votes, vote)
+	}
+	resp.Summary = newTallyResponse(b.Tally())
+	return resp
+}
+
+type awardInfoResponse struct {
+	BidID     string `json:"bidId"`
+	Reason    string `json:"reason"`
+	AwardedBy string `json:"awardedBy"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type candidateResponse struct {
+	Bid     bidResponse   `json:"bid"`
+	Summary tallyResponse `json:"summary"`
+}
+
+// awardResponse - победитель тендера (null, пока не выбран) и сравнение предложений
+type awardResponse struct {
+	TenderID   string              `json:"tenderId"`
+	Status     string              `json:"status"`
+	Award      *awardInfoResponse  `json:"award"`
+	Candidates []candidateResponse `json:"candidates"`
+}
+
+func newAwardResponse(s models.AwardSummary) awardResponse {
+	resp := awardResponse{
+		TenderID:   strconv.Itoa(s.Tender.ID),
+		Status:     s.Tender.Status.API(),
+		Candidates: make([]candidateResponse, 0, len(s.Candidates)),
+	}
+	if s.Award != nil {
+		resp.Award = &awardInfoResponse{
+			BidID:     strconv.Itoa(s.Award.BidID),
+			Reason:    s.Award.Reason,
+			AwardedBy: s.Award.AwardedBy,
+			CreatedAt: s.Award.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	for _, c := range s.Candidates {
+		resp.Candidates = append(resp.Candidates, candidateResponse{
+			Bid:     newBidResponse(c.Bid),
+			Summary: newTallyResponse(c.Tally),
+		})
+	}
+	return resp
+}
+
+type scoreResponse struct {
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+func newScoreResponses(scores []models.Score) []scoreResponse {
+	resp := make([]scoreResponse, 0, len(scores))
+	for _, s := range scores {
+		resp = append(resp, scoreResponse{
+			Criterion: s.Criterion,
+			Score:     s.Value,
+			UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return resp
+}
+
+type standingResponse struct {
+	Rank      int                `json:"rank"`
+	Bid       bidResponse        `json:"bid"`
+	Scores    map[string]float64 `json:"scores"`
+	Total     float64            `json:"total"`
+	Reviewers int                `json:"reviewers"`
+	Complete  bool               `json:"complete"`
+}
+
+// leaderboardResponse - критерии тендера и рейтинг предложений; оценки округлены до сотых
+type leaderboardResponse struct {
+	TenderID  string             `json:"tenderId"`
+	Criteria  []models.Criterion `json:"criteria"`
+	Standings []standingResponse `json:"standings"`
+}
+
+func newLeaderboardResponse(tenderID int, l models.Leaderboard) leaderboardResponse {
+	resp := leaderboardResponse{
+		TenderID:  strconv.Itoa(tenderID),
+		Criteria:  l.Criteria,
+		Standings: make([]standingResponse, 0, len(l.Standings)),
+	}
+	if resp.Criteria == nil {
+		resp.Criteria = []models.Criterion{}
+	}
+	for i, st := range l.Standings {
+		scores := make(map[string]float64, len(st.Averages))
+		for code, avg := range st.Averages {
+			scores[code] = roundScore(avg)
+		}
+		resp.Standings = append(resp.Standings, standingResponse{
+			Rank:      i + 1,
+			Bid:       newBidResponse(st.Bid),
+			Scores:    scores,
+			Total:     roundScore(st.Total),
+			Reviewers: st.Reviewers,
+			Complete:  st.Complete,
+		})
+	}
+	return resp
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+type attachmentResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	UploadedBy  string `json:"uploadedBy"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+func newAttachmentResponse(a models.Attachment) attachmentResponse {
+	return attachmentResponse{
+		ID:          strconv.Itoa(a.ID),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   a.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func newAttachmentResponses(attachments []models.Attachment) []attachmentResponse {
+	resp := make([]attachmentResponse, 0, len(attachments))
+	for _, a := range attachments {
+		resp = append(resp, newAttachmentResponse(a))
+	}
+	return resp
+}
+
+type organizationResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`
+	CreatedAt   string `json:"createdAt"`
+	// Правила голосования для новых тендеров, если организация их задала
+	DecisionPolicy *models.DecisionPolicy `json:"decisionPolicy,omitempty"`
+}
+
+func newOrganizationResponse(o models.Organization) organizationResponse {
+	return organizationResponse{
+		ID:             strconv.Itoa(o.ID),
+		Name:           o.Name,
+		Description:    o.Description,
+		Type:           string(o.Type),
+		CreatedAt:      o.CreatedAt.Format(time.RFC3339),
+		DecisionPolicy: o.DecisionPolicy,
+	}
+}
+
+func newOrganizationResponses(organizations []models.Organization) []organizationResponse {
+	resp := make([]organizationResponse, 0, len(organizations))
+	for _, o := range organizations {
+		resp = append(resp, newOrganizationResponse(o))
+	}
+	return resp
+}
+
+type employeeResponse struct {
+	ID        string `json:"id"`
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	IsAdmin   bool   `json:"isAdmin"`
+	CreatedAt string `json:"createdAt"`
+}
+
+func newEmployeeResponse(e models.Employee) employeeResponse {
+	return employeeResponse{
+		ID:        strconv.Itoa(e.ID),
+		Username:  e.Username,
+		FirstName: e.FirstName,
+		LastName:  e.LastName,
+		IsAdmin:   e.IsAdmin,
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func newEmployeeResponses(employees []models.Employee) []employeeResponse {
+	resp := make([]employeeResponse, 0, len(employees))
+	for _, e := range employees {
+		resp = append(resp, newEmployeeResponse(e))
+	}
+	return resp
+}
+
+type responsibleResponse struct {
+	Username  string `json:"username"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Role      string `json:"role"`
+}
+
+func newResponsibleResponses(responsibles []models.Responsible) []responsibleResponse {
+	resp := make([]responsibleResponse, 0, len(responsibles))
+	for _, r := range responsibles {
+		resp = append(resp, responsibleResponse{
+			Username:  r.Employee.Username,
+			FirstName: r.Employee.FirstName,
+			LastName:  r.Employee.LastName,
+			Role:      r.Role,
+		})
+	}
+	return resp
+}
+
+// jsonID - идентификатор в теле запроса. По спецификации это строка,
+// но для совместимости со старыми клиентами принимается и число.
+type jsonID int
+
+func (id *jsonID) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		*id = 0
+		return nil
+	}
+	v, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid id %s", data)
+	}
+	*id = jsonID(v)
+	return nil
+}
+
+type createTenderRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	ServiceType     string `json:"serviceType"`
+	Status          string `json:"status"`
+	OrganizationID  jsonID `json:"organizationId"`
+	CreatorUsername string `json:"creatorUsername"`
+	// Необязательно, по умолчанию - правила организации
+	DecisionPolicy *models.DecisionPolicy `json:"decisionPolicy"`
+	// Необязательно, в RFC3339
+	SubmissionDeadline *time.Time `json:"submissionDeadline"`
+	DecisionDeadline   *time.Time `json:"decisionDeadline"`
+	// Необязательно: бюджет (его валюта обязательна для цен предложений) и обязательные позиции
+	Budget        *models.Money         `json:"budget"`
+	RequiredItems []models.RequiredItem `json:"requiredItems"`
+	// Необязательно, по умолчанию Public
+	Visibility string `json:"visibility"`
+	// Необязательно: закрытый прием предложений, требует submissionDeadline
+	Sealed bool `json:"sealed"`
+}
+
+type visibilityRequest struct {
+	Visibility string `json:"visibility"`
+}
+
+// inviteRequest приглашает либо организацию, либо пользователя
+type inviteRequest struct {
+	OrganizationID jsonID `json:"organizationId"`
+	Username       string `json:"username"`
+}
+
+type questionRequest struct {
+	Question string `json:"question" validate:"required,max=1000"`
+}
+
+type answerRequest struct {
+	Answer string `json:"answer" validate:"required,max=2000"`
+}
+
+// roundRequest открывает раунд пересмотра для предложений из шорт-листа, deadline в RFC3339
+type roundRequest struct {
+	BidIDs   []jsonID  `json:"bidIds" validate:"required,min=1"`
+	Deadline time.Time `json:"deadline" validate:"required"`
+}
+
+// auctionRequest назначает аукцион; время в RFC3339, продление в секундах
+type auctionRequest struct {
+	// Необязательно, по умолчанию аукцион начинается сразу
+	StartsAt *time.Time   `json:"startsAt"`
+	EndsAt   time.Time    `json:"endsAt" validate:"required"`
+	MinStep  models.Money `json:"minStep"`
+	// Необязательно, по умолчанию 60 секунд
+	ExtendWithin *int `json:"extendWithin"`
+	ExtendBy     *int `json:"extendBy"`
+}
+
+type auctionOfferRequest struct {
+	Price models.Money `json:"price"`
+}
+
+type awardRequest struct {
+	BidID  jsonID `json:"bidId"`
+	Reason string `json:"reason"`
+}
+
+type createBidRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	Status          string `json:"status"`
+	TenderID        jsonID `json:"tenderId"`
+	OrganizationID  jsonID `json:"organizationId"`
+	CreatorUsername string `json:"creatorUsername"`
+	// Необязательно: без authorType предложение подается от имени организации,
+	// если задан organizationId, иначе от имени пользователя
+	AuthorType string `json:"authorType"`
+	AuthorID   string `json:"authorId"`
+	// Необязательно: цена равна сумме позиций, если они заданы
+	Price        *models.Money     `json:"price"`
+	DeliveryDays int               `json:"deliveryDays"`
+	Items        []models.LineItem `json:"items"`
+}
+
+// pathID читает числовой идентификатор из пути запроса
+func pathID(ctx *gin.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param(name))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
+
+type createOrganizationRequest struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Type        models.OrganizationType `json:"type"`
+}
+
+type setResponsibleRequest struct {
+	// Необязательно, по умолчанию models.DefaultRole
+	Role string `json:"role" validate:"max=50"`
+}
+
+type createEmployeeRequest struct {
+	Username  string `json:"username" validate:"required,max=50"`
+	FirstName string `json:"firstName" validate:"required,max=50"`
+	LastName  string `json:"lastName" validate:"required,max=50"`
+	// bcrypt учитывает только первые 72 байта пароля
+	Password string `json:"password" validate:"required,min=8,max=72"`
+	IsAdmin  bool   `json:"isAdmin"`
+}
+
+// editEmployeeRequest - непереданные поля не меняются
+type editEmployeeRequest struct {
+	FirstName *string `json:"firstName" validate:"omitempty,min=1,max=50"`
+	LastName  *string `json:"lastName" validate:"omitempty,min=1,max=50"`
+	Password  *string `json:"password" validate:"omitempty,min=8,max=72"`
+	IsAdmin   *bool   `json:"isAdmin"`
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (s *Server) GetEmployeesHandler(ctx *gin.Context) {
+	employees, err := s.Db.GetEmployees(currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newEmployeeResponses(employees))
+}
+
+// GetEmployeeHandler доступен любому аутентифицированному пользователю
+func (s *Server) GetEmployeeHandler(ctx *gin.Context) {
+	employee, err := s.Db.GetEmployeeByUsername(ctx.Param("username"))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newEmployeeResponse(employee))
+}
+
+func (s *Server) CreateEmployeeHandler(ctx *gin.Context) {
+	var req createEmployeeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		fail(ctx, fmt.Errorf("failed to hash password: %w", err))
+		return
+	}
+	employee := models.Employee{
+		Username:     req.Username,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		PasswordHash: string(hash),
+		IsAdmin:      req.IsAdmin,
+	}
+	employee, err = s.Db.CreateEmployee(employee, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newEmployeeResponse(employee))
+}
+
+func (s *Server) EditEmployeeHandler(ctx *gin.Context) {
+	var req editEmployeeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	update := models.EmployeeUpdate{
+		FirstName: req.FirstName,
+		LastName:  req.LastName,
+		IsAdmin:   req.IsAdmin,
+	}
+	if req.Password != nil {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			fail(ctx, fmt.Errorf("failed to hash password: %w", err))
+			return
+		}
+		passwordHash := string(hash)
+		update.PasswordHash = &passwordHash
+	}
+	employee, err := s.Db.EditEmployee(ctx.Param("username"), update, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newEmployeeResponse(employee))
+}
+
+func (s *Server) DeleteEmployeeHandler(ctx *gin.Context) {
+	if err := s.Db.DeleteEmployee(ctx.Param("username"), currentUsername(ctx)); err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestCreateEmployeeHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.POST("/api/employees/new", asUser("admin"), srv.CreateEmployeeHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	createdAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	// В репозиторий попадает только хеш пароля
+	m.EXPECT().CreateEmployee(gomock.Any(), "admin").
+		DoAndReturn(func(e models.Employee, _ string) (models.Employee, error) {
+			assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(e.PasswordHash), []byte("secret-password")))
+			e.ID = 9
+			e.CreatedAt = createdAt
+			return e, nil
+		})
+
+	tests := []struct {
+		name   string
+		body   string
+		code   int
+		answer string
+	}{
+		{
+			name:   "Valid request",
+			body:   `{"username":"user9","firstName":"Ann","lastName":"Lee","password":"secret-password"}`,
+			code:   http.StatusOK,
+			answer: `{"id":"9","username":"user9","firstName":"Ann","lastName":"Lee","isAdmin":false,"createdAt":"2024-09-01T12:00:00Z"}`,
+		},
+		{
+			name: "Short password",
+			body: `{"username":"user9","firstName":"Ann","lastName":"Lee","password":"short"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "Missing username",
+			body: `{"firstName":"Ann","lastName":"Lee","password":"secret-password"}`,
+			code: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := resty.New().R().SetHeader("Content-Type", "application/json").SetBody(tt.body).Post(httpSrv.URL + "/api/employees/new")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			if tt.answer != "" {
+				assert.JSONEq(t, tt.answer, string(resp.Body()))
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"github.com/gin-gonic/gin"
+)
+
+type errorResponse struct {
+	Reason string `json:"reason"`
+}
+
+// fail прерывает обработку запроса. Ответ формирует ErrorMiddleware.
+func fail(ctx *gin.Context, err error) {
+	_ = ctx.Error(err)
+	ctx.Abort()
+}
+
+// ErrorMiddleware отображает ошибку обработчика в errorResponse с кодом по категории apperr.
+// Текст непредвиденных ошибок пишется только в лог.
+func (s *Server) ErrorMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		err := ctx.Errors.Last().Err
+		status := apperr.HTTPStatus(err)
+		if status == http.StatusInternalServerError {
+			s.log.Error().Err(err).Str("path", ctx.FullPath()).Str("action", ctx.GetString(actionKey)).Msg("Request failed")
+		} else {
+			s.log.Debug().Err(err).Str("path", ctx.FullPath()).Str("action", ctx.GetString(actionKey)).Msg("Request rejected")
+		}
+		ctx.JSON(status, errorResponse{Reason: apperr.Reason(err)})
+	}
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// setETag отдает версию тендера или предложения в заголовке ETag
+func setETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// ifMatch возвращает версию из заголовка If-Match. Без заголовка и для "*"
+// возвращается 0, и репозиторий версию не проверяет.
+func ifMatch(ctx *gin.Context) (int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	// Версия - сильный валидатор, слабые ETag для If-Match не подходят
+	value, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		value, ok = strings.CutSuffix(value, `"`)
+	}
+	version, err := strconv.Atoi(value)
+	if !ok || err != nil || version < 1 {
+		return 0, apperr.Invalid("Invalid If-Match header")
+	}
+	return version, nil
+}
+
+// writeTender отвечает тендером вместе с его ETag
+func writeTender(ctx *gin.Context, tender models.Tender) {
+	setETag(ctx, tender.Version)
+	ctx.JSON(http.StatusOK, newTenderResponse(tender))
+}
+
+// writeBid отвечает предложением вместе с его ETag
+func writeBid(ctx *gin.Context, bid models.Bid) {
+	setETag(ctx, bid.Version)
+	ctx.JSON(http.StatusOK, newBidResponse(bid))
+}
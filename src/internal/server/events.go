@@ -0,0 +1,79 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// TenderEventsHandler передает как server-sent events изменения тендера и его
+// предложений, голоса и отзывы. Каждое событие проверяется на доступность
+// подписчику в момент отправки.
+func (s *Server) TenderEventsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	username := currentUsername(ctx)
+	if _, err := s.Db.GetTenderStatus(id, username); err != nil {
+		fail(ctx, err)
+		return
+	}
+	s.streamEvents(ctx, username, models.TenderEvents, func(e models.Event) bool {
+		return e.Type == models.Resync || e.TenderID == id
+	})
+}
+
+// OwnBidEventsHandler передает как server-sent events изменения предложений,
+// автором которых является пользователь, и отзывы на них
+func (s *Server) OwnBidEventsHandler(ctx *gin.Context) {
+	s.streamEvents(ctx, currentUsername(ctx), models.OwnBidEvents, func(e models.Event) bool {
+		return e.Type == models.Resync || e.BidID != nil
+	})
+}
+
+// streamEvents держит поток, пока клиент не отключится. Если клиент не успевает
+// читать, поток закрывается: после переподключения состояние нужно перечитать.
+// То же означает событие Resync.
+func (s *Server) streamEvents(ctx *gin.Context, username string, scope models.EventScope, filter func(models.Event) bool) {
+	sub := s.Events.Subscribe(filter)
+	defer sub.Close()
+	heartbeat := time.NewTicker(s.Heartbeat)
+	defer heartbeat.Stop()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Status(http.StatusOK)
+	// Комментарий сразу отправляет заголовки: клиент видит, что подписка принята
+	if _, err := io.WriteString(ctx.Writer, ": subscribed\n\n"); err != nil {
+		return
+	}
+	ctx.Writer.Flush()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case event, ok := <-sub.Events:
+			if !ok {
+				return false
+			}
+			visible, err := s.Db.EventVisible(event, username, scope)
+			if err != nil {
+				s.log.Error().Err(err).Str("event", string(event.Type)).Msg("Event stream stopped")
+				return false
+			}
+			if visible {
+				ctx.SSEvent(string(event.Type), newEventResponse(event))
+			}
+			return true
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/events"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readStream подключается к потоку, после комментария о подписке вызывает
+// publish и читает поток до события last включительно
+func readStream(t *testing.T, url string, publish func(), last string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	var body strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == ": subscribed" {
+			publish()
+			scanner.Scan()
+			continue
+		}
+		body.WriteString(line + "\n")
+		if line == "event:"+last {
+			scanner.Scan()
+			body.WriteString(scanner.Text() + "\n")
+			break
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return body.String()
+}
+
+func TestEventStreams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	// Отдельный хаб на подтест: поток прошлого подтеста может еще не заметить отключения
+	serve := func(t *testing.T) (*events.Hub, string) {
+		hub := events.NewHub()
+		srv := &Server{Db: m, log: zerolog.New(os.Stdout), Events: hub, Heartbeat: time.Hour}
+		r := gin.Default()
+		r.Use(srv.ErrorMiddleware())
+		r.GET("/api/tenders/:id/events", asUser("user2"), srv.TenderEventsHandler)
+		r.GET("/api/bids/events", asUser("user2"), srv.OwnBidEventsHandler)
+		httpSrv := httptest.NewServer(r)
+		t.Cleanup(httpSrv.Close)
+		return hub, httpSrv.URL
+	}
+
+	hidden, published := 5, 6
+	edited := models.Event{Type: models.TenderEdited, TenderID: 1, Version: 3}
+	created := models.Event{Type: models.BidCreated, TenderID: 1, BidID: &hidden, Status: "CREATED", Version: 1}
+	changed := models.Event{Type: models.BidStatusChanged, TenderID: 1, BidID: &published, Status: "PUBLISHED", Version: 2}
+	resync := models.Event{Type: models.Resync}
+
+	t.Run("Tender events", func(t *testing.T) {
+		hub, url := serve(t)
+		m.EXPECT().GetTenderStatus(1, "user2").Return(models.PublishedT, nil)
+		m.EXPECT().EventVisible(edited, "user2", models.TenderEvents).Return(true, nil)
+		m.EXPECT().EventVisible(created, "user2", models.TenderEvents).Return(false, nil)
+		m.EXPECT().EventVisible(changed, "user2", models.TenderEvents).Return(true, nil)
+		m.EXPECT().EventVisible(resync, "user2", models.TenderEvents).Return(true, nil)
+
+		body := readStream(t, url+"/api/tenders/1/events", func() {
+			// Событие другого тендера до проверки видимости не доходит
+			hub.Publish(models.Event{Type: models.TenderEdited, TenderID: 2, Version: 7})
+			hub.Publish(edited)
+			hub.Publish(created)
+			hub.Publish(changed)
+			hub.Publish(resync)
+		}, "Resync")
+		assert.Equal(t, "event:TenderEdited\n"+`data:{"type":"TenderEdited","tenderId":"1","version":3}`+"\n\n"+
+			"event:BidStatusChanged\n"+`data:{"type":"BidStatusChanged","tenderId":"1","bidId":"6","status":"Published","version":2}`+"\n\n"+
+			"event:Resync\n"+`data:{"type":"Resync"}`+"\n", body)
+	})
+
+	t.Run("Own bid events", func(t *testing.T) {
+		hub, url := serve(t)
+		m.EXPECT().EventVisible(changed, "user2", models.OwnBidEvents).Return(true, nil)
+		m.EXPECT().EventVisible(resync, "user2", models.OwnBidEvents).Return(true, nil)
+
+		body := readStream(t, url+"/api/bids/events", func() {
+			hub.Publish(edited)
+			hub.Publish(changed)
+			hub.Publish(resync)
+		}, "Resync")
+		assert.NotContains(t, body, "TenderEdited")
+		assert.Contains(t, body, `"bidId":"6"`)
+	})
+
+	t.Run("Tender not found", func(t *testing.T) {
+		_, url := serve(t)
+		m.EXPECT().GetTenderStatus(9, "user2").Return(models.TenderStatus(""), apperr.NotFound("Tender 9 not found"))
+		resp, err := resty.New().R().Get(url + "/api/tenders/9/events")
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+		assert.JSONEq(t, `{"reason":"Tender 9 not found"}`, string(resp.Body()))
+	})
+}
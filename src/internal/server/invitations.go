@@ -0,0 +1,103 @@
+package server
+
+import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// SetTenderVisibilityHandler меняет видимость тендера: Public, InviteOnly или Internal
+func (s *Server) SetTenderVisibilityHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var req visibilityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	visibility, ok := models.ParseTenderVisibility(req.Visibility)
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid visibility %s", req.Visibility))
+		return
+	}
+	expected, err := ifMatch(ctx)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	tender, err := s.Db.SetTenderVisibility(id, visibility, currentUsername(ctx), expected)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	writeTender(ctx, tender)
+}
+
+func (s *Server) GetInvitationsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	invitations, err := s.Db.GetInvitations(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newInvitationResponses(invitations))
+}
+
+// InviteHandler приглашает к тендеру организацию ({"organizationId": "2"}) или пользователя ({"username": "user3"})
+func (s *Server) InviteHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var req inviteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	var invitation models.Invitation
+	if req.OrganizationID != 0 {
+		orgID := int(req.OrganizationID)
+		invitation.OrganizationID = &orgID
+	}
+	if req.Username != "" {
+		invitation.Username = &req.Username
+	}
+	if err := invitation.Validate(); err != nil {
+		fail(ctx, err)
+		return
+	}
+	invitation, err := s.Db.Invite(id, invitation, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newInvitationResponse(invitation))
+}
+
+func (s *Server) RevokeInvitationHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	invitationID, ok := pathID(ctx, "invitationId")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid invitation ID"))
+		return
+	}
+	if err := s.Db.RevokeInvitation(id, invitationID, currentUsername(ctx)); err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvitationHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.PUT("/api/tenders/:id/visibility", asUser("user1"), srv.SetTenderVisibilityHandler)
+	r.POST("/api/tenders/:id/invitations", asUser("user1"), srv.InviteHandler)
+	r.DELETE("/api/tenders/:id/invitations/:invitationId", asUser("user1"), srv.RevokeInvitationHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	orgID, username := 2, "user3"
+	invitedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	type test struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		ifMatch string
+		mock    func()
+		code    int
+		answer  string
+	}
+	tests := []test{
+		{
+			name:    "Invite only",
+			method:  http.MethodPut,
+			path:    "/api/tenders/1/visibility",
+			body:    `{"visibility":"InviteOnly"}`,
+			ifMatch: `"2"`,
+			mock: func() {
+				m.EXPECT().SetTenderVisibility(1, models.InviteOnlyTender, "user1", 2).
+					Return(models.Tender{ID: 1, Name: "tender", Status: models.PublishedT, OrganizationID: 1, Version: 2, Visibility: models.InviteOnlyTender}, nil)
+			},
+			code: http.StatusOK,
+			answer: `{"id":"1","name":"tender","description":"","serviceType":"","status":"Published","organizationId":"1","version":2,
+				"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true},"visibility":"InviteOnly"}`,
+		},
+		{
+			name:   "Unknown visibility",
+			method: http.MethodPut,
+			path:   "/api/tenders/1/visibility",
+			body:   `{"visibility":"Secret"}`,
+			code:   http.StatusBadRequest,
+			answer: `{"reason":"Invalid visibility Secret"}`,
+		},
+		{
+			name:   "Invite organization",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/invitations",
+			body:   `{"organizationId":"2"}`,
+			mock: func() {
+				m.EXPECT().Invite(1, models.Invitation{OrganizationID: &orgID}, "user1").
+					Return(models.Invitation{ID: 5, TenderID: 1, OrganizationID: &orgID, InvitedBy: "user1", CreatedAt: invitedAt}, nil)
+			},
+			code:   http.StatusCreated,
+			answer: `{"id":"5","tenderId":"1","organizationId":"2","username":null,"invitedBy":"user1","createdAt":"2024-09-01T12:00:00Z"}`,
+		},
+		{
+			name:   "Invite user twice",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/invitations",
+			body:   `{"username":"user3"}`,
+			mock: func() {
+				m.EXPECT().Invite(1, models.Invitation{Username: &username}, "user1").
+					Return(models.Invitation{}, apperr.Conflict("Already invited to tender 1"))
+			},
+			code: http.StatusConflict,
+		},
+		{
+			name:   "Invite both organization and user",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/invitations",
+			body:   `{"organizationId":"2","username":"user3"}`,
+			code:   http.StatusBadRequest,
+			answer: `{"reason":"Invitation must name either an organization or a user"}`,
+		},
+		{
+			name:   "Revoke",
+			method: http.MethodDelete,
+			path:   "/api/tenders/1/invitations/5",
+			mock: func() {
+				m.EXPECT().RevokeInvitation(1, 5, "user1").Return(nil)
+			},
+			code: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			req := resty.New().R()
+			if tt.body != "" {
+				req.SetHeader("Content-Type", "application/json").SetBody(tt.body)
+			}
+			if tt.ifMatch != "" {
+				req.SetHeader("If-Match", tt.ifMatch)
+			}
+			resp, err := req.Execute(tt.method, httpSrv.URL+tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			if tt.answer != "" {
+				assert.JSONEq(t, tt.answer, string(resp.Body()))
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// SetOrganizationDecisionPolicyHandler задает правила голосования для новых тендеров организации
+func (s *Server) SetOrganizationDecisionPolicyHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	var policy models.DecisionPolicy
+	if err := ctx.ShouldBindJSON(&policy); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	policy, err := s.Db.SetOrganizationDecisionPolicy(id, policy, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, policy)
+}
+
+func (s *Server) GetOrganizationsHandler(ctx *gin.Context) {
+	organizations, err := s.Db.GetOrganizations(currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newOrganizationResponses(organizations))
+}
+
+func (s *Server) GetOrganizationHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	organization, err := s.Db.GetOrganization(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newOrganizationResponse(organization))
+}
+
+func (s *Server) CreateOrganizationHandler(ctx *gin.Context) {
+	var req createOrganizationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	organization := models.Organization{
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+	}
+	if err := s.Valid.Struct(organization); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	organization, err := s.Db.CreateOrganization(organization, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newOrganizationResponse(organization))
+}
+
+func (s *Server) EditOrganizationHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	var update models.OrganizationUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(update); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	organization, err := s.Db.EditOrganization(id, update, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newOrganizationResponse(organization))
+}
+
+func (s *Server) DeleteOrganizationHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	if err := s.Db.DeleteOrganization(id, currentUsername(ctx)); err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+func (s *Server) GetResponsiblesHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	responsibles, err := s.Db.GetResponsibles(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newResponsibleResponses(responsibles))
+}
+
+// SetResponsibleHandler назначает сотрудника ответственным; тело {"role": "..."} необязательно
+func (s *Server) SetResponsibleHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	var req setResponsibleRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			fail(ctx, apperr.Invalid("Invalid request body"))
+			return
+		}
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	responsibles, err := s.Db.SetResponsible(id, ctx.Param("username"), req.Role, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newResponsibleResponses(responsibles))
+}
+
+func (s *Server) RemoveResponsibleHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid organization ID"))
+		return
+	}
+	responsibles, err := s.Db.RemoveResponsible(id, ctx.Param("username"), currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newResponsibleResponses(responsibles))
+}
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponsibleHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.PUT("/api/organizations/:id/responsibles/:username", asUser("user1"), srv.SetResponsibleHandler)
+	r.DELETE("/api/organizations/:id/responsibles/:username", asUser("user1"), srv.RemoveResponsibleHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	responsibles := []models.Responsible{
+		{Employee: models.Employee{Username: "user1", FirstName: "John", LastName: "Doe"}, Role: "member"},
+		{Employee: models.Employee{Username: "user7", FirstName: "Ann", LastName: "Lee"}, Role: "director"},
+	}
+
+	type test struct {
+		name   string
+		method string
+		path   string
+		body   string
+		mock   func()
+		code   int
+		answer string
+	}
+	tests := []test{
+		{
+			name:   "Assign with role",
+			method: http.MethodPut,
+			path:   "/api/organizations/1/responsibles/user7",
+			body:   `{"role":"director"}`,
+			mock: func() {
+				m.EXPECT().SetResponsible(1, "user7", "director", "user1").Return(responsibles, nil)
+			},
+			code: http.StatusOK,
+			answer: `[{"username":"user1","firstName":"John","lastName":"Doe","role":"member"},
+				{"username":"user7","firstName":"Ann","lastName":"Lee","role":"director"}]`,
+		},
+		{
+			name:   "Assign without body",
+			method: http.MethodPut,
+			path:   "/api/organizations/1/responsibles/user7",
+			mock: func() {
+				m.EXPECT().SetResponsible(1, "user7", "", "user1").Return(responsibles, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			name:   "Invalid organization",
+			method: http.MethodPut,
+			path:   "/api/organizations/abc/responsibles/user7",
+			code:   http.StatusBadRequest,
+			answer: `{"reason":"Invalid organization ID"}`,
+		},
+		{
+			name:   "Last responsible",
+			method: http.MethodDelete,
+			path:   "/api/organizations/1/responsibles/user1",
+			mock: func() {
+				m.EXPECT().RemoveResponsible(1, "user1", "user1").
+					Return(nil, apperr.Conflict("Organization 1 has 2 open tenders and no other responsibles"))
+			},
+			code:   http.StatusConflict,
+			answer: `{"reason":"Organization 1 has 2 open tenders and no other responsibles"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			req := resty.New().R()
+			if tt.body != "" {
+				req.SetHeader("Content-Type", "application/json").SetBody(tt.body)
+			}
+			resp, err := req.Execute(tt.method, httpSrv.URL+tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			if tt.answer != "" {
+				assert.JSONEq(t, tt.answer, string(resp.Body()))
+			}
+		})
+	}
+}
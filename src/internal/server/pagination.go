@@ -0,0 +1,105 @@
+package server
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// parseListParams читает из строки запроса limit, offset, cursor, sort, order,
+// а также фильтры service_type и status. Допускаются только поля сортировки из sortFields.
+func parseListParams(ctx *gin.Context, sortFields ...string) (models.ListParams, error) {
+	params := models.ListParams{Limit: models.DefaultPageLimit}
+
+	if v := ctx.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 || limit > models.MaxPageLimit {
+			return models.ListParams{}, apperr.Invalid("limit must be between 0 and %d", models.MaxPageLimit)
+		}
+		params.Limit = limit
+	}
+	if v := ctx.Query("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return models.ListParams{}, apperr.Invalid("offset must be a non-negative integer")
+		}
+		params.Offset = offset
+	}
+	params.Cursor = ctx.Query("cursor")
+
+	if sortBy := ctx.Query("sort"); sortBy != "" {
+		if !slices.Contains(sortFields, sortBy) {
+			return models.ListParams{}, apperr.Invalid("sort must be one of: %s", strings.Join(sortFields, ", "))
+		}
+		params.SortBy = sortBy
+	}
+	switch ctx.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		params.Desc = true
+	default:
+		return models.ListParams{}, apperr.Invalid("order must be asc or desc")
+	}
+
+	params.ServiceTypes = queryList(ctx, "service_type")
+	params.Statuses = queryList(ctx, "status")
+	return params, nil
+}
+
+// parseTenderListParams дополнительно переводит статусы из формата API в формат базы
+func parseTenderListParams(ctx *gin.Context) (models.ListParams, error) {
+	params, err := parseListParams(ctx, "name", "id", "version")
+	if err != nil {
+		return models.ListParams{}, err
+	}
+	for i, v := range params.Statuses {
+		status, ok := models.ParseTenderStatus(v)
+		if !ok {
+			return models.ListParams{}, apperr.Invalid("unknown tender status %s", v)
+		}
+		params.Statuses[i] = string(status)
+	}
+	return params, nil
+}
+
+// parseBidListParams дополнительно переводит статусы из формата API в формат базы.
+// Предложения можно ранжировать по цене.
+func parseBidListParams(ctx *gin.Context) (models.ListParams, error) {
+	params, err := parseListParams(ctx, "name", "id", "version", "price")
+	if err != nil {
+		return models.ListParams{}, err
+	}
+	for i, v := range params.Statuses {
+		status, ok := models.ParseBidStatus(v)
+		if !ok {
+			return models.ListParams{}, apperr.Invalid("unknown bid status %s", v)
+		}
+		params.Statuses[i] = string(status)
+	}
+	return params, nil
+}
+
+// queryList поддерживает как повторяющийся параметр (?status=A&status=B), так и список через запятую
+func queryList(ctx *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range ctx.QueryArray(key) {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
+
+// setPageHeaders передает общее число записей и курсор следующей страницы в заголовках
+func setPageHeaders(ctx *gin.Context, page models.Page) {
+	ctx.Header("X-Total-Count", strconv.FormatInt(page.Total, 10))
+	if page.NextCursor != "" {
+		ctx.Header("X-Next-Cursor", page.NextCursor)
+	}
+}
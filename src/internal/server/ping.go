@@ -7,5 +7,5 @@ import (
 )
 
 func (s *Server) PingHandler(ctx *gin.Context) {
-	ctx.JSON(http.StatusOK, gin.H{"message": "ok"})
+	ctx.String(http.StatusOK, "ok")
 }
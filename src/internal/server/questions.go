@@ -0,0 +1,75 @@
+package server
+
+import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"github.com/gin-gonic/gin"
+)
+
+// GetQuestionsHandler возвращает вопросы к тендеру: участникам - вопросы с ответами и свои
+func (s *Server) GetQuestionsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	questions, err := s.Db.GetQuestions(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newQuestionResponses(questions))
+}
+
+func (s *Server) AskQuestionHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var req questionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	question, err := s.Db.AskQuestion(id, req.Question, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newQuestionResponse(question))
+}
+
+// AnswerQuestionHandler публикует ответ для всех, кому виден тендер
+func (s *Server) AnswerQuestionHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	questionID, ok := pathID(ctx, "questionId")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid question ID"))
+		return
+	}
+	var req answerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	question, err := s.Db.AnswerQuestion(id, questionID, req.Answer, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newQuestionResponse(question))
+}
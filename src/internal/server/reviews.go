@@ -2,72 +2,66 @@ package server
 
 import (
 	"net/http"
-	"strconv"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
 func (s *Server) GetReviewsHandler(ctx *gin.Context) {
-	tenderIDStr := ctx.Param("tenderID")
-	username := ctx.Query("username")
-	organizationIDStr := ctx.Query("organizationId")
-
-	tenderID, err := strconv.Atoi(tenderIDStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tender ID"})
+	tenderID, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	authorUsername := ctx.Query("authorUsername")
+	if authorUsername == "" {
+		fail(ctx, apperr.Invalid("Invalid author username"))
 		return
 	}
 
-	organizationID, err := strconv.Atoi(organizationIDStr)
+	params, err := parseListParams(ctx, "id")
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid organization ID"})
+		fail(ctx, err)
 		return
 	}
 
-	reviews, err := s.Db.GetReviewsByAuthorAndTender(tenderID, username, organizationID)
+	reviews, page, err := s.Db.GetReviewsByAuthorAndTender(tenderID, authorUsername, currentUsername(ctx), params)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, reviews)
+	setPageHeaders(ctx, page)
+	ctx.JSON(http.StatusOK, newReviewResponses(reviews))
 }
 
+// AddFeedbackHandler сохраняет отзыв из параметра bidFeedback и возвращает предложение
 func (s *Server) AddFeedbackHandler(ctx *gin.Context) {
-	var review models.Review
-	if err := ctx.ShouldBindJSON(&review); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request payload"})
+	bidID, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
 		return
 	}
-
-	// Извлекаем данные из тела запроса
-	bidID := review.BidID
-	username := review.Username // Предполагается, что `username` также передается в теле запроса
-
-	// Получаем tenderID по bidID
-	tenderID, err := s.Db.GetTenderIDByBidID(bidID)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get tender ID"})
+	feedback := ctx.Query("bidFeedback")
+	if feedback == "" || len([]rune(feedback)) > 1000 {
+		fail(ctx, apperr.Invalid("Invalid bid feedback"))
 		return
 	}
 
-	// Проверяем права доступа пользователя
-	hasPermission, err := s.Db.CheckUserPermissionForTender(tenderID, username)
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check user permission"})
-		return
-	}
-	if !hasPermission {
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to leave feedback"})
-		return
+	// Автором отзыва всегда является владелец токена
+	review := models.Review{
+		BidID:    bidID,
+		Username: currentUsername(ctx),
+		Comment:  feedback,
 	}
 
-	// Добавляем отзыв в базу данных
-	if err := s.Db.AddFeedback(review, username); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add feedback"})
+	// Добавляем отзыв в базу данных, права проверяются по политике authz
+	bid, err := s.Db.AddFeedback(review, review.Username)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, review)
+	writeBid(ctx, bid)
 }
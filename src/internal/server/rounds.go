@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+func (s *Server) GetRoundsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	rounds, err := s.Db.GetRounds(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newRoundResponses(rounds))
+}
+
+// OpenRoundHandler открывает раунд пересмотра ({"bidIds": ["1", "2"], "deadline": "2024-09-10T12:00:00Z"})
+func (s *Server) OpenRoundHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var req roundRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(req); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
+	}
+	request := models.RoundRequest{Deadline: req.Deadline}
+	for _, bidID := range req.BidIDs {
+		request.BidIDs = append(request.BidIDs, int(bidID))
+	}
+	round, err := s.Db.OpenRound(id, request, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, newRoundResponse(round))
+}
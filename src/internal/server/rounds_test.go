@@ -0,0 +1,148 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuestionAndRoundHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/:id/questions", asUser("user2"), srv.GetQuestionsHandler)
+	r.POST("/api/tenders/:id/questions", asUser("user2"), srv.AskQuestionHandler)
+	r.PUT("/api/tenders/:id/questions/:questionId/answer", asUser("user1"), srv.AnswerQuestionHandler)
+	r.POST("/api/tenders/:id/rounds", asUser("user1"), srv.OpenRoundHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	askedAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	answeredAt := askedAt.Add(time.Hour)
+	deadline := time.Date(2024, 9, 10, 12, 0, 0, 0, time.UTC)
+	answer, answeredBy := "Delivery to Moscow only", "user1"
+
+	type test struct {
+		name   string
+		method string
+		path   string
+		body   string
+		mock   func()
+		code   int
+		answer string
+	}
+	tests := []test{
+		{
+			name:   "Ask question",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/questions",
+			body:   `{"question":"Where to deliver?"}`,
+			mock: func() {
+				m.EXPECT().AskQuestion(1, "Where to deliver?", "user2").
+					Return(models.Question{ID: 3, TenderID: 1, Text: "Where to deliver?", AskedBy: "user2", CreatedAt: askedAt}, nil)
+			},
+			code:   http.StatusCreated,
+			answer: `{"id":"3","tenderId":"1","question":"Where to deliver?","askedBy":"user2","answer":null,"createdAt":"2024-09-01T12:00:00Z"}`,
+		},
+		{
+			name:   "Empty question",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/questions",
+			body:   `{"question":""}`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "Answered questions of other bidders are anonymous",
+			method: http.MethodGet,
+			path:   "/api/tenders/1/questions",
+			mock: func() {
+				m.EXPECT().GetQuestions(1, "user2").
+					Return([]models.Question{{ID: 3, TenderID: 1, Text: "Where to deliver?", Answer: &answer, AnsweredBy: &answeredBy, AnsweredAt: &answeredAt, CreatedAt: askedAt}}, nil)
+			},
+			code: http.StatusOK,
+			answer: `[{"id":"3","tenderId":"1","question":"Where to deliver?","answer":"Delivery to Moscow only","answeredBy":"user1",
+				"answeredAt":"2024-09-01T13:00:00Z","createdAt":"2024-09-01T12:00:00Z"}]`,
+		},
+		{
+			name:   "Answer question",
+			method: http.MethodPut,
+			path:   "/api/tenders/1/questions/3/answer",
+			body:   `{"answer":"Delivery to Moscow only"}`,
+			mock: func() {
+				m.EXPECT().AnswerQuestion(1, 3, answer, "user1").
+					Return(models.Question{ID: 3, TenderID: 1, Text: "Where to deliver?", AskedBy: "user2", Answer: &answer, AnsweredBy: &answeredBy, AnsweredAt: &answeredAt, CreatedAt: askedAt}, nil)
+			},
+			code: http.StatusOK,
+			answer: `{"id":"3","tenderId":"1","question":"Where to deliver?","askedBy":"user2","answer":"Delivery to Moscow only","answeredBy":"user1",
+				"answeredAt":"2024-09-01T13:00:00Z","createdAt":"2024-09-01T12:00:00Z"}`,
+		},
+		{
+			name:   "Open round",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/rounds",
+			body:   `{"bidIds":["4","7"],"deadline":"2024-09-10T12:00:00Z"}`,
+			mock: func() {
+				m.EXPECT().OpenRound(1, models.RoundRequest{BidIDs: []int{4, 7}, Deadline: deadline}, "user1").
+					Return(models.Round{ID: 1, TenderID: 1, Number: 2, Deadline: deadline, OpenedBy: "user1", CreatedAt: askedAt, BidIDs: []int{4, 7}}, nil)
+			},
+			code:   http.StatusCreated,
+			answer: `{"number":2,"deadline":"2024-09-10T12:00:00Z","openedBy":"user1","bidIds":["4","7"],"createdAt":"2024-09-01T12:00:00Z"}`,
+		},
+		{
+			name:   "Open round with empty shortlist",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/rounds",
+			body:   `{"bidIds":[],"deadline":"2024-09-10T12:00:00Z"}`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "Open round while submission is open",
+			method: http.MethodPost,
+			path:   "/api/tenders/1/rounds",
+			body:   `{"bidIds":["4"],"deadline":"2024-09-10T12:00:00Z"}`,
+			mock: func() {
+				m.EXPECT().OpenRound(1, models.RoundRequest{BidIDs: []int{4}, Deadline: deadline}, "user1").
+					Return(models.Round{}, apperr.Conflict("Submission to tender 1 is open until 2024-09-05T12:00:00Z"))
+			},
+			code:   http.StatusConflict,
+			answer: `{"reason":"Submission to tender 1 is open until 2024-09-05T12:00:00Z"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			req := resty.New().R()
+			if tt.body != "" {
+				req.SetHeader("Content-Type", "application/json").SetBody(tt.body)
+			}
+			resp, err := req.Execute(tt.method, httpSrv.URL+tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			if tt.answer != "" {
+				assert.JSONEq(t, tt.answer, string(resp.Body()))
+			}
+		})
+	}
+}
@@ -0,0 +1,332 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/auth"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/openapi"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/server"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
+	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specPath = "../../../../задание/openapi.yml"
+
+var pathParam = regexp.MustCompile(`\{[^}]+\}|:[^/]+`)
+
+// Каждая операция из спецификации должна быть зарегистрирована в SetupRoutes
+func TestSpecRoutesRegistered(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc, err := openapi.Load(specPath)
+	require.NoError(t, err)
+
+	zlog := zerolog.New(os.Stdout)
+	r := SetupRoutes(server.New(context.Background(), nil, nil, nil, server.AttachmentLimits{}, nil, &zlog))
+	registered := map[string]bool{}
+	for _, route := range r.Routes() {
+		registered[route.Method+" "+pathParam.ReplaceAllString(route.Path, "{}")] = true
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			key := method + " " + pathParam.ReplaceAllString(openapi.BasePath+path, "{}")
+			assert.True(t, registered[key], "operation %s (%s) is not registered", op.OperationID, key)
+		}
+	}
+}
+
+// Ответы всех операций проверяются по спецификации: при нарушении контракта
+// middleware заменяет ответ ошибкой 500
+func TestConformance(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	doc, err := openapi.Load(specPath)
+	require.NoError(t, err)
+	zlog := zerolog.New(os.Stdout)
+	validator, err := openapi.New(doc, zlog)
+	require.NoError(t, err)
+
+	m := mocks.NewMockRepository(ctrl)
+	authManager := auth.NewManager("secret", time.Hour)
+	s := server.New(context.Background(), m, authManager, nil, server.AttachmentLimits{}, nil, &zlog)
+	httpSrv := httptest.NewServer(SetupRoutes(s, validator.Middleware()))
+	defer httpSrv.Close()
+
+	employee := models.Employee{ID: 1, Username: "user1"}
+	token, _, err := authManager.Issue(employee)
+	require.NoError(t, err)
+	m.EXPECT().GetEmployeeByUsername("user1").Return(employee, nil).AnyTimes()
+
+	createdAt := time.Date(2024, 9, 1, 12, 0, 0, 0, time.UTC)
+	orgID := 1
+	tender := models.Tender{ID: 1, Name: "tender #1", Description: "new", ServiceType: "Delivery", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1, CreatedAt: createdAt}
+	bid := models.Bid{ID: 1, Name: "bid #1", Description: "new", Status: models.PublishedB, TenderID: 1, AuthorType: models.OrganizationAuthor, OrganizationID: &orgID, CreatorUsername: "user1", Version: 1, CreatedAt: createdAt}
+	review := models.Review{ID: 1, BidID: 1, Username: "user1", OrganizationID: 1, Comment: "good job", CreatedAt: createdAt}
+
+	type test struct {
+		operation string
+		method    string
+		path      string
+		query     map[string]string
+		body      string
+		mock      func()
+		code      int
+	}
+	tests := []test{
+		{
+			operation: "checkServer",
+			method:    http.MethodGet,
+			path:      "/api/ping",
+			code:      http.StatusOK,
+		},
+		{
+			operation: "getTenders",
+			method:    http.MethodGet,
+			path:      "/api/tenders",
+			query:     map[string]string{"service_type": "Delivery", "limit": "5"},
+			mock: func() {
+				m.EXPECT().GetAllTenders("user1", gomock.Any()).Return([]models.Tender{tender}, models.Page{Total: 1}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "createTender",
+			method:    http.MethodPost,
+			path:      "/api/tenders/new",
+			body:      `{"name":"tender #1","description":"new","serviceType":"Delivery","status":"Created","organizationId":"1","creatorUsername":"user1"}`,
+			mock: func() {
+				m.EXPECT().CreateTender(gomock.Any()).Return(tender, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "getUserTenders",
+			method:    http.MethodGet,
+			path:      "/api/tenders/my",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().GetTendersByUser("user1", gomock.Any()).Return([]models.Tender{tender}, models.Page{Total: 1}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "getTenderStatus",
+			method:    http.MethodGet,
+			path:      "/api/tenders/1/status",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().GetTenderStatus(1, "user1").Return(models.PublishedT, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "updateTenderStatus",
+			method:    http.MethodPut,
+			path:      "/api/tenders/1/status",
+			query:     map[string]string{"status": "Published", "username": "user1"},
+			mock: func() {
+				m.EXPECT().SetTenderStatus(1, "PUBLISHED", "user1", 0).Return(tender, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "editTender",
+			method:    http.MethodPatch,
+			path:      "/api/tenders/1/edit",
+			query:     map[string]string{"username": "user1"},
+			body:      `{"name":"tender #1"}`,
+			mock: func() {
+				m.EXPECT().EditTender(1, gomock.Any(), "user1", 0).Return(tender, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "rollbackTender",
+			method:    http.MethodPut,
+			path:      "/api/tenders/1/rollback/1",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().RollbackTender(1, 1, "user1", 0).Return(tender, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "createBid",
+			method:    http.MethodPost,
+			path:      "/api/bids/new",
+			body:      `{"name":"bid #1","description":"new","status":"Created","tenderId":"1","organizationId":"1","creatorUsername":"user1"}`,
+			mock: func() {
+				m.EXPECT().CreateBid(gomock.Any(), "user1").Return(bid, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "getUserBids",
+			method:    http.MethodGet,
+			path:      "/api/bids/my",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().GetBidsByUser("user1", gomock.Any()).Return([]models.Bid{bid}, models.Page{Total: 1}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "getBidsForTender",
+			method:    http.MethodGet,
+			path:      "/api/bids/1/list",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().GetBidsForTender(1, "user1", gomock.Any()).Return([]models.Bid{bid}, models.Page{Total: 1}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "getBidStatus",
+			method:    http.MethodGet,
+			path:      "/api/bids/1/status",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().GetBidStatus(1, "user1").Return(models.PublishedB, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "updateBidStatus",
+			method:    http.MethodPut,
+			path:      "/api/bids/1/status",
+			query:     map[string]string{"status": "Canceled", "username": "user1"},
+			mock: func() {
+				m.EXPECT().SetBidStatus(1, "CANCELED", "user1", 0).Return(bid, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "editBid",
+			method:    http.MethodPatch,
+			path:      "/api/bids/1/edit",
+			query:     map[string]string{"username": "user1"},
+			body:      `{"description":"updated"}`,
+			mock: func() {
+				m.EXPECT().EditBid(1, gomock.Any(), "user1", 0).Return(bid, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "submitBidDecision",
+			method:    http.MethodPut,
+			path:      "/api/bids/1/submit_decision",
+			query:     map[string]string{"decision": "Rejected", "username": "user1"},
+			mock: func() {
+				m.EXPECT().DeclineDecision(1, "user1").Return(bid, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "submitBidFeedback",
+			method:    http.MethodPut,
+			path:      "/api/bids/1/feedback",
+			query:     map[string]string{"bidFeedback": "good job", "username": "user1"},
+			mock: func() {
+				m.EXPECT().AddFeedback(gomock.Any(), "user1").Return(bid, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "rollbackBid",
+			method:    http.MethodPut,
+			path:      "/api/bids/1/rollback/1",
+			query:     map[string]string{"username": "user1"},
+			mock: func() {
+				m.EXPECT().RollbackBid(1, 1, "user1", 0).Return(bid, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "getBidReviews",
+			method:    http.MethodGet,
+			path:      "/api/bids/1/reviews",
+			query:     map[string]string{"authorUsername": "user2", "requesterUsername": "user1"},
+			mock: func() {
+				m.EXPECT().GetReviewsByAuthorAndTender(1, "user2", "user1", gomock.Any()).Return([]models.Review{review}, models.Page{Total: 1}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			operation: "updateTenderStatus",
+			method:    http.MethodPut,
+			path:      "/api/tenders/1/status",
+			query:     map[string]string{"status": "Open", "username": "user1"},
+			code:      http.StatusBadRequest,
+		},
+		{
+			operation: "createTender",
+			method:    http.MethodPost,
+			path:      "/api/tenders/new",
+			body:      `{"name":"tender #1","description":"new","serviceType":"Delivery","status":"Created","organizationId":1,"creatorUsername":"user1"}`,
+			code:      http.StatusBadRequest,
+		},
+		{
+			operation: "getTenders",
+			method:    http.MethodGet,
+			path:      "/api/tenders",
+			query:     map[string]string{"limit": "51"},
+			code:      http.StatusBadRequest,
+		},
+		{
+			operation: "getTenderStatus",
+			method:    http.MethodGet,
+			path:      "/api/tenders/1/status",
+			mock: func() {
+				m.EXPECT().GetTenderStatus(1, "user1").Return(models.TenderStatus("DELETED"), nil)
+			},
+			code: http.StatusInternalServerError,
+		},
+	}
+
+	// Набор проверок строится по спецификации: у каждой операции должен быть успешный сценарий
+	covered := map[string]bool{}
+	for _, tt := range tests {
+		if tt.code == http.StatusOK {
+			covered[tt.operation] = true
+		}
+	}
+	for _, item := range doc.Paths.Map() {
+		for _, op := range item.Operations() {
+			assert.True(t, covered[op.OperationID], "operation %s has no conformance case", op.OperationID)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.operation, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			req := resty.New().R().
+				SetHeader("Authorization", "Bearer "+token).
+				SetQueryParams(tt.query)
+			if tt.body != "" {
+				req.SetHeader("Content-Type", "application/json").SetBody(tt.body)
+			}
+			resp, err := req.Execute(tt.method, httpSrv.URL+tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode(), string(resp.Body()))
+			if tt.code != http.StatusOK {
+				assert.Contains(t, string(resp.Body()), `"reason"`)
+			}
+		})
+	}
+}
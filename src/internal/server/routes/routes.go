@@ -1,44 +1,131 @@
 package routes
 
 import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/server"
 	"github.com/gin-gonic/gin"
 )
 
-func SetupRoutes(s *server.Server) *gin.Engine {
+// SetupRoutes регистрирует маршруты из задание/openapi.yml. Дополнительные
+// middleware (например, проверка контракта OpenAPI) применяются ко всем маршрутам.
+func SetupRoutes(s *server.Server, middleware ...gin.HandlerFunc) *gin.Engine {
 	r := gin.Default()
+	r.Use(middleware...)
+	// Ошибки обработчиков превращаются в ответ {"reason": ...} в одном месте
+	r.Use(s.ErrorMiddleware())
 
 	pingGroup := r.Group("/api")
 	{
 		pingGroup.GET("/ping", s.PingHandler)
 	}
 
-	tenderGroup := r.Group("/api/tenders")
+	authGroup := r.Group("/api/auth")
+	{
+		authGroup.POST("/token", s.LoginHandler)
+	}
+
+	// gin требует одинаковых имен параметров на одной позиции пути,
+	// поэтому {tenderId} и {bidId} из спецификации регистрируются как :id
+	tenderGroup := r.Group("/api/tenders", s.AuthMiddleware())
 	{
-		tenderGroup.GET("/", s.GetAllTendersHandler)
-		tenderGroup.GET("/my", s.GetTendersByUser)
-		tenderGroup.POST("/new", s.CreateTenderHandler)
-		tenderGroup.PATCH("/status/:id", s.SetTenderStatusHandler)
-		tenderGroup.PATCH("/:id/edit", s.EditTenderHandler)
-		tenderGroup.PUT("/:tenderID/rollback/:version", s.RollbackTenderHandler)
+		handle(tenderGroup, http.MethodGet, "", authz.ActionListTenders, s.GetAllTendersHandler)
+		handle(tenderGroup, http.MethodGet, "/my", authz.ActionListOwnTenders, s.GetTendersByUser)
+		handle(tenderGroup, http.MethodPost, "/new", authz.ActionCreateTender, s.CreateTenderHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/status", authz.ActionViewTender, s.GetTenderStatusHandler)
+		handle(tenderGroup, http.MethodPut, "/:id/status", authz.ActionSetTenderStatus, s.SetTenderStatusHandler)
+		handle(tenderGroup, http.MethodPatch, "/:id/edit", authz.ActionEditTender, s.EditTenderHandler)
+		handle(tenderGroup, http.MethodPut, "/:id/rollback/:version", authz.ActionRollbackTender, s.RollbackTenderHandler)
+		// Журнала статусов и версий нет в спецификации, контракт для них не проверяется
+		handle(tenderGroup, http.MethodGet, "/:id/transitions", authz.ActionViewTender, s.GetTenderTransitionsHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/versions", authz.ActionViewTender, s.GetTenderVersionsHandler)
+		handle(tenderGroup, http.MethodPut, "/:id/decision_policy", authz.ActionEditTender, s.SetTenderDecisionPolicyHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/award", authz.ActionViewAward, s.GetTenderAwardHandler)
+		handle(tenderGroup, http.MethodPost, "/:id/award", authz.ActionAwardTender, s.AwardTenderHandler)
+		handle(tenderGroup, http.MethodPut, "/:id/criteria", authz.ActionEditTender, s.SetTenderCriteriaHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/leaderboard", authz.ActionViewScores, s.GetTenderLeaderboardHandler)
+		// Вложений нет в спецификации
+		handle(tenderGroup, http.MethodPost, "/:id/attachments", authz.ActionEditTender, s.UploadAttachmentHandler(models.TenderAttachments))
+		handle(tenderGroup, http.MethodGet, "/:id/attachments", authz.ActionViewTender, s.GetAttachmentsHandler(models.TenderAttachments))
+		handle(tenderGroup, http.MethodGet, "/:id/attachments/:attachmentId", authz.ActionViewTender, s.DownloadAttachmentHandler(models.TenderAttachments))
+		handle(tenderGroup, http.MethodDelete, "/:id/attachments/:attachmentId", authz.ActionEditTender, s.DeleteAttachmentHandler(models.TenderAttachments))
+		// Видимости и приглашений нет в спецификации
+		handle(tenderGroup, http.MethodPut, "/:id/visibility", authz.ActionEditTender, s.SetTenderVisibilityHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/invitations", authz.ActionViewInvitations, s.GetInvitationsHandler)
+		handle(tenderGroup, http.MethodPost, "/:id/invitations", authz.ActionInvite, s.InviteHandler)
+		handle(tenderGroup, http.MethodDelete, "/:id/invitations/:invitationId", authz.ActionInvite, s.RevokeInvitationHandler)
+		// Вопросов и раундов пересмотра нет в спецификации
+		handle(tenderGroup, http.MethodGet, "/:id/questions", authz.ActionViewTender, s.GetQuestionsHandler)
+		handle(tenderGroup, http.MethodPost, "/:id/questions", authz.ActionAskQuestion, s.AskQuestionHandler)
+		handle(tenderGroup, http.MethodPut, "/:id/questions/:questionId/answer", authz.ActionAnswerQuestion, s.AnswerQuestionHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/rounds", authz.ActionViewTender, s.GetRoundsHandler)
+		handle(tenderGroup, http.MethodPost, "/:id/rounds", authz.ActionOpenRound, s.OpenRoundHandler)
+		// Аукциона нет в спецификации
+		handle(tenderGroup, http.MethodGet, "/:id/auction", authz.ActionViewTender, s.GetAuctionHandler)
+		handle(tenderGroup, http.MethodPost, "/:id/auction", authz.ActionOpenAuction, s.OpenAuctionHandler)
+		handle(tenderGroup, http.MethodGet, "/:id/auction/stream", authz.ActionViewTender, s.AuctionStreamHandler)
+		// Потоков событий нет в спецификации
+		handle(tenderGroup, http.MethodGet, "/:id/events", authz.ActionViewTender, s.TenderEventsHandler)
 	}
 
-	bidsGroup := r.Group("/api/bids")
+	bidsGroup := r.Group("/api/bids", s.AuthMiddleware())
 	{
-		bidsGroup.GET("/:tenderID/list", s.GetBidsForTenderHandler)
-		bidsGroup.GET("/my", s.GetBidsByUserHandler)
-		bidsGroup.POST("/new", s.CreateBidHandler)
-		bidsGroup.PATCH("/status/:id", s.SetBidStatusHandler)
-		bidsGroup.PATCH("/:id/edit", s.EditBidHandler)
-		bidsGroup.PUT("/:bidID/rollback/:version", s.RollbackBidHandler)
-		bidsGroup.PATCH("/:id/submit_decision", s.SubmitDecisionHandler)
-		bidsGroup.PATCH("/:id/decline_decision", s.SubmitDeclinedHandler)
+		handle(bidsGroup, http.MethodPost, "/new", authz.ActionCreateBid, s.CreateBidHandler)
+		handle(bidsGroup, http.MethodGet, "/my", authz.ActionListOwnBids, s.GetBidsByUserHandler)
+		handle(bidsGroup, http.MethodGet, "/events", authz.ActionListOwnBids, s.OwnBidEventsHandler)
+		handle(bidsGroup, http.MethodGet, "/:id/list", authz.ActionListTenderBids, s.GetBidsForTenderHandler)
+		handle(bidsGroup, http.MethodGet, "/:id/status", authz.ActionViewBid, s.GetBidStatusHandler)
+		handle(bidsGroup, http.MethodPut, "/:id/status", authz.ActionSetBidStatus, s.SetBidStatusHandler)
+		handle(bidsGroup, http.MethodPatch, "/:id/edit", authz.ActionEditBid, s.EditBidHandler)
+		handle(bidsGroup, http.MethodPut, "/:id/submit_decision", authz.ActionDecideBid, s.SubmitDecisionHandler)
+		handle(bidsGroup, http.MethodPut, "/:id/rollback/:version", authz.ActionRollbackBid, s.RollbackBidHandler)
+		handle(bidsGroup, http.MethodGet, "/:id/versions", authz.ActionViewBid, s.GetBidVersionsHandler)
+		// Бюллетеня нет в спецификации, контракт для него не проверяется
+		handle(bidsGroup, http.MethodGet, "/:id/decisions", authz.ActionViewDecisions, s.GetBidDecisionsHandler)
+		handle(bidsGroup, http.MethodDelete, "/:id/decisions", authz.ActionDecideBid, s.RetractDecisionHandler)
+		// Оценок по критериям тоже нет в спецификации
+		handle(bidsGroup, http.MethodPut, "/:id/scores", authz.ActionScoreBid, s.ScoreBidHandler)
+		handle(bidsGroup, http.MethodPost, "/:id/attachments", authz.ActionEditBid, s.UploadAttachmentHandler(models.BidAttachments))
+		handle(bidsGroup, http.MethodGet, "/:id/attachments", authz.ActionViewBid, s.GetAttachmentsHandler(models.BidAttachments))
+		handle(bidsGroup, http.MethodGet, "/:id/attachments/:attachmentId", authz.ActionViewBid, s.DownloadAttachmentHandler(models.BidAttachments))
+		handle(bidsGroup, http.MethodDelete, "/:id/attachments/:attachmentId", authz.ActionEditBid, s.DeleteAttachmentHandler(models.BidAttachments))
+		handle(bidsGroup, http.MethodPost, "/:id/auction_offer", authz.ActionAuctionOffer, s.SubmitAuctionPriceHandler)
 
 		//отзывы
-		bidsGroup.POST("/feedback", s.AddFeedbackHandler)
-		bidsGroup.GET("/:tenderID/reviews", s.GetReviewsHandler)
-		// GET /api/bids/1/reviews?authorUsername=user2&organizationId=1
-		//}
-		return r
+		handle(bidsGroup, http.MethodPut, "/:id/feedback", authz.ActionAddFeedback, s.AddFeedbackHandler)
+		handle(bidsGroup, http.MethodGet, "/:id/reviews", authz.ActionViewAuthorReviews, s.GetReviewsHandler)
+		// GET /api/bids/1/reviews?authorUsername=user2&requesterUsername=user1
+	}
+
+	// Организаций и сотрудников нет в спецификации
+	organizationGroup := r.Group("/api/organizations", s.AuthMiddleware())
+	{
+		handle(organizationGroup, http.MethodGet, "", authz.ActionListOrganizations, s.GetOrganizationsHandler)
+		handle(organizationGroup, http.MethodPost, "/new", authz.ActionCreateOrganization, s.CreateOrganizationHandler)
+		handle(organizationGroup, http.MethodGet, "/:id", authz.ActionViewOrganization, s.GetOrganizationHandler)
+		handle(organizationGroup, http.MethodPatch, "/:id/edit", authz.ActionEditOrganization, s.EditOrganizationHandler)
+		handle(organizationGroup, http.MethodDelete, "/:id", authz.ActionDeleteOrganization, s.DeleteOrganizationHandler)
+		handle(organizationGroup, http.MethodPut, "/:id/decision_policy", authz.ActionSetOrganizationPolicy, s.SetOrganizationDecisionPolicyHandler)
+		handle(organizationGroup, http.MethodGet, "/:id/responsibles", authz.ActionViewOrganization, s.GetResponsiblesHandler)
+		handle(organizationGroup, http.MethodPut, "/:id/responsibles/:username", authz.ActionManageResponsibles, s.SetResponsibleHandler)
+		handle(organizationGroup, http.MethodDelete, "/:id/responsibles/:username", authz.ActionManageResponsibles, s.RemoveResponsibleHandler)
+	}
+
+	employeeGroup := r.Group("/api/employees", s.AuthMiddleware())
+	{
+		handle(employeeGroup, http.MethodGet, "", authz.ActionListEmployees, s.GetEmployeesHandler)
+		handle(employeeGroup, http.MethodPost, "/new", authz.ActionCreateEmployee, s.CreateEmployeeHandler)
+		handle(employeeGroup, http.MethodGet, "/:username", authz.ActionViewEmployee, s.GetEmployeeHandler)
+		handle(employeeGroup, http.MethodPatch, "/:username/edit", authz.ActionEditEmployee, s.EditEmployeeHandler)
+		handle(employeeGroup, http.MethodDelete, "/:username", authz.ActionDeleteEmployee, s.DeleteEmployeeHandler)
 	}
+	return r
+}
+
+// handle регистрирует защищенный эндпоинт. Без объявленной в authz политики
+// сервер не стартует, без аутентификации эндпоинт не выполняется.
+func handle(group *gin.RouterGroup, method, path string, action authz.Action, handler gin.HandlerFunc) {
+	group.Handle(method, path, server.RequireAction(action), handler)
 }
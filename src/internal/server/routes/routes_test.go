@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/server"
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+// Каждый защищенный эндпоинт должен быть объявлен с политикой authz
+func TestSetupRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	zlog := zerolog.New(os.Stdout)
+	s := server.New(context.Background(), nil, nil, nil, server.AttachmentLimits{}, nil, &zlog)
+	assert.NotPanics(t, func() { SetupRoutes(s) })
+}
+
+// Без токена отвечают только публичные эндпоинты
+func TestRoutesRequireAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	zlog := zerolog.New(os.Stdout)
+	s := server.New(context.Background(), nil, nil, nil, server.AttachmentLimits{}, nil, &zlog)
+	r := SetupRoutes(s)
+
+	public := map[string]bool{"GET /api/ping": true, "POST /api/auth/token": true}
+	for _, route := range r.Routes() {
+		if public[route.Method+" "+route.Path] {
+			continue
+		}
+		path := strings.NewReplacer(":id", "1", ":version", "1", ":attachmentId", "1", ":invitationId", "1",
+			":questionId", "1", ":username", "user1").Replace(route.Path)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(route.Method, path, nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "%s %s", route.Method, route.Path)
+	}
+}
+
+// Эндпоинт, зарегистрированный без AuthMiddleware, не выполняется анонимно
+func TestHandleWithoutAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	zlog := zerolog.New(os.Stdout)
+	s := server.New(context.Background(), nil, nil, nil, server.AttachmentLimits{}, nil, &zlog)
+	r := gin.New()
+	r.Use(s.ErrorMiddleware())
+
+	called := false
+	handle(r.Group("/api"), http.MethodGet, "/open", authz.ActionListTenders, func(ctx *gin.Context) {
+		called = true
+		ctx.Status(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/open", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.False(t, called)
+}
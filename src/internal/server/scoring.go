@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"github.com/gin-gonic/gin"
+)
+
+// SetTenderCriteriaHandler заменяет критерии оценки тендера
+func (s *Server) SetTenderCriteriaHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var criteria []models.Criterion
+	if err := ctx.ShouldBindJSON(&criteria); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	criteria, err := s.Db.SetTenderCriteria(id, criteria, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, criteria)
+}
+
+// ScoreBidHandler принимает оценки по кодам критериев: {"price": 8, "quality": 7}
+func (s *Server) ScoreBidHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	var values map[string]int
+	if err := ctx.ShouldBindJSON(&values); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	scores, err := s.Db.ScoreBid(id, values, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newScoreResponses(scores))
+}
+
+// GetTenderLeaderboardHandler возвращает рейтинг предложений тендера по оценкам
+func (s *Server) GetTenderLeaderboardHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	leaderboard, err := s.Db.GetTenderLeaderboard(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newLeaderboardResponse(id, leaderboard))
+}
@@ -2,57 +2,149 @@ package server
 
 import (
 	"context"
+	"time"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/auth"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/events"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/storage"
 	"github.com/go-playground/validator"
 	"github.com/rs/zerolog"
 )
 
+// Последний int в методах изменения - версия из If-Match (0 - без проверки)
 type TendersRepo interface {
-	GetAllTenders(string) ([]models.Tender, error)
-	GetTendersByUser(string) ([]models.Tender, error)
+	GetAllTenders(string, models.ListParams) ([]models.Tender, models.Page, error)
+	GetTendersByUser(string, models.ListParams) ([]models.Tender, models.Page, error)
 	CreateTender(models.Tender) (models.Tender, error)
-	SetTenderStatus(int, string) error
-	EditTender(int, string, string) (models.Tender, error)
-	RollbackTender(int, int) (models.Tender, error)
+	GetTenderStatus(int, string) (models.TenderStatus, error)
+	SetTenderStatus(int, string, string, int) (models.Tender, error)
+	EditTender(int, models.TenderUpdate, string, int) (models.Tender, error)
+	RollbackTender(int, int, string, int) (models.Tender, error)
+	GetTenderTransitions(int, string) ([]models.TenderTransition, error)
+	GetTenderVersions(int, string) ([]models.Version, error)
+	SetTenderDecisionPolicy(int, models.DecisionPolicy, string, int) (models.Tender, error)
+	AwardTender(int, int, string, string) (models.AwardSummary, error)
+	GetTenderAward(int, string) (models.AwardSummary, error)
+	SetTenderCriteria(int, []models.Criterion, string) ([]models.Criterion, error)
+	GetTenderLeaderboard(int, string) (models.Leaderboard, error)
+	SetTenderVisibility(int, models.TenderVisibility, string, int) (models.Tender, error)
+	GetInvitations(int, string) ([]models.Invitation, error)
+	Invite(int, models.Invitation, string) (models.Invitation, error)
+	RevokeInvitation(int, int, string) error
+	AskQuestion(int, string, string) (models.Question, error)
+	GetQuestions(int, string) ([]models.Question, error)
+	AnswerQuestion(int, int, string, string) (models.Question, error)
+	OpenRound(int, models.RoundRequest, string) (models.Round, error)
+	GetRounds(int, string) ([]models.Round, error)
+	OpenAuction(int, models.AuctionSettings, string) (models.Auction, error)
+	GetAuction(int, string) (models.Auction, error)
 }
 
 type BidsRepo interface {
-	GetBidsByUser(string) ([]models.Bid, error)
-	GetBidsForTender(int) ([]models.Bid, error)
+	GetBidsByUser(string, models.ListParams) ([]models.Bid, models.Page, error)
+	GetBidsForTender(int, string, models.ListParams) ([]models.Bid, models.Page, error)
 	CreateBid(models.Bid, string) (models.Bid, error)
-	SetBidStatus(int, string) error
-	EditBid(int, string, string) (models.Bid, error)
-	RollbackBid(int, int) (models.Bid, error)
-	SubmitDecision(int, string) error
-	DeclineDecision(int, string) error
-	CheckUserPermissionForBid(int, string) (bool, error)
-	CheckUserPermissionForTender(int, string) (bool, error)
-	GetTenderIDByBidID(int) (int, error)
+	GetBidStatus(int, string) (models.BidStatus, error)
+	SetBidStatus(int, string, string, int) (models.Bid, error)
+	EditBid(int, models.BidUpdate, string, int) (models.Bid, error)
+	RollbackBid(int, int, string, int) (models.Bid, error)
+	GetBidVersions(int, string) ([]models.Version, error)
+	SubmitDecision(int, string) (models.Bid, error)
+	DeclineDecision(int, string) (models.Bid, error)
+	RetractDecision(int, string) (models.Bid, error)
+	GetBidDecisions(int, string) (models.Ballot, error)
+	ScoreBid(int, map[string]int, string) ([]models.Score, error)
+	SubmitAuctionPrice(int, models.Money, string) (models.Auction, error)
 }
 
 type FeedbackReview interface {
-	AddFeedback(models.Review, string) error
-	GetReviewsByAuthorAndTender(int, string, int) ([]models.Review, error)
+	AddFeedback(models.Review, string) (models.Bid, error)
+	GetReviewsByAuthorAndTender(int, string, string, models.ListParams) ([]models.Review, models.Page, error)
+}
+
+type EmployeeRepo interface {
+	GetEmployeeByUsername(string) (models.Employee, error)
+	GetEmployees(string) ([]models.Employee, error)
+	CreateEmployee(models.Employee, string) (models.Employee, error)
+	EditEmployee(string, models.EmployeeUpdate, string) (models.Employee, error)
+	DeleteEmployee(string, string) error
+}
+
+type OrganizationRepo interface {
+	SetOrganizationDecisionPolicy(int, models.DecisionPolicy, string) (models.DecisionPolicy, error)
+	GetOrganizations(string) ([]models.Organization, error)
+	GetOrganization(int, string) (models.Organization, error)
+	CreateOrganization(models.Organization, string) (models.Organization, error)
+	EditOrganization(int, models.OrganizationUpdate, string) (models.Organization, error)
+	DeleteOrganization(int, string) error
+	GetResponsibles(int, string) ([]models.Responsible, error)
+	SetResponsible(int, string, string, string) ([]models.Responsible, error)
+	RemoveResponsible(int, string, string) ([]models.Responsible, error)
+}
+
+// Последний int в методах изменения - версия владельца из If-Match
+type AttachmentsRepo interface {
+	AddAttachment(models.AttachmentOwner, models.Attachment, func(context.Context) error, string, int) (models.Attachment, error)
+	GetAttachments(models.AttachmentOwner, string) ([]models.Attachment, error)
+	GetAttachment(models.AttachmentOwner, int, string) (models.Attachment, error)
+	DeleteAttachment(models.AttachmentOwner, int, string, int) error
+}
+
+type EventsRepo interface {
+	EventVisible(models.Event, string, models.EventScope) (bool, error)
 }
 
 type Repository interface {
 	TendersRepo
 	BidsRepo
 	FeedbackReview
+	EmployeeRepo
+	OrganizationRepo
+	AttachmentsRepo
+	EventsRepo
+}
+
+// AttachmentLimits - ограничения на загружаемые вложения
+type AttachmentLimits struct {
+	MaxSize int64
+	// Types - разрешенные MIME-типы, определяемые по содержимому файла
+	Types []string
 }
 
+// defaultAuctionPoll - как часто поток аукциона перечитывает его состояние
+const defaultAuctionPoll = time.Second
+
+// defaultHeartbeat - период комментариев-пингов в потоках событий: без них
+// прокси закрывают простаивающие соединения
+const defaultHeartbeat = 30 * time.Second
+
 type Server struct {
-	Db    Repository
-	log   zerolog.Logger
-	Valid *validator.Validate
+	Db     Repository
+	Auth   *auth.Manager
+	Blobs  storage.BlobStore
+	Limits AttachmentLimits
+	log    zerolog.Logger
+	Valid  *validator.Validate
+	// AuctionPoll - период опроса состояния аукциона для потока событий
+	AuctionPoll time.Duration
+	// Events раздает события активности потокам подписчиков
+	Events    *events.Hub
+	Heartbeat time.Duration
 }
 
-func New(ctx context.Context, db Repository, zlog *zerolog.Logger) *Server {
+func New(ctx context.Context, db Repository, authManager *auth.Manager, blobs storage.BlobStore, limits AttachmentLimits, hub *events.Hub, zlog *zerolog.Logger) *Server {
 	validate := validator.New()
 	return &Server{
-		Db:    db,
-		log:   *zlog,
-		Valid: validate,
+		Db:     db,
+		Auth:   authManager,
+		Blobs:  blobs,
+		Limits: limits,
+		log:    *zlog,
+		Valid:  validate,
+
+		AuctionPoll: defaultAuctionPoll,
+		Events:      hub,
+		Heartbeat:   defaultHeartbeat,
 	}
 }
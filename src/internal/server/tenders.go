@@ -1,146 +1,287 @@
 package server
 
 import (
-	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
 	"github.com/gin-gonic/gin"
 )
 
 // возвращает список тендеров с фильтрацией по типу услуг
 func (s *Server) GetAllTendersHandler(ctx *gin.Context) {
-	serviceType := ctx.Query("serviceType")
+	params, err := parseTenderListParams(ctx)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	// Старый параметр serviceType поддерживается наравне с service_type из спецификации
+	params.ServiceTypes = append(params.ServiceTypes, queryList(ctx, "serviceType")...)
 
-	tenders, err := s.Db.GetAllTenders(serviceType)
+	tenders, page, err := s.Db.GetAllTenders(currentUsername(ctx), params)
 	if err != nil {
-		s.log.Error().Err(err).Msg("Failed to get tenders")
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to fetch tenders", "error": err.Error()})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "List of tenders", "tenders": tenders})
+	setPageHeaders(ctx, page)
+	ctx.JSON(http.StatusOK, newTenderResponses(tenders))
 }
 
 func (s *Server) GetTendersByUser(ctx *gin.Context) {
-	username := ctx.Query("username")
-	tenders, err := s.Db.GetTendersByUser(username)
+	username := currentUsername(ctx)
+	params, err := parseTenderListParams(ctx)
 	if err != nil {
-		s.log.Error().Err(err).Msg("Invalid username")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid params"})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, tenders)
-
+	tenders, page, err := s.Db.GetTendersByUser(username, params)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	setPageHeaders(ctx, page)
+	ctx.JSON(http.StatusOK, newTenderResponses(tenders))
 }
 
 func (s *Server) CreateTenderHandler(ctx *gin.Context) {
-	var tender models.Tender
-	if err := ctx.ShouldBindBodyWithJSON(&tender); err != nil {
-		s.log.Error().Err(err).Msg("Invalid request body")
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Invalid request body"})
+	var req createTenderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
 		return
 	}
+	// Автором тендера всегда является владелец токена
+	if req.CreatorUsername != "" && req.CreatorUsername != currentUsername(ctx) {
+		fail(ctx, apperr.Forbidden("creatorUsername does not match the token owner"))
+		return
+	}
+	tender := models.Tender{
+		Name:            req.Name,
+		Description:     req.Description,
+		ServiceType:     req.ServiceType,
+		OrganizationID:  int(req.OrganizationID),
+		CreatorUsername: currentUsername(ctx),
+		DecisionPolicy:  req.DecisionPolicy,
+
+		SubmissionDeadline: req.SubmissionDeadline,
+		DecisionDeadline:   req.DecisionDeadline,
+		RequiredItems:      req.RequiredItems,
+		Sealed:             req.Sealed,
+	}
+	if req.Budget != nil {
+		tender.Budget = *req.Budget
+	}
+	if req.Visibility != "" {
+		visibility, ok := models.ParseTenderVisibility(req.Visibility)
+		if !ok {
+			fail(ctx, apperr.Invalid("Invalid visibility %s", req.Visibility))
+			return
+		}
+		tender.Visibility = visibility
+	}
 	if err := s.Valid.Struct(tender); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		fail(ctx, apperr.Invalid("%v", err))
 		return
 	}
 	tender, err := s.Db.CreateTender(tender)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("user %s is not responsible for organization %d", tender.CreatorUsername, tender.OrganizationID) {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "User is not responsible for this organization"})
-			return
-		}
-		s.log.Error().Err(err).Msg("Failed to add tender")
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to add tender", "error": err.Error()})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "Tender created successfully", "tender": tender})
+	writeTender(ctx, tender)
 }
-func (s *Server) SetTenderStatusHandler(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.Atoi(idStr)
+
+func (s *Server) GetTenderStatusHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	status, err := s.Db.GetTenderStatus(id, currentUsername(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tender ID"})
+		fail(ctx, err)
 		return
 	}
+	ctx.JSON(http.StatusOK, status.API())
+}
 
-	// Извлечение нового статуса из тела запроса
-	var requestBody struct {
-		Status string `json:"status"`
+func (s *Server) SetTenderStatusHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
 	}
 
-	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	// Новый статус передается в параметре запроса, допустимость перехода проверяет репозиторий
+	status, ok := models.ParseTenderStatus(ctx.Query("status"))
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid status"))
 		return
 	}
 
-	if requestBody.Status != "PUBLISHED" && requestBody.Status != "CLOSED" {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+	expected, err := ifMatch(ctx)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
-
-	err = s.Db.SetTenderStatus(id, requestBody.Status)
+	tender, err := s.Db.SetTenderStatus(id, string(status), currentUsername(ctx), expected)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "Tender status updated successfully"})
+	writeTender(ctx, tender)
 }
 
 func (s *Server) EditTenderHandler(ctx *gin.Context) {
-	idStr := ctx.Param("id")
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tender ID"})
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
 		return
 	}
 
-	var requestBody struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
+	var update models.TenderUpdate
+	if err := ctx.ShouldBindJSON(&update); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if err := s.Valid.Struct(update); err != nil {
+		fail(ctx, apperr.Invalid("%v", err))
+		return
 	}
 
-	if err := s.Valid.Struct(requestBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	expected, err := ifMatch(ctx)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	tender, err := s.Db.EditTender(id, update, currentUsername(ctx), expected)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
 
-	if err := ctx.ShouldBindJSON(&requestBody); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+	writeTender(ctx, tender)
+}
+
+func (s *Server) RollbackTenderHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		fail(ctx, apperr.Invalid("Invalid version"))
+		return
+	}
+	expected, err := ifMatch(ctx)
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	updatedTender, err := s.Db.RollbackTender(id, version, currentUsername(ctx), expected)
+	if err != nil {
+		fail(ctx, err)
 		return
 	}
+	writeTender(ctx, updatedTender)
+}
 
-	query, err := s.Db.EditTender(id, requestBody.Name, requestBody.Description)
+// GetTenderTransitionsHandler возвращает журнал смены статусов тендера
+func (s *Server) GetTenderTransitionsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	transitions, err := s.Db.GetTenderTransitions(id, currentUsername(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
+	ctx.JSON(http.StatusOK, newTransitionResponses(transitions))
+}
 
-	ctx.JSON(http.StatusOK, query)
+// GetTenderVersionsHandler возвращает историю версий тендера с изменениями между ними
+func (s *Server) GetTenderVersionsHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	versions, err := s.Db.GetTenderVersions(id, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newVersionResponses(versions))
 }
 
-func (s *Server) RollbackTenderHandler(ctx *gin.Context) {
-	idStr := ctx.Param("tenderID")
-	log.Println("tenderID:", idStr) // Логируем значение
-	id, err := strconv.Atoi(idStr)
+// SetTenderDecisionPolicyHandler заменяет правила голосования тендера
+func (s *Server) SetTenderDecisionPolicyHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var policy models.DecisionPolicy
+	if err := ctx.ShouldBindJSON(&policy); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	expected, err := ifMatch(ctx)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tender ID"})
+		fail(ctx, err)
 		return
 	}
-	versionStr := ctx.Param("version")
-	log.Println("version:", versionStr) // Логируем значение
-	version, err := strconv.Atoi(versionStr)
+	tender, err := s.Db.SetTenderDecisionPolicy(id, policy, currentUsername(ctx), expected)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid version"})
+		fail(ctx, err)
+		return
+	}
+	writeTender(ctx, tender)
+}
+
+// AwardTenderHandler выбирает победителя среди одобренных предложений тендера
+func (s *Server) AwardTenderHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
+		return
+	}
+	var req awardRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		fail(ctx, apperr.Invalid("Invalid request body"))
+		return
+	}
+	if req.BidID <= 0 {
+		fail(ctx, apperr.Invalid("Invalid bid ID"))
+		return
+	}
+	if req.Reason == "" || len([]rune(req.Reason)) > 1000 {
+		fail(ctx, apperr.Invalid("Invalid award reason"))
+		return
+	}
+	summary, err := s.Db.AwardTender(id, int(req.BidID), req.Reason, currentUsername(ctx))
+	if err != nil {
+		fail(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, newAwardResponse(summary))
+}
+
+// GetTenderAwardHandler возвращает победителя тендера и сравнение предложений
+func (s *Server) GetTenderAwardHandler(ctx *gin.Context) {
+	id, ok := pathID(ctx, "id")
+	if !ok {
+		fail(ctx, apperr.Invalid("Invalid tender ID"))
 		return
 	}
-	updatedTender, err := s.Db.RollbackTender(id, version)
+	summary, err := s.Db.GetTenderAward(id, currentUsername(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(ctx, err)
 		return
 	}
-	ctx.JSON(http.StatusOK, updatedTender)
+	ctx.JSON(http.StatusOK, newAwardResponse(summary))
 }
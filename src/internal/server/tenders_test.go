@@ -2,11 +2,15 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strconv"
 	"testing"
 
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/apperr"
+	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/authz"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
 	"git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/mocks"
 	"github.com/gin-gonic/gin"
@@ -17,6 +21,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// asUser подменяет AuthMiddleware в тестах обработчиков
+func asUser(username string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(usernameKey, username)
+		ctx.Next()
+	}
+}
+
 func TestGetAllTenders(t *testing.T) {
 	gin.SetMode(gin.TestMode) // Устанавливаем тестовый режим для Gin
 	ctrl := gomock.NewController(t)
@@ -29,7 +41,8 @@ func TestGetAllTenders(t *testing.T) {
 		Valid: validator.New(),
 	}
 	r := gin.Default()
-	r.GET("/api/tenders", srv.GetAllTendersHandler)
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders", asUser("user1"), srv.GetAllTendersHandler)
 	httpSrv := httptest.NewServer(r)
 	defer httpSrv.Close()
 
@@ -41,6 +54,7 @@ func TestGetAllTenders(t *testing.T) {
 		name    string
 		request string
 		filter  string
+		limit   string
 		method  string
 		tender  []models.Tender
 		err     error
@@ -50,15 +64,15 @@ func TestGetAllTenders(t *testing.T) {
 		{
 			name:    "Test 'GetAllTendersHandler' #1; Default call with service type",
 			request: "/api/tenders",
-			filter:  "?serviceType=it",
+			filter:  "Delivery",
 			method:  http.MethodGet,
 			tender: []models.Tender{
-				{ID: 1, Name: "tender #1", Description: "new", ServiceType: "it", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
-				{ID: 2, Name: "tender #2", Description: "new", ServiceType: "it", Status: models.PublishedT, OrganizationID: 2, CreatorUsername: "user2", Version: 1},
+				{ID: 1, Name: "tender #1", Description: "new", ServiceType: "Delivery", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
+				{ID: 2, Name: "tender #2", Description: "new", ServiceType: "Delivery", Status: models.PublishedT, OrganizationID: 2, CreatorUsername: "user2", Version: 1},
 			},
 			want: want{
 				code:    http.StatusOK,
-				tenders: `{"message":"List of tenders","tenders":[{"id":1,"name":"tender #1","description":"new","serviceType":"it","status":"PUBLISHED","organizationId":1,"creatorUsername":"user1","version":1},{"id":2,"name":"tender #2","description":"new","serviceType":"it","status":"PUBLISHED","organizationId":2,"creatorUsername":"user2","version":1}]}`,
+				tenders: `[{"id":"1","name":"tender #1","description":"new","serviceType":"Delivery","status":"Published","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}},{"id":"2","name":"tender #2","description":"new","serviceType":"Delivery","status":"Published","organizationId":"2","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}]`,
 			},
 		},
 		{
@@ -67,12 +81,12 @@ func TestGetAllTenders(t *testing.T) {
 			filter:  "",
 			method:  http.MethodGet,
 			tender: []models.Tender{
-				{ID: 1, Name: "tender #1", Description: "new", ServiceType: "it", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
-				{ID: 2, Name: "tender #2", Description: "new", ServiceType: "beauty", Status: models.PublishedT, OrganizationID: 2, CreatorUsername: "user2", Version: 1},
+				{ID: 1, Name: "tender #1", Description: "new", ServiceType: "Delivery", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
+				{ID: 2, Name: "tender #2", Description: "new", ServiceType: "Construction", Status: models.PublishedT, OrganizationID: 2, CreatorUsername: "user2", Version: 1},
 			},
 			want: want{
 				code:    http.StatusOK,
-				tenders: `{"message":"List of tenders","tenders":[{"id":1,"name":"tender #1","description":"new","serviceType":"it","status":"PUBLISHED","organizationId":1,"creatorUsername":"user1","version":1},{"id":2,"name":"tender #2","description":"new","serviceType":"beauty","status":"PUBLISHED","organizationId":2,"creatorUsername":"user2","version":1}]}`,
+				tenders: `[{"id":"1","name":"tender #1","description":"new","serviceType":"Delivery","status":"Published","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}},{"id":"2","name":"tender #2","description":"new","serviceType":"Construction","status":"Published","organizationId":"2","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}]`,
 			},
 		},
 		{
@@ -84,7 +98,7 @@ func TestGetAllTenders(t *testing.T) {
 			err:     nil,
 			want: want{
 				code:    http.StatusOK,
-				tenders: `{"message":"List of tenders","tenders":[]}`,
+				tenders: `[]`,
 			},
 		},
 		{
@@ -96,22 +110,39 @@ func TestGetAllTenders(t *testing.T) {
 			err:     errors.New("db error"),
 			want: want{
 				code:    http.StatusInternalServerError,
-				tenders: `{"message":"Failed to fetch tenders","error":"db error"}`,
+				tenders: `{"reason":"Internal server error"}`,
+			},
+		},
+		{
+			name:    "Test 'GetAllTenders' #5; Limit is out of range",
+			request: "/api/tenders",
+			filter:  "",
+			limit:   "51",
+			method:  http.MethodGet,
+			want: want{
+				code:    http.StatusBadRequest,
+				tenders: `{"reason":"limit must be between 0 and 50"}`,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tt.filter != "" {
-				m.EXPECT().GetAllTenders(tt.filter).Return(tt.tender, tt.err)
-			} else {
-				m.EXPECT().GetAllTenders("").Return(tt.tender, tt.err)
+			if tt.limit == "" {
+				params := models.ListParams{Limit: models.DefaultPageLimit}
+				if tt.filter != "" {
+					params.ServiceTypes = []string{tt.filter}
+				}
+				page := models.Page{Total: int64(len(tt.tender))}
+				m.EXPECT().GetAllTenders("user1", params).Return(tt.tender, page, tt.err)
 			}
 			srv.Db = m
 			if httpSrv.URL == "" {
 				t.Fatal("Test server is not running")
 			}
 			req := resty.New().R().SetQueryParam("serviceType", tt.filter)
+			if tt.limit != "" {
+				req.SetQueryParam("limit", tt.limit)
+			}
 			req.Method = tt.method
 			req.URL = httpSrv.URL + tt.request
 			resp, err := req.Send()
@@ -119,6 +150,9 @@ func TestGetAllTenders(t *testing.T) {
 			assert.NoError(t, err)
 			assert.JSONEq(t, tt.want.tenders, string(resp.Body()))
 			assert.Equal(t, tt.want.code, resp.StatusCode())
+			if tt.want.code == http.StatusOK {
+				assert.Equal(t, strconv.Itoa(len(tt.tender)), resp.Header().Get("X-Total-Count"))
+			}
 		})
 	}
 }
@@ -135,7 +169,8 @@ func TestGetTendersByUser(t *testing.T) {
 		Valid: validator.New(),
 	}
 	r := gin.Default()
-	r.GET("/api/tenders/my", srv.GetTendersByUser)
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/my", asUser("user1"), srv.GetTendersByUser)
 	httpSrv := httptest.NewServer(r)
 	defer httpSrv.Close() // Закрываем сервер в конце теста
 	type want struct {
@@ -158,12 +193,12 @@ func TestGetTendersByUser(t *testing.T) {
 			filter:  "user1",
 			method:  http.MethodGet,
 			tender: []models.Tender{
-				{ID: 1, Name: "tender #1", Description: "new", ServiceType: "it", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
-				{ID: 2, Name: "tender #2", Description: "new", ServiceType: "beauty", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
+				{ID: 1, Name: "tender #1", Description: "new", ServiceType: "Delivery", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
+				{ID: 2, Name: "tender #2", Description: "new", ServiceType: "Construction", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1},
 			},
 			want: want{
 				code:    http.StatusOK,
-				tenders: `[{"id":1,"name":"tender #1","description":"new","serviceType":"it","status":"PUBLISHED","organizationId":1,"creatorUsername":"user1","version":1},{"id":2,"name":"tender #2","description":"new","serviceType":"beauty","status":"PUBLISHED","organizationId":1,"creatorUsername":"user1","version":1}]`,
+				tenders: `[{"id":"1","name":"tender #1","description":"new","serviceType":"Delivery","status":"Published","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}},{"id":"2","name":"tender #2","description":"new","serviceType":"Construction","status":"Published","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}]`,
 			},
 		},
 		{
@@ -179,23 +214,24 @@ func TestGetTendersByUser(t *testing.T) {
 			},
 		},
 		{
-			name:    "Test 'GetTendersByUser' #3; Failed to fetch tenders",
+			name:    "Test 'GetTendersByUser' #3; Unknown employee",
 			request: "/api/tenders/my",
-			filter:  "",
+			filter:  "user1",
 			method:  http.MethodGet,
 			tender:  nil,
-			err:     errors.New("invalid username"),
+			err:     apperr.NotFound("Employee user1 not found"),
 			want: want{
-				code:    http.StatusBadRequest,
-				tenders: `{"message":"Invalid params"}`,
+				code:    http.StatusNotFound,
+				tenders: `{"reason":"Employee user1 not found"}`,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			m.EXPECT().GetTendersByUser(tt.filter).Return(tt.tender, tt.err)
+			params := models.ListParams{Limit: models.DefaultPageLimit}
+			m.EXPECT().GetTendersByUser(tt.filter, params).Return(tt.tender, models.Page{Total: int64(len(tt.tender))}, tt.err)
 			srv.Db = m
-			req := resty.New().R().SetQueryParam("username", tt.filter)
+			req := resty.New().R()
 			req.Method = tt.method
 			req.URL = httpSrv.URL + tt.request
 			resp, err := req.Send()
@@ -219,7 +255,8 @@ func TestCreateTenderHandler(t *testing.T) {
 		Valid: validator.New(),
 	}
 	r := gin.Default()
-	r.POST("/api/tenders/new", srv.CreateTenderHandler)
+	r.Use(srv.ErrorMiddleware())
+	r.POST("/api/tenders/new", asUser("user1"), srv.CreateTenderHandler)
 	httpSrv := httptest.NewServer(r)
 	defer httpSrv.Close()
 
@@ -242,48 +279,60 @@ func TestCreateTenderHandler(t *testing.T) {
 			name:    "Test 'CreateTenderHandler' #1; Valid request",
 			request: "/api/tenders/new",
 			method:  http.MethodPost,
-			body:    `{"name":"tender #1","description":"new","serviceType":"it","organizationId":1,"creatorUsername":"user1"}`,
+			body:    `{"name":"tender #1","description":"new","serviceType":"Delivery","status":"Created","organizationId":"1","creatorUsername":"user1"}`,
 			err:     nil,
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusOK,
-				answer: `{"message":"Tender created successfully","tender":{"id":1,"name":"tender #1","description":"new","serviceType":"it","status":"CREATED","organizationId":1,"creatorUsername":"user1","version":1}}`,
+				answer: `{"id":"1","name":"tender #1","description":"new","serviceType":"Delivery","status":"Created","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}`,
 			},
 		},
 		{
 			name:    "Test 'CreateTenderHandler' #2; Invalid request (missing required field)",
 			request: "/api/tenders/new",
 			method:  http.MethodPost,
-			body:    `{"name":"tender","description":"new","serviceType":"it","organizationId":1,"creatorUsername":"user1"`,
+			body:    `{"name":"tender","description":"new","serviceType":"Delivery","organizationId":1,"creatorUsername":"user1"`,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"message":"Invalid request body"}`,
+				answer: `{"reason":"Invalid request body"}`,
 			},
 		},
 		{
 			name:    "Test 'CreateTenderHandler' #3; Failed to validate request",
 			request: "/api/tenders/new",
 			method:  http.MethodPost,
-			body:    `{"name":"tender #1","description":"new","serviceType":"it","organizationId":1}`,
+			body:    `{"description":"new","serviceType":"Delivery","organizationId":1}`,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Key: 'Tender.CreatorUsername' Error:Field validation for 'CreatorUsername' failed on the 'required' tag"}`, // Ожидаемая ошибка валидации
+				answer: `{"reason":"Key: 'Tender.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`, // Ожидаемая ошибка валидации
 			},
 		},
 		{
-			name:    "Test 'CreateTenderHandler' #4; Failed to create tender",
+			name:    "Test 'CreateTenderHandler' #4; Creator differs from token owner",
 			request: "/api/tenders/new",
 			method:  http.MethodPost,
-			body:    `{"name":"tender #1","description":"new","serviceType":"it","organizationId":1,"creatorUsername":"user1"}`,
+			body:    `{"name":"tender #1","description":"new","serviceType":"Delivery","status":"Created","organizationId":"1","creatorUsername":"user2"}`,
+			err:     nil,
+			dbFlag:  false,
+			want: want{
+				code:   http.StatusForbidden,
+				answer: `{"reason":"creatorUsername does not match the token owner"}`,
+			},
+		},
+		{
+			name:    "Test 'CreateTenderHandler' #5; Failed to create tender",
+			request: "/api/tenders/new",
+			method:  http.MethodPost,
+			body:    `{"name":"tender #1","description":"new","serviceType":"Delivery","organizationId":1}`,
 			err:     errors.New("db error"),
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusInternalServerError,
-				answer: `{"message":"Failed to add tender","error":"db error"}`,
+				answer: `{"reason":"Internal server error"}`,
 			},
 		},
 	}
@@ -296,7 +345,7 @@ func TestCreateTenderHandler(t *testing.T) {
 						ID:              1,
 						Name:            "tender #1",
 						Description:     "new",
-						ServiceType:     "it",
+						ServiceType:     "Delivery",
 						Status:          "CREATED",
 						OrganizationID:  1,
 						CreatorUsername: "user1",
@@ -328,7 +377,8 @@ func TestSetTenderStatusHandler(t *testing.T) {
 		Valid: validator.New(),
 	}
 	r := gin.Default()
-	r.PATCH("/api/tenders/:id/status", srv.SetTenderStatusHandler)
+	r.Use(srv.ErrorMiddleware())
+	r.PUT("/api/tenders/:id/status", asUser("user1"), srv.SetTenderStatusHandler)
 	httpSrv := httptest.NewServer(r)
 	defer httpSrv.Close()
 	type want struct {
@@ -339,7 +389,7 @@ func TestSetTenderStatusHandler(t *testing.T) {
 		name    string
 		request string
 		method  string
-		body    string
+		status  string
 		err     error
 		dbFlag  bool
 		want    want
@@ -348,72 +398,101 @@ func TestSetTenderStatusHandler(t *testing.T) {
 		{
 			name:    "Test 'SetTenderStatusHandler' #1; Valid request",
 			request: "/api/tenders/1/status",
-			method:  http.MethodPatch,
-			body:    `{"status":"PUBLISHED"}`,
+			method:  http.MethodPut,
+			status:  "Published",
 			err:     nil,
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusOK,
-				answer: `{"message":"Tender status updated successfully"}`,
+				answer: `{"id":"1","name":"tender #1","description":"new","serviceType":"Delivery","status":"Published","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}`,
 			},
 		},
 		{
 			name:    "Test 'SetTenderStatusHandler' #2; Invalid tender ID",
 			request: "/api/tenders/abc/status",
-			method:  http.MethodPatch,
-			body:    `{"status":"PUBLISHED"}`,
+			method:  http.MethodPut,
+			status:  "Published",
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid tender ID"}`,
+				answer: `{"reason":"Invalid tender ID"}`,
 			},
 		},
 		{
-			name:    "Test 'SetTenderStatusHandler' #3; Invalid request body (missing required field)",
+			name:    "Test 'SetTenderStatusHandler' #3; Missing status",
 			request: "/api/tenders/1/status",
-			method:  http.MethodPatch,
-			body:    `{`,
+			method:  http.MethodPut,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid request body"}`,
+				answer: `{"reason":"Invalid status"}`,
 			},
 		},
 		{
 			name:    "Test 'SetTenderStatusHandler' #4; Invalid status",
 			request: "/api/tenders/1/status",
-			method:  http.MethodPatch,
-			body:    `{"status":"OPEN"}`,
+			method:  http.MethodPut,
+			status:  "Open",
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid status"}`,
+				answer: `{"reason":"Invalid status"}`,
 			},
 		},
 		{
-			name:    "Test 'SetTenderStatusHandler' #5; Failed to update tender status",
+			name:    "Test 'SetTenderStatusHandler' #5; User is not responsible for tender",
 			request: "/api/tenders/1/status",
-			method:  http.MethodPatch,
-			body:    `{"status":"PUBLISHED"}`,
+			method:  http.MethodPut,
+			status:  "Published",
+			err:     fmt.Errorf("%w: %s", authz.ErrForbidden, authz.ActionSetTenderStatus),
+			dbFlag:  true,
+			want: want{
+				code:   http.StatusForbidden,
+				answer: `{"reason":"forbidden: tender:set_status"}`,
+			},
+		},
+		{
+			name:    "Test 'SetTenderStatusHandler' #6; Failed to update tender status",
+			request: "/api/tenders/1/status",
+			method:  http.MethodPut,
+			status:  "Published",
 			err:     errors.New("db error"),
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusInternalServerError,
-				answer: `{"error":"db error"}`,
+				answer: `{"reason":"Internal server error"}`,
+			},
+		},
+		{
+			name:    "Test 'SetTenderStatusHandler' #7; Transition is not allowed",
+			request: "/api/tenders/1/status",
+			method:  http.MethodPut,
+			status:  "Published",
+			err:     apperr.Conflict("Cannot change tender status from Closed to Published"),
+			dbFlag:  true,
+			want: want{
+				code:   http.StatusConflict,
+				answer: `{"reason":"Cannot change tender status from Closed to Published"}`,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.dbFlag {
-				m.EXPECT().SetTenderStatus(gomock.Any(), "PUBLISHED").Return(tt.err)
+				var tender models.Tender
+				if tt.err == nil {
+					tender = models.Tender{ID: 1, Name: "tender #1", Description: "new", ServiceType: "Delivery", Status: models.PublishedT, OrganizationID: 1, CreatorUsername: "user1", Version: 1}
+				}
+				m.EXPECT().SetTenderStatus(1, "PUBLISHED", "user1", 0).Return(tender, tt.err)
 			}
 			req := resty.New().R()
+			if tt.status != "" {
+				req.SetQueryParam("status", tt.status)
+			}
 			req.Method = tt.method
-			req.Body = tt.body
 			req.URL = httpSrv.URL + tt.request
 			resp, err := req.Send()
 			assert.NoError(t, err)
@@ -434,81 +513,126 @@ func TestEditTenderHandler(t *testing.T) {
 		Valid: validator.New(),
 	}
 	r := gin.Default()
-	r.PATCH("/api/:id/tenders/", srv.EditTenderHandler)
+	r.Use(srv.ErrorMiddleware())
+	r.PATCH("/api/tenders/:id/edit", asUser("user1"), srv.EditTenderHandler)
 	httpSrv := httptest.NewServer(r)
 	defer httpSrv.Close()
 	type want struct {
 		code   int
 		answer string
+		etag   string
 	}
 	type test struct {
-		name    string
-		request string
-		method  string
-		body    string
-		err     error
-		dbFlag  bool
-		want    want
+		name     string
+		request  string
+		method   string
+		body     string
+		ifMatch  string
+		expected int
+		err      error
+		dbFlag   bool
+		want     want
 	}
 	tests := []test{
 		{
 			name:    "Test 'EditTenderHandler' #1; Valid request",
-			request: "/api/1/tenders/",
+			request: "/api/tenders/1/edit",
 			method:  http.MethodPatch,
 			body:    `{"name":"tender #1 updated","description":"updated"}`,
 			err:     nil,
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusOK,
-				answer: `{"id":1,"name":"tender #1 updated","description":"updated","serviceType":"it","status":"PUBLISHED","organizationId":1,"creatorUsername":"user1","version":2}`,
+				answer: `{"id":"1","name":"tender #1 updated","description":"updated","serviceType":"Delivery","status":"Published","organizationId":"1","version":2,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}`,
+				etag:   `"2"`,
 			},
 		},
 		{
 			name:    "Test 'EditTenderHandler' #2; Invalid tender ID",
-			request: "/api/abc/tenders/",
+			request: "/api/tenders/abc/edit",
 			method:  http.MethodPatch,
 			body:    `{"name":"tender #1 updated","description":"updated"}`,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid tender ID"}`,
+				answer: `{"reason":"Invalid tender ID"}`,
 			},
 		},
 		{
 			name:    "Test 'EditTenderHandler' #3; Failed validation",
-			request: "/api/1/tenders",
+			request: "/api/tenders/1/edit",
 			method:  http.MethodPatch,
-			body:    `{"description":"updated"`,
-			err:     errors.New("validation error"),
+			body:    `{"serviceType":"Cleaning"}`,
+			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid request body"}`,
+				answer: `{"reason":"Key: 'TenderUpdate.ServiceType' Error:Field validation for 'ServiceType' failed on the 'oneof' tag"}`,
 			},
 		},
 		{
 			name:    "Test 'EditTenderHandler' #4; Invalid request body (missing required field)",
-			request: "/api/1/tenders/",
+			request: "/api/tenders/1/edit",
 			method:  http.MethodPatch,
 			body:    `{`,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid request body"}`,
+				answer: `{"reason":"Invalid request body"}`,
 			},
 		},
 		{
 			name:    "Test 'EditTenderHandler' #5; Failed to update tender",
-			request: "/api/1/tenders/",
+			request: "/api/tenders/1/edit",
 			method:  http.MethodPatch,
 			body:    `{"name":"tender #1 updated","description":"updated"}`,
 			err:     errors.New("db error"),
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusInternalServerError,
-				answer: `{"error":"db error"}`,
+				answer: `{"reason":"Internal server error"}`,
+			},
+		},
+		{
+			name:     "Test 'EditTenderHandler' #6; If-Match matches current version",
+			request:  "/api/tenders/1/edit",
+			method:   http.MethodPatch,
+			body:     `{"name":"tender #1 updated","description":"updated"}`,
+			ifMatch:  `"1"`,
+			expected: 1,
+			dbFlag:   true,
+			want: want{
+				code:   http.StatusOK,
+				answer: `{"id":"1","name":"tender #1 updated","description":"updated","serviceType":"Delivery","status":"Published","organizationId":"1","version":2,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}`,
+				etag:   `"2"`,
+			},
+		},
+		{
+			name:     "Test 'EditTenderHandler' #7; Stale If-Match",
+			request:  "/api/tenders/1/edit",
+			method:   http.MethodPatch,
+			body:     `{"name":"tender #1 updated","description":"updated"}`,
+			ifMatch:  `"1"`,
+			expected: 1,
+			err:      apperr.PreconditionFailed("Tender 1 has version 2, expected 1"),
+			dbFlag:   true,
+			want: want{
+				code:   http.StatusPreconditionFailed,
+				answer: `{"reason":"Tender 1 has version 2, expected 1"}`,
+			},
+		},
+		{
+			name:    "Test 'EditTenderHandler' #8; Malformed If-Match",
+			request: "/api/tenders/1/edit",
+			method:  http.MethodPatch,
+			body:    `{"name":"tender #1 updated","description":"updated"}`,
+			ifMatch: `W/"1"`,
+			dbFlag:  false,
+			want: want{
+				code:   http.StatusBadRequest,
+				answer: `{"reason":"Invalid If-Match header"}`,
 			},
 		},
 	}
@@ -519,15 +643,18 @@ func TestEditTenderHandler(t *testing.T) {
 					ID:              1,
 					Name:            "tender #1 updated",
 					Description:     "updated",
-					ServiceType:     "it",
+					ServiceType:     "Delivery",
 					Status:          "PUBLISHED",
 					OrganizationID:  1,
 					CreatorUsername: "user1",
 					Version:         2,
 				}
-				m.EXPECT().EditTender(gomock.Any(), gomock.Any(), gomock.Any()).Return(tender, tt.err)
+				m.EXPECT().EditTender(1, gomock.Any(), "user1", tt.expected).Return(tender, tt.err)
 			}
 			req := resty.New().R()
+			if tt.ifMatch != "" {
+				req.SetHeader("If-Match", tt.ifMatch)
+			}
 			req.Method = tt.method
 			req.Body = tt.body
 			req.URL = httpSrv.URL + tt.request
@@ -535,6 +662,7 @@ func TestEditTenderHandler(t *testing.T) {
 			assert.NoError(t, err)
 			assert.Equal(t, tt.want.code, resp.StatusCode())
 			assert.JSONEq(t, tt.want.answer, string(resp.Body()))
+			assert.Equal(t, tt.want.etag, resp.Header().Get("ETag"))
 		})
 	}
 }
@@ -550,7 +678,8 @@ func TestRollbackTenderHandler(t *testing.T) {
 		Valid: validator.New(),
 	}
 	r := gin.Default()
-	r.PUT("/api/:tenderID/rollback/:version", srv.RollbackTenderHandler)
+	r.Use(srv.ErrorMiddleware())
+	r.PUT("/api/tenders/:id/rollback/:version", asUser("user1"), srv.RollbackTenderHandler)
 	httpSrv := httptest.NewServer(r)
 	defer httpSrv.Close()
 	type want struct {
@@ -568,46 +697,46 @@ func TestRollbackTenderHandler(t *testing.T) {
 	tests := []test{
 		{
 			name:    "Test 'RollbackTenderHandler' #1; Default call",
-			request: "/api/1/rollback/1",
+			request: "/api/tenders/1/rollback/1",
 			method:  http.MethodPut,
 			err:     nil,
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusOK,
-				answer: `{"id":1,"name":"tender #1 updated","description":"updated","serviceType":"it","status":"PUBLISHED","organizationId":1,"creatorUsername":"user1","version":1}`,
+				answer: `{"id":"1","name":"tender #1 updated","description":"updated","serviceType":"Delivery","status":"Published","organizationId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z","decisionPolicy":{"rule":"QUORUM","veto":true}}`,
 			},
 		},
 		{
 			name:    "Test 'RollbackTenderHandler' #2; Invalid tender ID",
-			request: "/api/abc/rollback/1",
+			request: "/api/tenders/abc/rollback/1",
 			method:  http.MethodPut,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid tender ID"}`,
+				answer: `{"reason":"Invalid tender ID"}`,
 			},
 		},
 		{
 			name:    "Test 'RollbackTenderHandler' #3; Invalid version",
-			request: "/api/1/rollback/abc",
+			request: "/api/tenders/1/rollback/abc",
 			method:  http.MethodPut,
 			err:     nil,
 			dbFlag:  false,
 			want: want{
 				code:   http.StatusBadRequest,
-				answer: `{"error":"Invalid version"}`,
+				answer: `{"reason":"Invalid version"}`,
 			},
 		},
 		{
 			name:    "Test 'RollbackTenderHandler' #4; Failed to rollback tender",
-			request: "/api/1/rollback/1",
+			request: "/api/tenders/1/rollback/1",
 			method:  http.MethodPut,
 			err:     errors.New("db error"),
 			dbFlag:  true,
 			want: want{
 				code:   http.StatusInternalServerError,
-				answer: `{"error":"db error"}`,
+				answer: `{"reason":"Internal server error"}`,
 			},
 		},
 	}
@@ -618,13 +747,13 @@ func TestRollbackTenderHandler(t *testing.T) {
 					ID:              1,
 					Name:            "tender #1 updated",
 					Description:     "updated",
-					ServiceType:     "it",
+					ServiceType:     "Delivery",
 					Status:          "PUBLISHED",
 					OrganizationID:  1,
 					CreatorUsername: "user1",
 					Version:         1,
 				}
-				m.EXPECT().RollbackTender(gomock.Any(), gomock.Any()).Return(tender, tt.err)
+				m.EXPECT().RollbackTender(gomock.Any(), gomock.Any(), "user1", 0).Return(tender, tt.err)
 			}
 			req := resty.New().R()
 			req.Method = tt.method
@@ -638,3 +767,197 @@ func TestRollbackTenderHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTenderVersionsHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/:id/versions", asUser("user1"), srv.GetTenderVersionsHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	versions := []models.Version{
+		{Version: 1, Fields: map[string]string{"name": "tender #1", "description": "new"}, ChangedBy: "user1"},
+		{Version: 2, Fields: map[string]string{"name": "tender #1", "description": "edited"}, ChangedBy: "user2"},
+	}
+	m.EXPECT().GetTenderVersions(1, "user1").Return(versions, nil)
+	m.EXPECT().GetTenderVersions(2, "user1").Return(nil, apperr.NotFound("Tender 2 not found"))
+
+	resp, err := resty.New().R().Get(httpSrv.URL + "/api/tenders/1/versions")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.JSONEq(t, `[
+		{"version":1,"fields":{"name":"tender #1","description":"new"},"changes":[
+			{"field":"description","from":"","to":"new"},{"field":"name","from":"","to":"tender #1"}
+		],"changedBy":"user1","createdAt":"0001-01-01T00:00:00Z"},
+		{"version":2,"fields":{"name":"tender #1","description":"edited"},"changes":[
+			{"field":"description","from":"new","to":"edited"}
+		],"changedBy":"user2","createdAt":"0001-01-01T00:00:00Z"}
+	]`, string(resp.Body()))
+
+	resp, err = resty.New().R().Get(httpSrv.URL + "/api/tenders/2/versions")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode())
+	assert.JSONEq(t, `{"reason":"Tender 2 not found"}`, string(resp.Body()))
+}
+
+func TestAwardTenderHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.POST("/api/tenders/:id/award", asUser("user1"), srv.AwardTenderHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	orgID := 1
+	summary := models.AwardSummary{
+		Tender: models.Tender{ID: 1, Status: models.ClosedT},
+		Award:  &models.Award{TenderID: 1, BidID: 2, Reason: "lowest price", AwardedBy: "user1"},
+		Candidates: []models.Candidate{
+			{
+				Bid:   models.Bid{ID: 1, Name: "bid #1", Status: models.DeclinedB, TenderID: 1, AuthorType: models.OrganizationAuthor, OrganizationID: &orgID, Version: 1},
+				Tally: models.Tally{Approvals: 3, Total: 3, Required: 3, Outcome: models.OutcomeApproved},
+			},
+			{
+				Bid:   models.Bid{ID: 2, Name: "bid #2", Status: models.SubmittedB, TenderID: 1, AuthorType: models.OrganizationAuthor, OrganizationID: &orgID, Version: 1},
+				Tally: models.Tally{Approvals: 3, Total: 3, Required: 3, Outcome: models.OutcomeApproved},
+			},
+		},
+	}
+	type test struct {
+		name   string
+		path   string
+		body   string
+		mock   func()
+		code   int
+		answer string
+	}
+	tests := []test{
+		{
+			name: "Valid request",
+			path: "/api/tenders/1/award",
+			body: `{"bidId":"2","reason":"lowest price"}`,
+			mock: func() {
+				m.EXPECT().AwardTender(1, 2, "lowest price", "user1").Return(summary, nil)
+			},
+			code: http.StatusOK,
+			answer: `{"tenderId":"1","status":"Closed",
+				"award":{"bidId":"2","reason":"lowest price","awardedBy":"user1","createdAt":"0001-01-01T00:00:00Z"},
+				"candidates":[
+					{"bid":{"id":"1","name":"bid #1","description":"","status":"Rejected","tenderId":"1","authorType":"Organization","authorId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z"},
+					 "summary":{"approvals":3,"rejections":0,"required":3,"remaining":0,"outcome":"Approved"}},
+					{"bid":{"id":"2","name":"bid #2","description":"","status":"Approved","tenderId":"1","authorType":"Organization","authorId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z"},
+					 "summary":{"approvals":3,"rejections":0,"required":3,"remaining":0,"outcome":"Approved"}}
+				]}`,
+		},
+		{
+			name:   "Missing reason",
+			path:   "/api/tenders/1/award",
+			body:   `{"bidId":"2"}`,
+			code:   http.StatusBadRequest,
+			answer: `{"reason":"Invalid award reason"}`,
+		},
+		{
+			name:   "Missing bid",
+			path:   "/api/tenders/1/award",
+			body:   `{"reason":"lowest price"}`,
+			code:   http.StatusBadRequest,
+			answer: `{"reason":"Invalid bid ID"}`,
+		},
+		{
+			name: "Bid is not approved",
+			path: "/api/tenders/1/award",
+			body: `{"bidId":3,"reason":"lowest price"}`,
+			mock: func() {
+				m.EXPECT().AwardTender(1, 3, "lowest price", "user1").Return(models.AwardSummary{}, apperr.Conflict("Bid 3 is not approved"))
+			},
+			code:   http.StatusConflict,
+			answer: `{"reason":"Bid 3 is not approved"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mock != nil {
+				tt.mock()
+			}
+			resp, err := resty.New().R().SetBody(tt.body).Post(httpSrv.URL + tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, resp.StatusCode())
+			assert.JSONEq(t, tt.answer, string(resp.Body()))
+		})
+	}
+}
+
+func TestGetTenderLeaderboardHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := mocks.NewMockRepository(ctrl)
+	srv := &Server{
+		Db:    m,
+		log:   zerolog.New(os.Stdout),
+		Valid: validator.New(),
+	}
+	r := gin.Default()
+	r.Use(srv.ErrorMiddleware())
+	r.GET("/api/tenders/:id/leaderboard", asUser("user1"), srv.GetTenderLeaderboardHandler)
+	httpSrv := httptest.NewServer(r)
+	defer httpSrv.Close()
+
+	orgID := 1
+	leaderboard := models.Leaderboard{
+		Criteria: []models.Criterion{
+			{ID: 1, TenderID: 1, Code: "price", Name: "Price", Weight: 2},
+			{ID: 2, TenderID: 1, Code: "quality", Name: "Quality", Weight: 1},
+		},
+		Standings: []models.Standing{
+			{
+				Bid:       models.Bid{ID: 2, Name: "bid #2", Status: models.PublishedB, TenderID: 1, AuthorType: models.OrganizationAuthor, OrganizationID: &orgID, Version: 1},
+				Averages:  map[string]float64{"price": 7.5, "quality": 5},
+				Reviewers: 2,
+				Total:     20.0 / 3,
+				Complete:  true,
+			},
+			{
+				Bid:      models.Bid{ID: 1, Name: "bid #1", Status: models.PublishedB, TenderID: 1, AuthorType: models.OrganizationAuthor, OrganizationID: &orgID, Version: 1},
+				Averages: map[string]float64{},
+			},
+		},
+	}
+	m.EXPECT().GetTenderLeaderboard(1, "user1").Return(leaderboard, nil)
+	m.EXPECT().GetTenderLeaderboard(2, "user1").Return(models.Leaderboard{}, apperr.Forbidden("Access denied"))
+
+	resp, err := resty.New().R().Get(httpSrv.URL + "/api/tenders/1/leaderboard")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.JSONEq(t, `{"tenderId":"1",
+		"criteria":[{"code":"price","name":"Price","weight":2},{"code":"quality","name":"Quality","weight":1}],
+		"standings":[
+			{"rank":1,
+			 "bid":{"id":"2","name":"bid #2","description":"","status":"Published","tenderId":"1","authorType":"Organization","authorId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z"},
+			 "scores":{"price":7.5,"quality":5},"total":6.67,"reviewers":2,"complete":true},
+			{"rank":2,
+			 "bid":{"id":"1","name":"bid #1","description":"","status":"Published","tenderId":"1","authorType":"Organization","authorId":"1","version":1,"createdAt":"0001-01-01T00:00:00Z"},
+			 "scores":{},"total":0,"reviewers":0,"complete":false}
+		]}`, string(resp.Body()))
+
+	resp, err = resty.New().R().Get(httpSrv.URL + "/api/tenders/2/leaderboard")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode())
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrNotFound - блоба с таким ключом нет в хранилище
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore хранит содержимое вложений. Ключ - SHA-256 содержимого в hex:
+// одинаковые файлы хранятся один раз, а записанный блоб не меняется.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// Checksum возвращает ключ блоба для содержимого r
+func Checksum(r io.Reader) (string, int64, error) {
+	h := sha256.New()
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+func validKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
+
+// Local хранит блобы в каталоге файловой системы: <dir>/<первые 2 символа ключа>/<ключ>
+type Local struct {
+	dir string
+}
+
+func NewLocal(dir string) (*Local, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+	return &Local{dir: dir}, nil
+}
+
+func (l *Local) path(key string) string {
+	return filepath.Join(l.dir, key[:2], key)
+}
+
+// Put записывает блоб через временный файл, чтобы читатели не видели его частично.
+// Содержимое сверяется с ключом; существующий блоб не перезаписывается.
+func (l *Local) Put(ctx context.Context, key string, r io.Reader) error {
+	if !validKey(key) {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	path := l.path(key)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create blob: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	sum, _, err := Checksum(io.TeeReader(r, tmp))
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if sum != key {
+		return fmt.Errorf("blob checksum mismatch: expected %s, got %s", key, sum)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save blob: %w", err)
+	}
+	return nil
+}
+
+func (l *Local) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	if !validKey(key) {
+		return nil, ErrNotFound
+	}
+	f, err := os.Open(l.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	key, size, err := Checksum(strings.NewReader("specification"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(13), size)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("specification")))
+	// Повторная запись того же содержимого ничего не меняет
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("specification")))
+
+	r, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, r.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "specification", string(data))
+
+	other, _, err := Checksum(strings.NewReader("other"))
+	require.NoError(t, err)
+	// Содержимое, не совпадающее с ключом, не сохраняется
+	assert.Error(t, store.Put(ctx, other, strings.NewReader("specification")))
+	_, err = store.Open(ctx, other)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.Error(t, store.Put(ctx, "../../etc/passwd", strings.NewReader("x")))
+	_, err = store.Open(ctx, "../../etc/passwd")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
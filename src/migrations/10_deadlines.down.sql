@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tender_closing_deadline_idx;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS decision_deadline;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS submission_deadline;
+ALTER TABLE tender DROP COLUMN IF EXISTS decision_deadline;
+ALTER TABLE tender DROP COLUMN IF EXISTS submission_deadline;
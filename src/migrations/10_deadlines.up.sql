@@ -0,0 +1,9 @@
+-- Сроки хранятся с часовым поясом: клиенты передают их в RFC3339 с любым смещением
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS decision_deadline TIMESTAMPTZ;
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS decision_deadline TIMESTAMPTZ;
+
+-- Планировщик ищет опубликованные тендеры с истекшим сроком
+CREATE INDEX IF NOT EXISTS tender_closing_deadline_idx ON tender ((COALESCE(decision_deadline, submission_deadline)))
+    WHERE status = 'PUBLISHED';
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS bid_tender_price_idx;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS items;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS delivery_days;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS price_currency;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS price_amount;
+ALTER TABLE bid DROP COLUMN IF EXISTS items;
+ALTER TABLE bid DROP COLUMN IF EXISTS delivery_days;
+ALTER TABLE bid DROP COLUMN IF EXISTS price_currency;
+ALTER TABLE bid DROP COLUMN IF EXISTS price_amount;
+ALTER TABLE tender DROP COLUMN IF EXISTS required_items;
+ALTER TABLE tender DROP COLUMN IF EXISTS budget_currency;
+ALTER TABLE tender DROP COLUMN IF EXISTS budget_amount;
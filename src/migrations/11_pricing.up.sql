@@ -0,0 +1,17 @@
+-- Суммы хранятся в минимальных единицах валюты, пустая валюта - сумма не задана
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS budget_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS budget_currency VARCHAR(3) NOT NULL DEFAULT '';
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS required_items JSONB;
+
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS price_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3) NOT NULL DEFAULT '';
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS delivery_days INT NOT NULL DEFAULT 0;
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS items JSONB;
+
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS price_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS price_currency VARCHAR(3) NOT NULL DEFAULT '';
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS delivery_days INT NOT NULL DEFAULT 0;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS items JSONB;
+
+-- Сравнение предложений тендера по цене
+CREATE INDEX IF NOT EXISTS bid_tender_price_idx ON bid (tender_id, price_amount, id);
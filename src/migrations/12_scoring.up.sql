@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS tender_criterion (
+    id SERIAL PRIMARY KEY,
+    tender_id INT NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    weight INT NOT NULL CHECK (weight > 0),
+    UNIQUE (tender_id, code)
+);
+
+-- Оценка ответственного по критерию; повторная оценка заменяет прежнюю
+CREATE TABLE IF NOT EXISTS bid_score (
+    id SERIAL PRIMARY KEY,
+    bid_id INT NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    criterion_id INT NOT NULL REFERENCES tender_criterion(id) ON DELETE CASCADE,
+    username VARCHAR(50) NOT NULL REFERENCES employee(username) ON DELETE CASCADE,
+    score INT NOT NULL CHECK (score BETWEEN 0 AND 10),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, criterion_id, username)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE bid_history DROP COLUMN IF EXISTS attachments;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS attachments;
+DROP TABLE IF EXISTS attachment;
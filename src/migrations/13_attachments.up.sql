@@ -0,0 +1,21 @@
+-- Описания вложений; содержимое хранится в BlobStore под ключом checksum (SHA-256)
+CREATE TABLE IF NOT EXISTS attachment (
+    id SERIAL PRIMARY KEY,
+    tender_id INT REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id INT REFERENCES bid(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    checksum CHAR(64) NOT NULL,
+    uploaded_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    -- Вложение принадлежит либо тендеру, либо предложению
+    CHECK ((tender_id IS NULL) <> (bid_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS attachment_tender_idx ON attachment (tender_id) WHERE tender_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS attachment_bid_idx ON attachment (bid_id) WHERE bid_id IS NOT NULL;
+
+-- Список вложений входит в снимок версии
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS attachments JSONB;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS attachments JSONB;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS organization_responsible_org_user_idx;
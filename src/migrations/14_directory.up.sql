@@ -0,0 +1,2 @@
+-- Сотрудник назначается ответственным за организацию один раз, повторное назначение меняет роль
+CREATE UNIQUE INDEX IF NOT EXISTS organization_responsible_org_user_idx ON organization_responsible (organization_id, user_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS bid_organization_idx;
+ALTER TABLE bid DROP CONSTRAINT IF EXISTS bid_author_organization_check;
+ALTER TABLE bid DROP CONSTRAINT IF EXISTS bid_author_type_check;
+ALTER TABLE bid DROP COLUMN IF EXISTS author_type;
@@ -0,0 +1,11 @@
+-- Тип автора предложения: пользователь или организация. Для предложений
+-- организации organization_id обязателен, для пользовательских - пуст.
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS author_type VARCHAR(20);
+UPDATE bid SET author_type = CASE WHEN organization_id IS NULL THEN 'User' ELSE 'Organization' END
+WHERE author_type IS NULL;
+ALTER TABLE bid ALTER COLUMN author_type SET DEFAULT 'User';
+ALTER TABLE bid ALTER COLUMN author_type SET NOT NULL;
+ALTER TABLE bid ADD CONSTRAINT bid_author_type_check CHECK (author_type IN ('User', 'Organization'));
+ALTER TABLE bid ADD CONSTRAINT bid_author_organization_check CHECK ((author_type = 'Organization') = (organization_id IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS bid_organization_idx ON bid (organization_id) WHERE organization_id IS NOT NULL;
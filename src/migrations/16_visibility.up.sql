@@ -0,0 +1,17 @@
+-- Видимость опубликованного тендера: всем, по приглашениям или только организации
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'PUBLIC'
+    CHECK (visibility IN ('PUBLIC', 'INVITE_ONLY', 'INTERNAL'));
+
+-- Приглашение организации или пользователя к тендеру
+CREATE TABLE IF NOT EXISTS tender_invitation (
+    id SERIAL PRIMARY KEY,
+    tender_id INT NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    organization_id INT REFERENCES organization(id) ON DELETE CASCADE,
+    username VARCHAR(50) REFERENCES employee(username) ON DELETE CASCADE,
+    invited_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((organization_id IS NULL) <> (username IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tender_invitation_organization_idx ON tender_invitation (tender_id, organization_id) WHERE organization_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS tender_invitation_user_idx ON tender_invitation (tender_id, username) WHERE username IS NOT NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_sealed_deadline_check;
+ALTER TABLE tender DROP COLUMN IF EXISTS sealed;
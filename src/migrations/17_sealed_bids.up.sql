@@ -0,0 +1,3 @@
+-- Закрытый прием предложений: содержимое раскрывается по окончании подачи
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS sealed BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE tender ADD CONSTRAINT tender_sealed_deadline_check CHECK (NOT sealed OR submission_deadline IS NOT NULL);
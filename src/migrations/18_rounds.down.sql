@@ -0,0 +1,7 @@
+ALTER TABLE bid_history DROP COLUMN IF EXISTS round;
+ALTER TABLE bid DROP COLUMN IF EXISTS round;
+DROP TABLE IF EXISTS tender_round;
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_round_deadline_check;
+ALTER TABLE tender DROP COLUMN IF EXISTS round_deadline;
+ALTER TABLE tender DROP COLUMN IF EXISTS round;
+DROP TABLE IF EXISTS tender_question;
@@ -0,0 +1,37 @@
+-- Вопросы участников к тендеру и ответы его ответственных
+CREATE TABLE IF NOT EXISTS tender_question (
+    id SERIAL PRIMARY KEY,
+    tender_id INT NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    question VARCHAR(1000) NOT NULL,
+    asked_by VARCHAR(50) NOT NULL,
+    answer VARCHAR(2000),
+    answered_by VARCHAR(50),
+    answered_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((answer IS NULL) = (answered_by IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS tender_question_tender_idx ON tender_question (tender_id);
+
+-- Раунды тендера: первый - прием предложений, следующие - пересмотр
+-- предложений из шорт-листа до round_deadline (best and final offer).
+-- Сроки хранятся с часовым поясом, как в 10_deadlines: планировщик сравнивает их
+-- с ними и не должен зависеть от TimeZone сессии
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS round INT NOT NULL DEFAULT 1;
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS round_deadline TIMESTAMPTZ;
+ALTER TABLE tender ADD CONSTRAINT tender_round_deadline_check CHECK ((round = 1) = (round_deadline IS NULL));
+
+CREATE TABLE IF NOT EXISTS tender_round (
+    id SERIAL PRIMARY KEY,
+    tender_id INT NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    number INT NOT NULL CHECK (number > 1),
+    deadline TIMESTAMPTZ NOT NULL,
+    opened_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tender_id, number)
+);
+
+-- Раунд последнего пересмотра предложения: предложение в шорт-листе раунда,
+-- если его round совпадает с раундом тендера
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS round INT NOT NULL DEFAULT 1;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS round INT NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS auction_offer;
+DROP TABLE IF EXISTS auction;
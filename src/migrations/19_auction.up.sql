@@ -0,0 +1,32 @@
+-- Аукцион на понижение цены по тендеру. Строка аукциона блокируется при каждой
+-- ставке (SELECT ... FOR UPDATE): ставки одного аукциона принимаются по очереди.
+-- Сроки хранятся с часовым поясом, как в 10_deadlines.
+CREATE TABLE IF NOT EXISTS auction (
+    tender_id INT PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    min_step_amount BIGINT NOT NULL CHECK (min_step_amount > 0),
+    min_step_currency VARCHAR(3) NOT NULL,
+    -- Продление: ставка позже чем за extend_within секунд до конца переносит конец на extend_by секунд от ставки
+    extend_within INT NOT NULL CHECK (extend_within >= 0),
+    extend_by INT NOT NULL CHECK (extend_by >= 0),
+    best_price_amount BIGINT NOT NULL DEFAULT 0,
+    best_price_currency VARCHAR(3) NOT NULL DEFAULT '',
+    best_bid_id INT REFERENCES bid(id) ON DELETE SET NULL,
+    offers INT NOT NULL DEFAULT 0,
+    opened_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+-- Принятые ставки аукциона
+CREATE TABLE IF NOT EXISTS auction_offer (
+    id SERIAL PRIMARY KEY,
+    tender_id INT NOT NULL REFERENCES auction(tender_id) ON DELETE CASCADE,
+    bid_id INT NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    submitted_by VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS auction_offer_tender_idx ON auction_offer (tender_id, id);
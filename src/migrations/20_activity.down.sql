@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS auction_activity ON auction;
+DROP TRIGGER IF EXISTS review_activity ON reviews;
+DROP TRIGGER IF EXISTS decision_activity ON bid_decisions;
+DROP TRIGGER IF EXISTS bid_activity ON bid;
+DROP TRIGGER IF EXISTS tender_activity ON tender;
+DROP FUNCTION IF EXISTS notify_auction_activity();
+DROP FUNCTION IF EXISTS notify_bid_child_activity();
+DROP FUNCTION IF EXISTS notify_bid_activity();
+DROP FUNCTION IF EXISTS notify_tender_activity();
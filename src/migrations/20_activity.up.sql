@@ -0,0 +1,66 @@
+-- События активности для потоков server-sent events. Каждая реплика слушает канал
+-- activity (LISTEN) и раздает события своим подписчикам. NOTIFY доставляется
+-- только после фиксации транзакции, поэтому откаченные изменения событий не дают.
+CREATE OR REPLACE FUNCTION notify_tender_activity() RETURNS trigger AS $$
+BEGIN
+    IF NEW.status IS DISTINCT FROM OLD.status THEN
+        PERFORM pg_notify('activity', json_build_object('type', 'TenderStatusChanged', 'tenderId', NEW.id, 'status', NEW.status, 'version', NEW.version)::text);
+    ELSIF NEW.version IS DISTINCT FROM OLD.version THEN
+        PERFORM pg_notify('activity', json_build_object('type', 'TenderEdited', 'tenderId', NEW.id, 'version', NEW.version)::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_bid_activity() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM pg_notify('activity', json_build_object('type', 'BidCreated', 'tenderId', NEW.tender_id, 'bidId', NEW.id, 'status', NEW.status, 'version', NEW.version)::text);
+    ELSIF NEW.status IS DISTINCT FROM OLD.status THEN
+        PERFORM pg_notify('activity', json_build_object('type', 'BidStatusChanged', 'tenderId', NEW.tender_id, 'bidId', NEW.id, 'status', NEW.status, 'version', NEW.version)::text);
+    ELSIF NEW.version IS DISTINCT FROM OLD.version THEN
+        PERFORM pg_notify('activity', json_build_object('type', 'BidEdited', 'tenderId', NEW.tender_id, 'bidId', NEW.id, 'version', NEW.version)::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Голоса и отзывы ссылаются на предложение, тендер берется из него
+CREATE OR REPLACE FUNCTION notify_bid_child_activity() RETURNS trigger AS $$
+DECLARE
+    changed_bid INT;
+    event_type TEXT := TG_ARGV[0];
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_bid := OLD.bid_id;
+    ELSE
+        changed_bid := NEW.bid_id;
+    END IF;
+    PERFORM pg_notify('activity', json_build_object('type', event_type, 'tenderId', bid.tender_id, 'bidId', bid.id)::text)
+    FROM bid WHERE bid.id = changed_bid;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION notify_auction_activity() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('activity', json_build_object('type', 'AuctionUpdated', 'tenderId', NEW.tender_id)::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tender_activity ON tender;
+CREATE TRIGGER tender_activity AFTER UPDATE ON tender
+    FOR EACH ROW EXECUTE FUNCTION notify_tender_activity();
+DROP TRIGGER IF EXISTS bid_activity ON bid;
+CREATE TRIGGER bid_activity AFTER INSERT OR UPDATE ON bid
+    FOR EACH ROW EXECUTE FUNCTION notify_bid_activity();
+DROP TRIGGER IF EXISTS decision_activity ON bid_decisions;
+CREATE TRIGGER decision_activity AFTER INSERT OR UPDATE OR DELETE ON bid_decisions
+    FOR EACH ROW EXECUTE FUNCTION notify_bid_child_activity('DecisionChanged');
+DROP TRIGGER IF EXISTS review_activity ON reviews;
+CREATE TRIGGER review_activity AFTER INSERT ON reviews
+    FOR EACH ROW EXECUTE FUNCTION notify_bid_child_activity('ReviewAdded');
+DROP TRIGGER IF EXISTS auction_activity ON auction;
+CREATE TRIGGER auction_activity AFTER INSERT OR UPDATE ON auction
+    FOR EACH ROW EXECUTE FUNCTION notify_auction_activity();
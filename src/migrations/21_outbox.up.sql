@@ -0,0 +1,20 @@
+-- Transactional outbox: доменные события пишутся в той же транзакции, что и
+-- изменение, а relay доставляет их во внешние системы не менее одного раза.
+-- Время доставки считается по часам базы: реплики не зависят от своих часов.
+CREATE TABLE IF NOT EXISTS outbox (
+    id SERIAL PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    aggregate VARCHAR(20) NOT NULL,
+    aggregate_id INT NOT NULL,
+    -- Пустой автор - изменение выполнил планировщик
+    actor VARCHAR(50) NOT NULL DEFAULT '',
+    data JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP,
+    last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending ON outbox (next_attempt_at, id) WHERE delivered_at IS NULL;
+CREATE INDEX IF NOT EXISTS outbox_delivered ON outbox (delivered_at) WHERE delivered_at IS NOT NULL;
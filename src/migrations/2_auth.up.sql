@@ -0,0 +1,8 @@
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS password_hash VARCHAR(100);
+
+-- Тестовые пользователи из 1_init получают пароль "password". Остальные
+-- сотрудники остаются без пароля и не могут войти, пока он не задан.
+UPDATE employee
+SET password_hash = '$2a$10$eq7cllAQ6II8sFcyZGpRBuKSZa8JU3T4sO9QhJ3tYb95EWIr5ilXy'
+WHERE password_hash IS NULL
+  AND username IN ('user1', 'user2', 'user3', 'user4', 'user5', 'user6', 'simpleUser1', 'simpleUser2');
@@ -0,0 +1 @@
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,3 @@
+ALTER TABLE reviews DROP COLUMN IF EXISTS created_at;
+ALTER TABLE bid DROP COLUMN IF EXISTS created_at;
+ALTER TABLE tender DROP COLUMN IF EXISTS created_at;
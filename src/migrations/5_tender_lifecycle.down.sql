@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS tender_transition;
+
+UPDATE tender SET status = 'CLOSED' WHERE status IN ('CANCELLED', 'ARCHIVED');
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_status_check;
+ALTER TABLE tender ADD CONSTRAINT tender_status_check
+    CHECK (status IN ('CREATED', 'PUBLISHED', 'CLOSED'));
@@ -0,0 +1,15 @@
+ALTER TABLE tender DROP CONSTRAINT IF EXISTS tender_status_check;
+ALTER TABLE tender ADD CONSTRAINT tender_status_check
+    CHECK (status IN ('CREATED', 'PUBLISHED', 'CLOSED', 'CANCELLED', 'ARCHIVED'));
+
+CREATE TABLE IF NOT EXISTS tender_transition (
+    id SERIAL PRIMARY KEY,
+    tender_id INT NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    from_status VARCHAR(50),
+    to_status VARCHAR(50) NOT NULL,
+    username VARCHAR(50) REFERENCES employee(username) ON DELETE SET NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS tender_transition_tender_id_idx ON tender_transition (tender_id, id);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS bid_history_version_idx;
+DROP INDEX IF EXISTS tender_history_version_idx;
+
+-- Снимки текущих версий в старой схеме не хранились
+DELETE FROM bid_history h USING bid b WHERE h.bid_id = b.id AND h.version = b.version;
+DELETE FROM tender_history h USING tender t WHERE h.tender_id = t.id AND h.version = t.version;
+
+ALTER TABLE bid_history DROP COLUMN IF EXISTS created_at;
+ALTER TABLE bid_history DROP COLUMN IF EXISTS changed_by;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS created_at;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS changed_by;
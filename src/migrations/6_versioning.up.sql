@@ -0,0 +1,36 @@
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS changed_by VARCHAR(50);
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS changed_by VARCHAR(50);
+ALTER TABLE bid_history ADD COLUMN IF NOT EXISTS created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+-- Раньше откат тендера возвращал текущей записи старый номер версии, и в
+-- истории появлялись дубли номеров. Снимки не удаляются: история нумеруется
+-- заново в порядке записи.
+UPDATE tender_history h SET version = n.version
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY tender_id ORDER BY id) AS version FROM tender_history) n
+WHERE n.id = h.id AND n.version <> h.version;
+
+UPDATE bid_history h SET version = n.version
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY bid_id ORDER BY id) AS version FROM bid_history) n
+WHERE n.id = h.id AND n.version <> h.version;
+
+-- Текущая запись получает номер после последнего снимка
+UPDATE tender t SET version = h.max_version + 1
+FROM (SELECT tender_id, MAX(version) AS max_version FROM tender_history GROUP BY tender_id) h
+WHERE h.tender_id = t.id AND h.max_version >= t.version;
+
+UPDATE bid b SET version = h.max_version + 1
+FROM (SELECT bid_id, MAX(version) AS max_version FROM bid_history GROUP BY bid_id) h
+WHERE h.bid_id = b.id AND h.max_version >= b.version;
+
+-- История теперь хранит и текущую версию
+INSERT INTO tender_history (tender_id, name, description, service_type, status, organization_id, creator_username, version, changed_by)
+SELECT id, name, description, service_type, status, organization_id, creator_username, version, creator_username
+FROM tender;
+
+INSERT INTO bid_history (bid_id, name, description, status, tender_id, organization_id, creator_username, version, changed_by)
+SELECT id, name, description, status, tender_id, organization_id, creator_username, version, creator_username
+FROM bid;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tender_history_version_idx ON tender_history (tender_id, version);
+CREATE UNIQUE INDEX IF NOT EXISTS bid_history_version_idx ON bid_history (bid_id, version);
@@ -0,0 +1,4 @@
+ALTER TABLE organization_responsible DROP COLUMN IF EXISTS role;
+ALTER TABLE organization DROP COLUMN IF EXISTS decision_policy;
+ALTER TABLE tender_history DROP COLUMN IF EXISTS decision_policy;
+ALTER TABLE tender DROP COLUMN IF EXISTS decision_policy;
@@ -0,0 +1,4 @@
+ALTER TABLE tender ADD COLUMN IF NOT EXISTS decision_policy JSONB;
+ALTER TABLE tender_history ADD COLUMN IF NOT EXISTS decision_policy JSONB;
+ALTER TABLE organization ADD COLUMN IF NOT EXISTS decision_policy JSONB;
+ALTER TABLE organization_responsible ADD COLUMN IF NOT EXISTS role VARCHAR(50) NOT NULL DEFAULT 'member';
@@ -0,0 +1 @@
+ALTER TABLE bid_decisions DROP CONSTRAINT IF EXISTS bid_decisions_bid_id_username_key;
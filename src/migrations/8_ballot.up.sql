@@ -0,0 +1,5 @@
+-- Раньше один пользователь мог голосовать несколько раз: остается последний голос
+DELETE FROM bid_decisions a USING bid_decisions b
+WHERE a.bid_id = b.bid_id AND a.username = b.username AND a.id < b.id;
+
+ALTER TABLE bid_decisions ADD CONSTRAINT bid_decisions_bid_id_username_key UNIQUE (bid_id, username);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS tender_award (
+    tender_id INT PRIMARY KEY REFERENCES tender(id) ON DELETE CASCADE,
+    bid_id INT NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    awarded_by VARCHAR(50) REFERENCES employee(username) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
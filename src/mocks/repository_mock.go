@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	models "git.codenrock.com/avito-testirovanie-na-backend-1270/cnrprod1725726028-team-79521/zadanie-6105/src/internal/models"
@@ -34,6 +35,51 @@ func (m *MockTendersRepo) EXPECT() *MockTendersRepoMockRecorder {
 	return m.recorder
 }
 
+// AnswerQuestion mocks base method.
+func (m *MockTendersRepo) AnswerQuestion(arg0, arg1 int, arg2, arg3 string) (models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerQuestion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnswerQuestion indicates an expected call of AnswerQuestion.
+func (mr *MockTendersRepoMockRecorder) AnswerQuestion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerQuestion", reflect.TypeOf((*MockTendersRepo)(nil).AnswerQuestion), arg0, arg1, arg2, arg3)
+}
+
+// AskQuestion mocks base method.
+func (m *MockTendersRepo) AskQuestion(arg0 int, arg1, arg2 string) (models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AskQuestion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AskQuestion indicates an expected call of AskQuestion.
+func (mr *MockTendersRepoMockRecorder) AskQuestion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AskQuestion", reflect.TypeOf((*MockTendersRepo)(nil).AskQuestion), arg0, arg1, arg2)
+}
+
+// AwardTender mocks base method.
+func (m *MockTendersRepo) AwardTender(arg0, arg1 int, arg2, arg3 string) (models.AwardSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardTender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.AwardSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardTender indicates an expected call of AwardTender.
+func (mr *MockTendersRepoMockRecorder) AwardTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardTender", reflect.TypeOf((*MockTendersRepo)(nil).AwardTender), arg0, arg1, arg2, arg3)
+}
+
 // CreateTender mocks base method.
 func (m *MockTendersRepo) CreateTender(arg0 models.Tender) (models.Tender, error) {
 	m.ctrl.T.Helper()
@@ -43,618 +89,1907 @@ func (m *MockTendersRepo) CreateTender(arg0 models.Tender) (models.Tender, error
 	return ret0, ret1
 }
 
-// CreateTender indicates an expected call of CreateTender.
-func (mr *MockTendersRepoMockRecorder) CreateTender(arg0 interface{}) *gomock.Call {
+// CreateTender indicates an expected call of CreateTender.
+func (mr *MockTendersRepoMockRecorder) CreateTender(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTender", reflect.TypeOf((*MockTendersRepo)(nil).CreateTender), arg0)
+}
+
+// EditTender mocks base method.
+func (m *MockTendersRepo) EditTender(arg0 int, arg1 models.TenderUpdate, arg2 string, arg3 int) (models.Tender, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditTender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditTender indicates an expected call of EditTender.
+func (mr *MockTendersRepoMockRecorder) EditTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTender", reflect.TypeOf((*MockTendersRepo)(nil).EditTender), arg0, arg1, arg2, arg3)
+}
+
+// GetAllTenders mocks base method.
+func (m *MockTendersRepo) GetAllTenders(arg0 string, arg1 models.ListParams) ([]models.Tender, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllTenders", arg0, arg1)
+	ret0, _ := ret[0].([]models.Tender)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAllTenders indicates an expected call of GetAllTenders.
+func (mr *MockTendersRepoMockRecorder) GetAllTenders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenders", reflect.TypeOf((*MockTendersRepo)(nil).GetAllTenders), arg0, arg1)
+}
+
+// GetAuction mocks base method.
+func (m *MockTendersRepo) GetAuction(arg0 int, arg1 string) (models.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", arg0, arg1)
+	ret0, _ := ret[0].(models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockTendersRepoMockRecorder) GetAuction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*MockTendersRepo)(nil).GetAuction), arg0, arg1)
+}
+
+// GetInvitations mocks base method.
+func (m *MockTendersRepo) GetInvitations(arg0 int, arg1 string) ([]models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockTendersRepoMockRecorder) GetInvitations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockTendersRepo)(nil).GetInvitations), arg0, arg1)
+}
+
+// GetQuestions mocks base method.
+func (m *MockTendersRepo) GetQuestions(arg0 int, arg1 string) ([]models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestions indicates an expected call of GetQuestions.
+func (mr *MockTendersRepoMockRecorder) GetQuestions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestions", reflect.TypeOf((*MockTendersRepo)(nil).GetQuestions), arg0, arg1)
+}
+
+// GetRounds mocks base method.
+func (m *MockTendersRepo) GetRounds(arg0 int, arg1 string) ([]models.Round, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRounds", arg0, arg1)
+	ret0, _ := ret[0].([]models.Round)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRounds indicates an expected call of GetRounds.
+func (mr *MockTendersRepoMockRecorder) GetRounds(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRounds", reflect.TypeOf((*MockTendersRepo)(nil).GetRounds), arg0, arg1)
+}
+
+// GetTenderAward mocks base method.
+func (m *MockTendersRepo) GetTenderAward(arg0 int, arg1 string) (models.AwardSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderAward", arg0, arg1)
+	ret0, _ := ret[0].(models.AwardSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenderAward indicates an expected call of GetTenderAward.
+func (mr *MockTendersRepoMockRecorder) GetTenderAward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderAward", reflect.TypeOf((*MockTendersRepo)(nil).GetTenderAward), arg0, arg1)
+}
+
+// GetTenderLeaderboard mocks base method.
+func (m *MockTendersRepo) GetTenderLeaderboard(arg0 int, arg1 string) (models.Leaderboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderLeaderboard", arg0, arg1)
+	ret0, _ := ret[0].(models.Leaderboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenderLeaderboard indicates an expected call of GetTenderLeaderboard.
+func (mr *MockTendersRepoMockRecorder) GetTenderLeaderboard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderLeaderboard", reflect.TypeOf((*MockTendersRepo)(nil).GetTenderLeaderboard), arg0, arg1)
+}
+
+// GetTenderStatus mocks base method.
+func (m *MockTendersRepo) GetTenderStatus(arg0 int, arg1 string) (models.TenderStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderStatus", arg0, arg1)
+	ret0, _ := ret[0].(models.TenderStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenderStatus indicates an expected call of GetTenderStatus.
+func (mr *MockTendersRepoMockRecorder) GetTenderStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderStatus", reflect.TypeOf((*MockTendersRepo)(nil).GetTenderStatus), arg0, arg1)
+}
+
+// GetTenderTransitions mocks base method.
+func (m *MockTendersRepo) GetTenderTransitions(arg0 int, arg1 string) ([]models.TenderTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderTransitions", arg0, arg1)
+	ret0, _ := ret[0].([]models.TenderTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenderTransitions indicates an expected call of GetTenderTransitions.
+func (mr *MockTendersRepoMockRecorder) GetTenderTransitions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderTransitions", reflect.TypeOf((*MockTendersRepo)(nil).GetTenderTransitions), arg0, arg1)
+}
+
+// GetTenderVersions mocks base method.
+func (m *MockTendersRepo) GetTenderVersions(arg0 int, arg1 string) ([]models.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderVersions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTenderVersions indicates an expected call of GetTenderVersions.
+func (mr *MockTendersRepoMockRecorder) GetTenderVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderVersions", reflect.TypeOf((*MockTendersRepo)(nil).GetTenderVersions), arg0, arg1)
+}
+
+// GetTendersByUser mocks base method.
+func (m *MockTendersRepo) GetTendersByUser(arg0 string, arg1 models.ListParams) ([]models.Tender, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTendersByUser", arg0, arg1)
+	ret0, _ := ret[0].([]models.Tender)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTendersByUser indicates an expected call of GetTendersByUser.
+func (mr *MockTendersRepoMockRecorder) GetTendersByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTendersByUser", reflect.TypeOf((*MockTendersRepo)(nil).GetTendersByUser), arg0, arg1)
+}
+
+// Invite mocks base method.
+func (m *MockTendersRepo) Invite(arg0 int, arg1 models.Invitation, arg2 string) (models.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockTendersRepoMockRecorder) Invite(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockTendersRepo)(nil).Invite), arg0, arg1, arg2)
+}
+
+// OpenAuction mocks base method.
+func (m *MockTendersRepo) OpenAuction(arg0 int, arg1 models.AuctionSettings, arg2 string) (models.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenAuction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenAuction indicates an expected call of OpenAuction.
+func (mr *MockTendersRepoMockRecorder) OpenAuction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAuction", reflect.TypeOf((*MockTendersRepo)(nil).OpenAuction), arg0, arg1, arg2)
+}
+
+// OpenRound mocks base method.
+func (m *MockTendersRepo) OpenRound(arg0 int, arg1 models.RoundRequest, arg2 string) (models.Round, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenRound", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Round)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenRound indicates an expected call of OpenRound.
+func (mr *MockTendersRepoMockRecorder) OpenRound(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenRound", reflect.TypeOf((*MockTendersRepo)(nil).OpenRound), arg0, arg1, arg2)
+}
+
+// RevokeInvitation mocks base method.
+func (m *MockTendersRepo) RevokeInvitation(arg0, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeInvitation", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockTendersRepoMockRecorder) RevokeInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockTendersRepo)(nil).RevokeInvitation), arg0, arg1, arg2)
+}
+
+// RollbackTender mocks base method.
+func (m *MockTendersRepo) RollbackTender(arg0, arg1 int, arg2 string, arg3 int) (models.Tender, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackTender indicates an expected call of RollbackTender.
+func (mr *MockTendersRepoMockRecorder) RollbackTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTender", reflect.TypeOf((*MockTendersRepo)(nil).RollbackTender), arg0, arg1, arg2, arg3)
+}
+
+// SetTenderCriteria mocks base method.
+func (m *MockTendersRepo) SetTenderCriteria(arg0 int, arg1 []models.Criterion, arg2 string) ([]models.Criterion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTenderCriteria", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Criterion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTenderCriteria indicates an expected call of SetTenderCriteria.
+func (mr *MockTendersRepoMockRecorder) SetTenderCriteria(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderCriteria", reflect.TypeOf((*MockTendersRepo)(nil).SetTenderCriteria), arg0, arg1, arg2)
+}
+
+// SetTenderDecisionPolicy mocks base method.
+func (m *MockTendersRepo) SetTenderDecisionPolicy(arg0 int, arg1 models.DecisionPolicy, arg2 string, arg3 int) (models.Tender, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTenderDecisionPolicy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTenderDecisionPolicy indicates an expected call of SetTenderDecisionPolicy.
+func (mr *MockTendersRepoMockRecorder) SetTenderDecisionPolicy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderDecisionPolicy", reflect.TypeOf((*MockTendersRepo)(nil).SetTenderDecisionPolicy), arg0, arg1, arg2, arg3)
+}
+
+// SetTenderStatus mocks base method.
+func (m *MockTendersRepo) SetTenderStatus(arg0 int, arg1, arg2 string, arg3 int) (models.Tender, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTenderStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTenderStatus indicates an expected call of SetTenderStatus.
+func (mr *MockTendersRepoMockRecorder) SetTenderStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderStatus", reflect.TypeOf((*MockTendersRepo)(nil).SetTenderStatus), arg0, arg1, arg2, arg3)
+}
+
+// SetTenderVisibility mocks base method.
+func (m *MockTendersRepo) SetTenderVisibility(arg0 int, arg1 models.TenderVisibility, arg2 string, arg3 int) (models.Tender, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTenderVisibility", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTenderVisibility indicates an expected call of SetTenderVisibility.
+func (mr *MockTendersRepoMockRecorder) SetTenderVisibility(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderVisibility", reflect.TypeOf((*MockTendersRepo)(nil).SetTenderVisibility), arg0, arg1, arg2, arg3)
+}
+
+// MockBidsRepo is a mock of BidsRepo interface.
+type MockBidsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBidsRepoMockRecorder
+}
+
+// MockBidsRepoMockRecorder is the mock recorder for MockBidsRepo.
+type MockBidsRepoMockRecorder struct {
+	mock *MockBidsRepo
+}
+
+// NewMockBidsRepo creates a new mock instance.
+func NewMockBidsRepo(ctrl *gomock.Controller) *MockBidsRepo {
+	mock := &MockBidsRepo{ctrl: ctrl}
+	mock.recorder = &MockBidsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBidsRepo) EXPECT() *MockBidsRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateBid mocks base method.
+func (m *MockBidsRepo) CreateBid(arg0 models.Bid, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBid", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBid indicates an expected call of CreateBid.
+func (mr *MockBidsRepoMockRecorder) CreateBid(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBid", reflect.TypeOf((*MockBidsRepo)(nil).CreateBid), arg0, arg1)
+}
+
+// DeclineDecision mocks base method.
+func (m *MockBidsRepo) DeclineDecision(arg0 int, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineDecision", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineDecision indicates an expected call of DeclineDecision.
+func (mr *MockBidsRepoMockRecorder) DeclineDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineDecision", reflect.TypeOf((*MockBidsRepo)(nil).DeclineDecision), arg0, arg1)
+}
+
+// EditBid mocks base method.
+func (m *MockBidsRepo) EditBid(arg0 int, arg1 models.BidUpdate, arg2 string, arg3 int) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditBid", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditBid indicates an expected call of EditBid.
+func (mr *MockBidsRepoMockRecorder) EditBid(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditBid", reflect.TypeOf((*MockBidsRepo)(nil).EditBid), arg0, arg1, arg2, arg3)
+}
+
+// GetBidDecisions mocks base method.
+func (m *MockBidsRepo) GetBidDecisions(arg0 int, arg1 string) (models.Ballot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidDecisions", arg0, arg1)
+	ret0, _ := ret[0].(models.Ballot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBidDecisions indicates an expected call of GetBidDecisions.
+func (mr *MockBidsRepoMockRecorder) GetBidDecisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidDecisions", reflect.TypeOf((*MockBidsRepo)(nil).GetBidDecisions), arg0, arg1)
+}
+
+// GetBidStatus mocks base method.
+func (m *MockBidsRepo) GetBidStatus(arg0 int, arg1 string) (models.BidStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidStatus", arg0, arg1)
+	ret0, _ := ret[0].(models.BidStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBidStatus indicates an expected call of GetBidStatus.
+func (mr *MockBidsRepoMockRecorder) GetBidStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidStatus", reflect.TypeOf((*MockBidsRepo)(nil).GetBidStatus), arg0, arg1)
+}
+
+// GetBidVersions mocks base method.
+func (m *MockBidsRepo) GetBidVersions(arg0 int, arg1 string) ([]models.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidVersions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBidVersions indicates an expected call of GetBidVersions.
+func (mr *MockBidsRepoMockRecorder) GetBidVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidVersions", reflect.TypeOf((*MockBidsRepo)(nil).GetBidVersions), arg0, arg1)
+}
+
+// GetBidsByUser mocks base method.
+func (m *MockBidsRepo) GetBidsByUser(arg0 string, arg1 models.ListParams) ([]models.Bid, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]models.Bid)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBidsByUser indicates an expected call of GetBidsByUser.
+func (mr *MockBidsRepoMockRecorder) GetBidsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsByUser", reflect.TypeOf((*MockBidsRepo)(nil).GetBidsByUser), arg0, arg1)
+}
+
+// GetBidsForTender mocks base method.
+func (m *MockBidsRepo) GetBidsForTender(arg0 int, arg1 string, arg2 models.ListParams) ([]models.Bid, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidsForTender", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Bid)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBidsForTender indicates an expected call of GetBidsForTender.
+func (mr *MockBidsRepoMockRecorder) GetBidsForTender(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsForTender", reflect.TypeOf((*MockBidsRepo)(nil).GetBidsForTender), arg0, arg1, arg2)
+}
+
+// RetractDecision mocks base method.
+func (m *MockBidsRepo) RetractDecision(arg0 int, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetractDecision", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetractDecision indicates an expected call of RetractDecision.
+func (mr *MockBidsRepoMockRecorder) RetractDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractDecision", reflect.TypeOf((*MockBidsRepo)(nil).RetractDecision), arg0, arg1)
+}
+
+// RollbackBid mocks base method.
+func (m *MockBidsRepo) RollbackBid(arg0, arg1 int, arg2 string, arg3 int) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackBid", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackBid indicates an expected call of RollbackBid.
+func (mr *MockBidsRepoMockRecorder) RollbackBid(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackBid", reflect.TypeOf((*MockBidsRepo)(nil).RollbackBid), arg0, arg1, arg2, arg3)
+}
+
+// ScoreBid mocks base method.
+func (m *MockBidsRepo) ScoreBid(arg0 int, arg1 map[string]int, arg2 string) ([]models.Score, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScoreBid", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Score)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScoreBid indicates an expected call of ScoreBid.
+func (mr *MockBidsRepoMockRecorder) ScoreBid(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreBid", reflect.TypeOf((*MockBidsRepo)(nil).ScoreBid), arg0, arg1, arg2)
+}
+
+// SetBidStatus mocks base method.
+func (m *MockBidsRepo) SetBidStatus(arg0 int, arg1, arg2 string, arg3 int) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBidStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBidStatus indicates an expected call of SetBidStatus.
+func (mr *MockBidsRepoMockRecorder) SetBidStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBidStatus", reflect.TypeOf((*MockBidsRepo)(nil).SetBidStatus), arg0, arg1, arg2, arg3)
+}
+
+// SubmitAuctionPrice mocks base method.
+func (m *MockBidsRepo) SubmitAuctionPrice(arg0 int, arg1 models.Money, arg2 string) (models.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAuctionPrice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitAuctionPrice indicates an expected call of SubmitAuctionPrice.
+func (mr *MockBidsRepoMockRecorder) SubmitAuctionPrice(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAuctionPrice", reflect.TypeOf((*MockBidsRepo)(nil).SubmitAuctionPrice), arg0, arg1, arg2)
+}
+
+// SubmitDecision mocks base method.
+func (m *MockBidsRepo) SubmitDecision(arg0 int, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitDecision", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitDecision indicates an expected call of SubmitDecision.
+func (mr *MockBidsRepoMockRecorder) SubmitDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitDecision", reflect.TypeOf((*MockBidsRepo)(nil).SubmitDecision), arg0, arg1)
+}
+
+// MockFeedbackReview is a mock of FeedbackReview interface.
+type MockFeedbackReview struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedbackReviewMockRecorder
+}
+
+// MockFeedbackReviewMockRecorder is the mock recorder for MockFeedbackReview.
+type MockFeedbackReviewMockRecorder struct {
+	mock *MockFeedbackReview
+}
+
+// NewMockFeedbackReview creates a new mock instance.
+func NewMockFeedbackReview(ctrl *gomock.Controller) *MockFeedbackReview {
+	mock := &MockFeedbackReview{ctrl: ctrl}
+	mock.recorder = &MockFeedbackReviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedbackReview) EXPECT() *MockFeedbackReviewMockRecorder {
+	return m.recorder
+}
+
+// AddFeedback mocks base method.
+func (m *MockFeedbackReview) AddFeedback(arg0 models.Review, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFeedback", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFeedback indicates an expected call of AddFeedback.
+func (mr *MockFeedbackReviewMockRecorder) AddFeedback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFeedback", reflect.TypeOf((*MockFeedbackReview)(nil).AddFeedback), arg0, arg1)
+}
+
+// GetReviewsByAuthorAndTender mocks base method.
+func (m *MockFeedbackReview) GetReviewsByAuthorAndTender(arg0 int, arg1, arg2 string, arg3 models.ListParams) ([]models.Review, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByAuthorAndTender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReviewsByAuthorAndTender indicates an expected call of GetReviewsByAuthorAndTender.
+func (mr *MockFeedbackReviewMockRecorder) GetReviewsByAuthorAndTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByAuthorAndTender", reflect.TypeOf((*MockFeedbackReview)(nil).GetReviewsByAuthorAndTender), arg0, arg1, arg2, arg3)
+}
+
+// MockEmployeeRepo is a mock of EmployeeRepo interface.
+type MockEmployeeRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmployeeRepoMockRecorder
+}
+
+// MockEmployeeRepoMockRecorder is the mock recorder for MockEmployeeRepo.
+type MockEmployeeRepoMockRecorder struct {
+	mock *MockEmployeeRepo
+}
+
+// NewMockEmployeeRepo creates a new mock instance.
+func NewMockEmployeeRepo(ctrl *gomock.Controller) *MockEmployeeRepo {
+	mock := &MockEmployeeRepo{ctrl: ctrl}
+	mock.recorder = &MockEmployeeRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmployeeRepo) EXPECT() *MockEmployeeRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateEmployee mocks base method.
+func (m *MockEmployeeRepo) CreateEmployee(arg0 models.Employee, arg1 string) (models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmployee", arg0, arg1)
+	ret0, _ := ret[0].(models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmployee indicates an expected call of CreateEmployee.
+func (mr *MockEmployeeRepoMockRecorder) CreateEmployee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployee", reflect.TypeOf((*MockEmployeeRepo)(nil).CreateEmployee), arg0, arg1)
+}
+
+// DeleteEmployee mocks base method.
+func (m *MockEmployeeRepo) DeleteEmployee(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmployee", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmployee indicates an expected call of DeleteEmployee.
+func (mr *MockEmployeeRepoMockRecorder) DeleteEmployee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockEmployeeRepo)(nil).DeleteEmployee), arg0, arg1)
+}
+
+// EditEmployee mocks base method.
+func (m *MockEmployeeRepo) EditEmployee(arg0 string, arg1 models.EmployeeUpdate, arg2 string) (models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditEmployee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditEmployee indicates an expected call of EditEmployee.
+func (mr *MockEmployeeRepoMockRecorder) EditEmployee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditEmployee", reflect.TypeOf((*MockEmployeeRepo)(nil).EditEmployee), arg0, arg1, arg2)
+}
+
+// GetEmployeeByUsername mocks base method.
+func (m *MockEmployeeRepo) GetEmployeeByUsername(arg0 string) (models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeByUsername", arg0)
+	ret0, _ := ret[0].(models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployeeByUsername indicates an expected call of GetEmployeeByUsername.
+func (mr *MockEmployeeRepoMockRecorder) GetEmployeeByUsername(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByUsername", reflect.TypeOf((*MockEmployeeRepo)(nil).GetEmployeeByUsername), arg0)
+}
+
+// GetEmployees mocks base method.
+func (m *MockEmployeeRepo) GetEmployees(arg0 string) ([]models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployees", arg0)
+	ret0, _ := ret[0].([]models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmployees indicates an expected call of GetEmployees.
+func (mr *MockEmployeeRepoMockRecorder) GetEmployees(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployees", reflect.TypeOf((*MockEmployeeRepo)(nil).GetEmployees), arg0)
+}
+
+// MockOrganizationRepo is a mock of OrganizationRepo interface.
+type MockOrganizationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationRepoMockRecorder
+}
+
+// MockOrganizationRepoMockRecorder is the mock recorder for MockOrganizationRepo.
+type MockOrganizationRepoMockRecorder struct {
+	mock *MockOrganizationRepo
+}
+
+// NewMockOrganizationRepo creates a new mock instance.
+func NewMockOrganizationRepo(ctrl *gomock.Controller) *MockOrganizationRepo {
+	mock := &MockOrganizationRepo{ctrl: ctrl}
+	mock.recorder = &MockOrganizationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationRepo) EXPECT() *MockOrganizationRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationRepo) CreateOrganization(arg0 models.Organization, arg1 string) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationRepoMockRecorder) CreateOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationRepo)(nil).CreateOrganization), arg0, arg1)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockOrganizationRepo) DeleteOrganization(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockOrganizationRepoMockRecorder) DeleteOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockOrganizationRepo)(nil).DeleteOrganization), arg0, arg1)
+}
+
+// EditOrganization mocks base method.
+func (m *MockOrganizationRepo) EditOrganization(arg0 int, arg1 models.OrganizationUpdate, arg2 string) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditOrganization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditOrganization indicates an expected call of EditOrganization.
+func (mr *MockOrganizationRepoMockRecorder) EditOrganization(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditOrganization", reflect.TypeOf((*MockOrganizationRepo)(nil).EditOrganization), arg0, arg1, arg2)
+}
+
+// GetOrganization mocks base method.
+func (m *MockOrganizationRepo) GetOrganization(arg0 int, arg1 string) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganization", arg0, arg1)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockOrganizationRepoMockRecorder) GetOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockOrganizationRepo)(nil).GetOrganization), arg0, arg1)
+}
+
+// GetOrganizations mocks base method.
+func (m *MockOrganizationRepo) GetOrganizations(arg0 string) ([]models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizations", arg0)
+	ret0, _ := ret[0].([]models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockOrganizationRepoMockRecorder) GetOrganizations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockOrganizationRepo)(nil).GetOrganizations), arg0)
+}
+
+// GetResponsibles mocks base method.
+func (m *MockOrganizationRepo) GetResponsibles(arg0 int, arg1 string) ([]models.Responsible, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResponsibles", arg0, arg1)
+	ret0, _ := ret[0].([]models.Responsible)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResponsibles indicates an expected call of GetResponsibles.
+func (mr *MockOrganizationRepoMockRecorder) GetResponsibles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResponsibles", reflect.TypeOf((*MockOrganizationRepo)(nil).GetResponsibles), arg0, arg1)
+}
+
+// RemoveResponsible mocks base method.
+func (m *MockOrganizationRepo) RemoveResponsible(arg0 int, arg1, arg2 string) ([]models.Responsible, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveResponsible", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Responsible)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveResponsible indicates an expected call of RemoveResponsible.
+func (mr *MockOrganizationRepoMockRecorder) RemoveResponsible(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveResponsible", reflect.TypeOf((*MockOrganizationRepo)(nil).RemoveResponsible), arg0, arg1, arg2)
+}
+
+// SetOrganizationDecisionPolicy mocks base method.
+func (m *MockOrganizationRepo) SetOrganizationDecisionPolicy(arg0 int, arg1 models.DecisionPolicy, arg2 string) (models.DecisionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrganizationDecisionPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.DecisionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetOrganizationDecisionPolicy indicates an expected call of SetOrganizationDecisionPolicy.
+func (mr *MockOrganizationRepoMockRecorder) SetOrganizationDecisionPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationDecisionPolicy", reflect.TypeOf((*MockOrganizationRepo)(nil).SetOrganizationDecisionPolicy), arg0, arg1, arg2)
+}
+
+// SetResponsible mocks base method.
+func (m *MockOrganizationRepo) SetResponsible(arg0 int, arg1, arg2, arg3 string) ([]models.Responsible, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetResponsible", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Responsible)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetResponsible indicates an expected call of SetResponsible.
+func (mr *MockOrganizationRepoMockRecorder) SetResponsible(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResponsible", reflect.TypeOf((*MockOrganizationRepo)(nil).SetResponsible), arg0, arg1, arg2, arg3)
+}
+
+// MockAttachmentsRepo is a mock of AttachmentsRepo interface.
+type MockAttachmentsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentsRepoMockRecorder
+}
+
+// MockAttachmentsRepoMockRecorder is the mock recorder for MockAttachmentsRepo.
+type MockAttachmentsRepoMockRecorder struct {
+	mock *MockAttachmentsRepo
+}
+
+// NewMockAttachmentsRepo creates a new mock instance.
+func NewMockAttachmentsRepo(ctrl *gomock.Controller) *MockAttachmentsRepo {
+	mock := &MockAttachmentsRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentsRepo) EXPECT() *MockAttachmentsRepoMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachmentsRepo) AddAttachment(arg0 models.AttachmentOwner, arg1 models.Attachment, arg2 func(context.Context) error, arg3 string, arg4 int) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentsRepoMockRecorder) AddAttachment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachmentsRepo)(nil).AddAttachment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAttachmentsRepo) DeleteAttachment(arg0 models.AttachmentOwner, arg1 int, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAttachmentsRepoMockRecorder) DeleteAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAttachmentsRepo)(nil).DeleteAttachment), arg0, arg1, arg2, arg3)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentsRepo) GetAttachment(arg0 models.AttachmentOwner, arg1 int, arg2 string) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentsRepoMockRecorder) GetAttachment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentsRepo)(nil).GetAttachment), arg0, arg1, arg2)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAttachmentsRepo) GetAttachments(arg0 models.AttachmentOwner, arg1 string) ([]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAttachmentsRepoMockRecorder) GetAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAttachmentsRepo)(nil).GetAttachments), arg0, arg1)
+}
+
+// MockEventsRepo is a mock of EventsRepo interface.
+type MockEventsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsRepoMockRecorder
+}
+
+// MockEventsRepoMockRecorder is the mock recorder for MockEventsRepo.
+type MockEventsRepoMockRecorder struct {
+	mock *MockEventsRepo
+}
+
+// NewMockEventsRepo creates a new mock instance.
+func NewMockEventsRepo(ctrl *gomock.Controller) *MockEventsRepo {
+	mock := &MockEventsRepo{ctrl: ctrl}
+	mock.recorder = &MockEventsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsRepo) EXPECT() *MockEventsRepoMockRecorder {
+	return m.recorder
+}
+
+// EventVisible mocks base method.
+func (m *MockEventsRepo) EventVisible(arg0 models.Event, arg1 string, arg2 models.EventScope) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventVisible", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EventVisible indicates an expected call of EventVisible.
+func (mr *MockEventsRepoMockRecorder) EventVisible(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventVisible", reflect.TypeOf((*MockEventsRepo)(nil).EventVisible), arg0, arg1, arg2)
+}
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockRepository) AddAttachment(arg0 models.AttachmentOwner, arg1 models.Attachment, arg2 func(context.Context) error, arg3 string, arg4 int) (models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockRepositoryMockRecorder) AddAttachment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockRepository)(nil).AddAttachment), arg0, arg1, arg2, arg3, arg4)
+}
+
+// AddFeedback mocks base method.
+func (m *MockRepository) AddFeedback(arg0 models.Review, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFeedback", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFeedback indicates an expected call of AddFeedback.
+func (mr *MockRepositoryMockRecorder) AddFeedback(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFeedback", reflect.TypeOf((*MockRepository)(nil).AddFeedback), arg0, arg1)
+}
+
+// AnswerQuestion mocks base method.
+func (m *MockRepository) AnswerQuestion(arg0, arg1 int, arg2, arg3 string) (models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnswerQuestion", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnswerQuestion indicates an expected call of AnswerQuestion.
+func (mr *MockRepositoryMockRecorder) AnswerQuestion(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnswerQuestion", reflect.TypeOf((*MockRepository)(nil).AnswerQuestion), arg0, arg1, arg2, arg3)
+}
+
+// AskQuestion mocks base method.
+func (m *MockRepository) AskQuestion(arg0 int, arg1, arg2 string) (models.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AskQuestion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AskQuestion indicates an expected call of AskQuestion.
+func (mr *MockRepositoryMockRecorder) AskQuestion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AskQuestion", reflect.TypeOf((*MockRepository)(nil).AskQuestion), arg0, arg1, arg2)
+}
+
+// AwardTender mocks base method.
+func (m *MockRepository) AwardTender(arg0, arg1 int, arg2, arg3 string) (models.AwardSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwardTender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.AwardSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AwardTender indicates an expected call of AwardTender.
+func (mr *MockRepositoryMockRecorder) AwardTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwardTender", reflect.TypeOf((*MockRepository)(nil).AwardTender), arg0, arg1, arg2, arg3)
+}
+
+// CreateBid mocks base method.
+func (m *MockRepository) CreateBid(arg0 models.Bid, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBid", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBid indicates an expected call of CreateBid.
+func (mr *MockRepositoryMockRecorder) CreateBid(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBid", reflect.TypeOf((*MockRepository)(nil).CreateBid), arg0, arg1)
+}
+
+// CreateEmployee mocks base method.
+func (m *MockRepository) CreateEmployee(arg0 models.Employee, arg1 string) (models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmployee", arg0, arg1)
+	ret0, _ := ret[0].(models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmployee indicates an expected call of CreateEmployee.
+func (mr *MockRepositoryMockRecorder) CreateEmployee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmployee", reflect.TypeOf((*MockRepository)(nil).CreateEmployee), arg0, arg1)
+}
+
+// CreateOrganization mocks base method.
+func (m *MockRepository) CreateOrganization(arg0 models.Organization, arg1 string) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", arg0, arg1)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockRepositoryMockRecorder) CreateOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockRepository)(nil).CreateOrganization), arg0, arg1)
+}
+
+// CreateTender mocks base method.
+func (m *MockRepository) CreateTender(arg0 models.Tender) (models.Tender, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTender", arg0)
+	ret0, _ := ret[0].(models.Tender)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTender indicates an expected call of CreateTender.
+func (mr *MockRepositoryMockRecorder) CreateTender(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTender", reflect.TypeOf((*MockRepository)(nil).CreateTender), arg0)
+}
+
+// DeclineDecision mocks base method.
+func (m *MockRepository) DeclineDecision(arg0 int, arg1 string) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineDecision", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeclineDecision indicates an expected call of DeclineDecision.
+func (mr *MockRepositoryMockRecorder) DeclineDecision(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineDecision", reflect.TypeOf((*MockRepository)(nil).DeclineDecision), arg0, arg1)
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockRepository) DeleteAttachment(arg0 models.AttachmentOwner, arg1 int, arg2 string, arg3 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockRepositoryMockRecorder) DeleteAttachment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockRepository)(nil).DeleteAttachment), arg0, arg1, arg2, arg3)
+}
+
+// DeleteEmployee mocks base method.
+func (m *MockRepository) DeleteEmployee(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmployee", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmployee indicates an expected call of DeleteEmployee.
+func (mr *MockRepositoryMockRecorder) DeleteEmployee(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmployee", reflect.TypeOf((*MockRepository)(nil).DeleteEmployee), arg0, arg1)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockRepository) DeleteOrganization(arg0 int, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockRepositoryMockRecorder) DeleteOrganization(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockRepository)(nil).DeleteOrganization), arg0, arg1)
+}
+
+// EditBid mocks base method.
+func (m *MockRepository) EditBid(arg0 int, arg1 models.BidUpdate, arg2 string, arg3 int) (models.Bid, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditBid", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditBid indicates an expected call of EditBid.
+func (mr *MockRepositoryMockRecorder) EditBid(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditBid", reflect.TypeOf((*MockRepository)(nil).EditBid), arg0, arg1, arg2, arg3)
+}
+
+// EditEmployee mocks base method.
+func (m *MockRepository) EditEmployee(arg0 string, arg1 models.EmployeeUpdate, arg2 string) (models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditEmployee", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditEmployee indicates an expected call of EditEmployee.
+func (mr *MockRepositoryMockRecorder) EditEmployee(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditEmployee", reflect.TypeOf((*MockRepository)(nil).EditEmployee), arg0, arg1, arg2)
+}
+
+// EditOrganization mocks base method.
+func (m *MockRepository) EditOrganization(arg0 int, arg1 models.OrganizationUpdate, arg2 string) (models.Organization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditOrganization", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Organization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditOrganization indicates an expected call of EditOrganization.
+func (mr *MockRepositoryMockRecorder) EditOrganization(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTender", reflect.TypeOf((*MockTendersRepo)(nil).CreateTender), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditOrganization", reflect.TypeOf((*MockRepository)(nil).EditOrganization), arg0, arg1, arg2)
 }
 
 // EditTender mocks base method.
-func (m *MockTendersRepo) EditTender(arg0 int, arg1, arg2 string) (models.Tender, error) {
+func (m *MockRepository) EditTender(arg0 int, arg1 models.TenderUpdate, arg2 string, arg3 int) (models.Tender, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditTender", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "EditTender", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(models.Tender)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // EditTender indicates an expected call of EditTender.
-func (mr *MockTendersRepoMockRecorder) EditTender(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) EditTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTender", reflect.TypeOf((*MockTendersRepo)(nil).EditTender), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTender", reflect.TypeOf((*MockRepository)(nil).EditTender), arg0, arg1, arg2, arg3)
 }
 
-// GetAllTenders mocks base method.
-func (m *MockTendersRepo) GetAllTenders(arg0 string) ([]models.Tender, error) {
+// EventVisible mocks base method.
+func (m *MockRepository) EventVisible(arg0 models.Event, arg1 string, arg2 models.EventScope) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTenders", arg0)
-	ret0, _ := ret[0].([]models.Tender)
+	ret := m.ctrl.Call(m, "EventVisible", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTenders indicates an expected call of GetAllTenders.
-func (mr *MockTendersRepoMockRecorder) GetAllTenders(arg0 interface{}) *gomock.Call {
+// EventVisible indicates an expected call of EventVisible.
+func (mr *MockRepositoryMockRecorder) EventVisible(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenders", reflect.TypeOf((*MockTendersRepo)(nil).GetAllTenders), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventVisible", reflect.TypeOf((*MockRepository)(nil).EventVisible), arg0, arg1, arg2)
 }
 
-// GetTendersByUser mocks base method.
-func (m *MockTendersRepo) GetTendersByUser(arg0 string) ([]models.Tender, error) {
+// GetAllTenders mocks base method.
+func (m *MockRepository) GetAllTenders(arg0 string, arg1 models.ListParams) ([]models.Tender, models.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTendersByUser", arg0)
+	ret := m.ctrl.Call(m, "GetAllTenders", arg0, arg1)
 	ret0, _ := ret[0].([]models.Tender)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetTendersByUser indicates an expected call of GetTendersByUser.
-func (mr *MockTendersRepoMockRecorder) GetTendersByUser(arg0 interface{}) *gomock.Call {
+// GetAllTenders indicates an expected call of GetAllTenders.
+func (mr *MockRepositoryMockRecorder) GetAllTenders(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTendersByUser", reflect.TypeOf((*MockTendersRepo)(nil).GetTendersByUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenders", reflect.TypeOf((*MockRepository)(nil).GetAllTenders), arg0, arg1)
 }
 
-// RollbackTender mocks base method.
-func (m *MockTendersRepo) RollbackTender(arg0, arg1 int) (models.Tender, error) {
+// GetAttachment mocks base method.
+func (m *MockRepository) GetAttachment(arg0 models.AttachmentOwner, arg1 int, arg2 string) (models.Attachment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollbackTender", arg0, arg1)
-	ret0, _ := ret[0].(models.Tender)
+	ret := m.ctrl.Call(m, "GetAttachment", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Attachment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RollbackTender indicates an expected call of RollbackTender.
-func (mr *MockTendersRepoMockRecorder) RollbackTender(arg0, arg1 interface{}) *gomock.Call {
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockRepositoryMockRecorder) GetAttachment(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTender", reflect.TypeOf((*MockTendersRepo)(nil).RollbackTender), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockRepository)(nil).GetAttachment), arg0, arg1, arg2)
 }
 
-// SetTenderStatus mocks base method.
-func (m *MockTendersRepo) SetTenderStatus(arg0 int, arg1 string) error {
+// GetAttachments mocks base method.
+func (m *MockRepository) GetAttachments(arg0 models.AttachmentOwner, arg1 string) ([]models.Attachment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetTenderStatus", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetTenderStatus indicates an expected call of SetTenderStatus.
-func (mr *MockTendersRepoMockRecorder) SetTenderStatus(arg0, arg1 interface{}) *gomock.Call {
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockRepositoryMockRecorder) GetAttachments(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderStatus", reflect.TypeOf((*MockTendersRepo)(nil).SetTenderStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockRepository)(nil).GetAttachments), arg0, arg1)
 }
 
-// MockBidsRepo is a mock of BidsRepo interface.
-type MockBidsRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockBidsRepoMockRecorder
+// GetAuction mocks base method.
+func (m *MockRepository) GetAuction(arg0 int, arg1 string) (models.Auction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuction", arg0, arg1)
+	ret0, _ := ret[0].(models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockBidsRepoMockRecorder is the mock recorder for MockBidsRepo.
-type MockBidsRepoMockRecorder struct {
-	mock *MockBidsRepo
+// GetAuction indicates an expected call of GetAuction.
+func (mr *MockRepositoryMockRecorder) GetAuction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuction", reflect.TypeOf((*MockRepository)(nil).GetAuction), arg0, arg1)
 }
 
-// NewMockBidsRepo creates a new mock instance.
-func NewMockBidsRepo(ctrl *gomock.Controller) *MockBidsRepo {
-	mock := &MockBidsRepo{ctrl: ctrl}
-	mock.recorder = &MockBidsRepoMockRecorder{mock}
-	return mock
+// GetBidDecisions mocks base method.
+func (m *MockRepository) GetBidDecisions(arg0 int, arg1 string) (models.Ballot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidDecisions", arg0, arg1)
+	ret0, _ := ret[0].(models.Ballot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockBidsRepo) EXPECT() *MockBidsRepoMockRecorder {
-	return m.recorder
+// GetBidDecisions indicates an expected call of GetBidDecisions.
+func (mr *MockRepositoryMockRecorder) GetBidDecisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidDecisions", reflect.TypeOf((*MockRepository)(nil).GetBidDecisions), arg0, arg1)
 }
 
-// CheckUserPermissionForBid mocks base method.
-func (m *MockBidsRepo) CheckUserPermissionForBid(arg0 int, arg1 string) (bool, error) {
+// GetBidStatus mocks base method.
+func (m *MockRepository) GetBidStatus(arg0 int, arg1 string) (models.BidStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserPermissionForBid", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetBidStatus", arg0, arg1)
+	ret0, _ := ret[0].(models.BidStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckUserPermissionForBid indicates an expected call of CheckUserPermissionForBid.
-func (mr *MockBidsRepoMockRecorder) CheckUserPermissionForBid(arg0, arg1 interface{}) *gomock.Call {
+// GetBidStatus indicates an expected call of GetBidStatus.
+func (mr *MockRepositoryMockRecorder) GetBidStatus(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserPermissionForBid", reflect.TypeOf((*MockBidsRepo)(nil).CheckUserPermissionForBid), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidStatus", reflect.TypeOf((*MockRepository)(nil).GetBidStatus), arg0, arg1)
 }
 
-// CheckUserPermissionForTender mocks base method.
-func (m *MockBidsRepo) CheckUserPermissionForTender(arg0 int, arg1 string) (bool, error) {
+// GetBidVersions mocks base method.
+func (m *MockRepository) GetBidVersions(arg0 int, arg1 string) ([]models.Version, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserPermissionForTender", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "GetBidVersions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Version)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckUserPermissionForTender indicates an expected call of CheckUserPermissionForTender.
-func (mr *MockBidsRepoMockRecorder) CheckUserPermissionForTender(arg0, arg1 interface{}) *gomock.Call {
+// GetBidVersions indicates an expected call of GetBidVersions.
+func (mr *MockRepositoryMockRecorder) GetBidVersions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserPermissionForTender", reflect.TypeOf((*MockBidsRepo)(nil).CheckUserPermissionForTender), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidVersions", reflect.TypeOf((*MockRepository)(nil).GetBidVersions), arg0, arg1)
 }
 
-// CreateBid mocks base method.
-func (m *MockBidsRepo) CreateBid(arg0 models.Bid, arg1 string) (models.Bid, error) {
+// GetBidsByUser mocks base method.
+func (m *MockRepository) GetBidsByUser(arg0 string, arg1 models.ListParams) ([]models.Bid, models.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBid", arg0, arg1)
-	ret0, _ := ret[0].(models.Bid)
+	ret := m.ctrl.Call(m, "GetBidsByUser", arg0, arg1)
+	ret0, _ := ret[0].([]models.Bid)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBidsByUser indicates an expected call of GetBidsByUser.
+func (mr *MockRepositoryMockRecorder) GetBidsByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsByUser", reflect.TypeOf((*MockRepository)(nil).GetBidsByUser), arg0, arg1)
+}
+
+// GetBidsForTender mocks base method.
+func (m *MockRepository) GetBidsForTender(arg0 int, arg1 string, arg2 models.ListParams) ([]models.Bid, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBidsForTender", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Bid)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetBidsForTender indicates an expected call of GetBidsForTender.
+func (mr *MockRepositoryMockRecorder) GetBidsForTender(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsForTender", reflect.TypeOf((*MockRepository)(nil).GetBidsForTender), arg0, arg1, arg2)
+}
+
+// GetEmployeeByUsername mocks base method.
+func (m *MockRepository) GetEmployeeByUsername(arg0 string) (models.Employee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmployeeByUsername", arg0)
+	ret0, _ := ret[0].(models.Employee)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateBid indicates an expected call of CreateBid.
-func (mr *MockBidsRepoMockRecorder) CreateBid(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployeeByUsername indicates an expected call of GetEmployeeByUsername.
+func (mr *MockRepositoryMockRecorder) GetEmployeeByUsername(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBid", reflect.TypeOf((*MockBidsRepo)(nil).CreateBid), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployeeByUsername", reflect.TypeOf((*MockRepository)(nil).GetEmployeeByUsername), arg0)
 }
 
-// DeclineDecision mocks base method.
-func (m *MockBidsRepo) DeclineDecision(arg0 int, arg1 string) error {
+// GetEmployees mocks base method.
+func (m *MockRepository) GetEmployees(arg0 string) ([]models.Employee, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeclineDecision", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetEmployees", arg0)
+	ret0, _ := ret[0].([]models.Employee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeclineDecision indicates an expected call of DeclineDecision.
-func (mr *MockBidsRepoMockRecorder) DeclineDecision(arg0, arg1 interface{}) *gomock.Call {
+// GetEmployees indicates an expected call of GetEmployees.
+func (mr *MockRepositoryMockRecorder) GetEmployees(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineDecision", reflect.TypeOf((*MockBidsRepo)(nil).DeclineDecision), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmployees", reflect.TypeOf((*MockRepository)(nil).GetEmployees), arg0)
 }
 
-// EditBid mocks base method.
-func (m *MockBidsRepo) EditBid(arg0 int, arg1, arg2 string) (models.Bid, error) {
+// GetInvitations mocks base method.
+func (m *MockRepository) GetInvitations(arg0 int, arg1 string) ([]models.Invitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditBid", arg0, arg1, arg2)
-	ret0, _ := ret[0].(models.Bid)
+	ret := m.ctrl.Call(m, "GetInvitations", arg0, arg1)
+	ret0, _ := ret[0].([]models.Invitation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EditBid indicates an expected call of EditBid.
-func (mr *MockBidsRepoMockRecorder) EditBid(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetInvitations indicates an expected call of GetInvitations.
+func (mr *MockRepositoryMockRecorder) GetInvitations(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditBid", reflect.TypeOf((*MockBidsRepo)(nil).EditBid), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitations", reflect.TypeOf((*MockRepository)(nil).GetInvitations), arg0, arg1)
 }
 
-// GetBidsByUser mocks base method.
-func (m *MockBidsRepo) GetBidsByUser(arg0 string) ([]models.Bid, error) {
+// GetOrganization mocks base method.
+func (m *MockRepository) GetOrganization(arg0 int, arg1 string) (models.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBidsByUser", arg0)
-	ret0, _ := ret[0].([]models.Bid)
+	ret := m.ctrl.Call(m, "GetOrganization", arg0, arg1)
+	ret0, _ := ret[0].(models.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBidsByUser indicates an expected call of GetBidsByUser.
-func (mr *MockBidsRepoMockRecorder) GetBidsByUser(arg0 interface{}) *gomock.Call {
+// GetOrganization indicates an expected call of GetOrganization.
+func (mr *MockRepositoryMockRecorder) GetOrganization(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsByUser", reflect.TypeOf((*MockBidsRepo)(nil).GetBidsByUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganization", reflect.TypeOf((*MockRepository)(nil).GetOrganization), arg0, arg1)
 }
 
-// GetBidsForTender mocks base method.
-func (m *MockBidsRepo) GetBidsForTender(arg0 int) ([]models.Bid, error) {
+// GetOrganizations mocks base method.
+func (m *MockRepository) GetOrganizations(arg0 string) ([]models.Organization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBidsForTender", arg0)
-	ret0, _ := ret[0].([]models.Bid)
+	ret := m.ctrl.Call(m, "GetOrganizations", arg0)
+	ret0, _ := ret[0].([]models.Organization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBidsForTender indicates an expected call of GetBidsForTender.
-func (mr *MockBidsRepoMockRecorder) GetBidsForTender(arg0 interface{}) *gomock.Call {
+// GetOrganizations indicates an expected call of GetOrganizations.
+func (mr *MockRepositoryMockRecorder) GetOrganizations(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsForTender", reflect.TypeOf((*MockBidsRepo)(nil).GetBidsForTender), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizations", reflect.TypeOf((*MockRepository)(nil).GetOrganizations), arg0)
 }
 
-// GetTenderIDByBidID mocks base method.
-func (m *MockBidsRepo) GetTenderIDByBidID(arg0 int) (int, error) {
+// GetQuestions mocks base method.
+func (m *MockRepository) GetQuestions(arg0 int, arg1 string) ([]models.Question, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTenderIDByBidID", arg0)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "GetQuestions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Question)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTenderIDByBidID indicates an expected call of GetTenderIDByBidID.
-func (mr *MockBidsRepoMockRecorder) GetTenderIDByBidID(arg0 interface{}) *gomock.Call {
+// GetQuestions indicates an expected call of GetQuestions.
+func (mr *MockRepositoryMockRecorder) GetQuestions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderIDByBidID", reflect.TypeOf((*MockBidsRepo)(nil).GetTenderIDByBidID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestions", reflect.TypeOf((*MockRepository)(nil).GetQuestions), arg0, arg1)
 }
 
-// RollbackBid mocks base method.
-func (m *MockBidsRepo) RollbackBid(arg0, arg1 int) (models.Bid, error) {
+// GetResponsibles mocks base method.
+func (m *MockRepository) GetResponsibles(arg0 int, arg1 string) ([]models.Responsible, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollbackBid", arg0, arg1)
-	ret0, _ := ret[0].(models.Bid)
+	ret := m.ctrl.Call(m, "GetResponsibles", arg0, arg1)
+	ret0, _ := ret[0].([]models.Responsible)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RollbackBid indicates an expected call of RollbackBid.
-func (mr *MockBidsRepoMockRecorder) RollbackBid(arg0, arg1 interface{}) *gomock.Call {
+// GetResponsibles indicates an expected call of GetResponsibles.
+func (mr *MockRepositoryMockRecorder) GetResponsibles(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackBid", reflect.TypeOf((*MockBidsRepo)(nil).RollbackBid), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResponsibles", reflect.TypeOf((*MockRepository)(nil).GetResponsibles), arg0, arg1)
 }
 
-// SetBidStatus mocks base method.
-func (m *MockBidsRepo) SetBidStatus(arg0 int, arg1 string) error {
+// GetReviewsByAuthorAndTender mocks base method.
+func (m *MockRepository) GetReviewsByAuthorAndTender(arg0 int, arg1, arg2 string, arg3 models.ListParams) ([]models.Review, models.Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetBidStatus", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetReviewsByAuthorAndTender", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// SetBidStatus indicates an expected call of SetBidStatus.
-func (mr *MockBidsRepoMockRecorder) SetBidStatus(arg0, arg1 interface{}) *gomock.Call {
+// GetReviewsByAuthorAndTender indicates an expected call of GetReviewsByAuthorAndTender.
+func (mr *MockRepositoryMockRecorder) GetReviewsByAuthorAndTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBidStatus", reflect.TypeOf((*MockBidsRepo)(nil).SetBidStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByAuthorAndTender", reflect.TypeOf((*MockRepository)(nil).GetReviewsByAuthorAndTender), arg0, arg1, arg2, arg3)
 }
 
-// SubmitDecision mocks base method.
-func (m *MockBidsRepo) SubmitDecision(arg0 int, arg1 string) error {
+// GetRounds mocks base method.
+func (m *MockRepository) GetRounds(arg0 int, arg1 string) ([]models.Round, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SubmitDecision", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetRounds", arg0, arg1)
+	ret0, _ := ret[0].([]models.Round)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SubmitDecision indicates an expected call of SubmitDecision.
-func (mr *MockBidsRepoMockRecorder) SubmitDecision(arg0, arg1 interface{}) *gomock.Call {
+// GetRounds indicates an expected call of GetRounds.
+func (mr *MockRepositoryMockRecorder) GetRounds(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitDecision", reflect.TypeOf((*MockBidsRepo)(nil).SubmitDecision), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRounds", reflect.TypeOf((*MockRepository)(nil).GetRounds), arg0, arg1)
 }
 
-// MockFeedbackReview is a mock of FeedbackReview interface.
-type MockFeedbackReview struct {
-	ctrl     *gomock.Controller
-	recorder *MockFeedbackReviewMockRecorder
+// GetTenderAward mocks base method.
+func (m *MockRepository) GetTenderAward(arg0 int, arg1 string) (models.AwardSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderAward", arg0, arg1)
+	ret0, _ := ret[0].(models.AwardSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockFeedbackReviewMockRecorder is the mock recorder for MockFeedbackReview.
-type MockFeedbackReviewMockRecorder struct {
-	mock *MockFeedbackReview
+// GetTenderAward indicates an expected call of GetTenderAward.
+func (mr *MockRepositoryMockRecorder) GetTenderAward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderAward", reflect.TypeOf((*MockRepository)(nil).GetTenderAward), arg0, arg1)
 }
 
-// NewMockFeedbackReview creates a new mock instance.
-func NewMockFeedbackReview(ctrl *gomock.Controller) *MockFeedbackReview {
-	mock := &MockFeedbackReview{ctrl: ctrl}
-	mock.recorder = &MockFeedbackReviewMockRecorder{mock}
-	return mock
+// GetTenderLeaderboard mocks base method.
+func (m *MockRepository) GetTenderLeaderboard(arg0 int, arg1 string) (models.Leaderboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderLeaderboard", arg0, arg1)
+	ret0, _ := ret[0].(models.Leaderboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockFeedbackReview) EXPECT() *MockFeedbackReviewMockRecorder {
-	return m.recorder
+// GetTenderLeaderboard indicates an expected call of GetTenderLeaderboard.
+func (mr *MockRepositoryMockRecorder) GetTenderLeaderboard(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderLeaderboard", reflect.TypeOf((*MockRepository)(nil).GetTenderLeaderboard), arg0, arg1)
 }
 
-// AddFeedback mocks base method.
-func (m *MockFeedbackReview) AddFeedback(arg0 models.Review, arg1 string) error {
+// GetTenderStatus mocks base method.
+func (m *MockRepository) GetTenderStatus(arg0 int, arg1 string) (models.TenderStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFeedback", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetTenderStatus", arg0, arg1)
+	ret0, _ := ret[0].(models.TenderStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AddFeedback indicates an expected call of AddFeedback.
-func (mr *MockFeedbackReviewMockRecorder) AddFeedback(arg0, arg1 interface{}) *gomock.Call {
+// GetTenderStatus indicates an expected call of GetTenderStatus.
+func (mr *MockRepositoryMockRecorder) GetTenderStatus(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFeedback", reflect.TypeOf((*MockFeedbackReview)(nil).AddFeedback), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderStatus", reflect.TypeOf((*MockRepository)(nil).GetTenderStatus), arg0, arg1)
 }
 
-// GetReviewsByAuthorAndTender mocks base method.
-func (m *MockFeedbackReview) GetReviewsByAuthorAndTender(arg0 int, arg1 string, arg2 int) ([]models.Review, error) {
+// GetTenderTransitions mocks base method.
+func (m *MockRepository) GetTenderTransitions(arg0 int, arg1 string) ([]models.TenderTransition, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReviewsByAuthorAndTender", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]models.Review)
+	ret := m.ctrl.Call(m, "GetTenderTransitions", arg0, arg1)
+	ret0, _ := ret[0].([]models.TenderTransition)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReviewsByAuthorAndTender indicates an expected call of GetReviewsByAuthorAndTender.
-func (mr *MockFeedbackReviewMockRecorder) GetReviewsByAuthorAndTender(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetTenderTransitions indicates an expected call of GetTenderTransitions.
+func (mr *MockRepositoryMockRecorder) GetTenderTransitions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByAuthorAndTender", reflect.TypeOf((*MockFeedbackReview)(nil).GetReviewsByAuthorAndTender), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderTransitions", reflect.TypeOf((*MockRepository)(nil).GetTenderTransitions), arg0, arg1)
 }
 
-// MockRepository is a mock of Repository interface.
-type MockRepository struct {
-	ctrl     *gomock.Controller
-	recorder *MockRepositoryMockRecorder
+// GetTenderVersions mocks base method.
+func (m *MockRepository) GetTenderVersions(arg0 int, arg1 string) ([]models.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTenderVersions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Version)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockRepositoryMockRecorder is the mock recorder for MockRepository.
-type MockRepositoryMockRecorder struct {
-	mock *MockRepository
+// GetTenderVersions indicates an expected call of GetTenderVersions.
+func (mr *MockRepositoryMockRecorder) GetTenderVersions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderVersions", reflect.TypeOf((*MockRepository)(nil).GetTenderVersions), arg0, arg1)
 }
 
-// NewMockRepository creates a new mock instance.
-func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
-	mock := &MockRepository{ctrl: ctrl}
-	mock.recorder = &MockRepositoryMockRecorder{mock}
-	return mock
+// GetTendersByUser mocks base method.
+func (m *MockRepository) GetTendersByUser(arg0 string, arg1 models.ListParams) ([]models.Tender, models.Page, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTendersByUser", arg0, arg1)
+	ret0, _ := ret[0].([]models.Tender)
+	ret1, _ := ret[1].(models.Page)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
-	return m.recorder
+// GetTendersByUser indicates an expected call of GetTendersByUser.
+func (mr *MockRepositoryMockRecorder) GetTendersByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTendersByUser", reflect.TypeOf((*MockRepository)(nil).GetTendersByUser), arg0, arg1)
 }
 
-// AddFeedback mocks base method.
-func (m *MockRepository) AddFeedback(arg0 models.Review, arg1 string) error {
+// Invite mocks base method.
+func (m *MockRepository) Invite(arg0 int, arg1 models.Invitation, arg2 string) (models.Invitation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFeedback", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Invite", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// AddFeedback indicates an expected call of AddFeedback.
-func (mr *MockRepositoryMockRecorder) AddFeedback(arg0, arg1 interface{}) *gomock.Call {
+// Invite indicates an expected call of Invite.
+func (mr *MockRepositoryMockRecorder) Invite(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFeedback", reflect.TypeOf((*MockRepository)(nil).AddFeedback), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockRepository)(nil).Invite), arg0, arg1, arg2)
 }
 
-// CheckUserPermissionForBid mocks base method.
-func (m *MockRepository) CheckUserPermissionForBid(arg0 int, arg1 string) (bool, error) {
+// OpenAuction mocks base method.
+func (m *MockRepository) OpenAuction(arg0 int, arg1 models.AuctionSettings, arg2 string) (models.Auction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserPermissionForBid", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "OpenAuction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Auction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckUserPermissionForBid indicates an expected call of CheckUserPermissionForBid.
-func (mr *MockRepositoryMockRecorder) CheckUserPermissionForBid(arg0, arg1 interface{}) *gomock.Call {
+// OpenAuction indicates an expected call of OpenAuction.
+func (mr *MockRepositoryMockRecorder) OpenAuction(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserPermissionForBid", reflect.TypeOf((*MockRepository)(nil).CheckUserPermissionForBid), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenAuction", reflect.TypeOf((*MockRepository)(nil).OpenAuction), arg0, arg1, arg2)
 }
 
-// CheckUserPermissionForTender mocks base method.
-func (m *MockRepository) CheckUserPermissionForTender(arg0 int, arg1 string) (bool, error) {
+// OpenRound mocks base method.
+func (m *MockRepository) OpenRound(arg0 int, arg1 models.RoundRequest, arg2 string) (models.Round, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckUserPermissionForTender", arg0, arg1)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "OpenRound", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Round)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CheckUserPermissionForTender indicates an expected call of CheckUserPermissionForTender.
-func (mr *MockRepositoryMockRecorder) CheckUserPermissionForTender(arg0, arg1 interface{}) *gomock.Call {
+// OpenRound indicates an expected call of OpenRound.
+func (mr *MockRepositoryMockRecorder) OpenRound(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckUserPermissionForTender", reflect.TypeOf((*MockRepository)(nil).CheckUserPermissionForTender), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenRound", reflect.TypeOf((*MockRepository)(nil).OpenRound), arg0, arg1, arg2)
 }
 
-// CreateBid mocks base method.
-func (m *MockRepository) CreateBid(arg0 models.Bid, arg1 string) (models.Bid, error) {
+// RemoveResponsible mocks base method.
+func (m *MockRepository) RemoveResponsible(arg0 int, arg1, arg2 string) ([]models.Responsible, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBid", arg0, arg1)
-	ret0, _ := ret[0].(models.Bid)
+	ret := m.ctrl.Call(m, "RemoveResponsible", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Responsible)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateBid indicates an expected call of CreateBid.
-func (mr *MockRepositoryMockRecorder) CreateBid(arg0, arg1 interface{}) *gomock.Call {
+// RemoveResponsible indicates an expected call of RemoveResponsible.
+func (mr *MockRepositoryMockRecorder) RemoveResponsible(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBid", reflect.TypeOf((*MockRepository)(nil).CreateBid), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveResponsible", reflect.TypeOf((*MockRepository)(nil).RemoveResponsible), arg0, arg1, arg2)
 }
 
-// CreateTender mocks base method.
-func (m *MockRepository) CreateTender(arg0 models.Tender) (models.Tender, error) {
+// RetractDecision mocks base method.
+func (m *MockRepository) RetractDecision(arg0 int, arg1 string) (models.Bid, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTender", arg0)
-	ret0, _ := ret[0].(models.Tender)
+	ret := m.ctrl.Call(m, "RetractDecision", arg0, arg1)
+	ret0, _ := ret[0].(models.Bid)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTender indicates an expected call of CreateTender.
-func (mr *MockRepositoryMockRecorder) CreateTender(arg0 interface{}) *gomock.Call {
+// RetractDecision indicates an expected call of RetractDecision.
+func (mr *MockRepositoryMockRecorder) RetractDecision(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTender", reflect.TypeOf((*MockRepository)(nil).CreateTender), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetractDecision", reflect.TypeOf((*MockRepository)(nil).RetractDecision), arg0, arg1)
 }
 
-// DeclineDecision mocks base method.
-func (m *MockRepository) DeclineDecision(arg0 int, arg1 string) error {
+// RevokeInvitation mocks base method.
+func (m *MockRepository) RevokeInvitation(arg0, arg1 int, arg2 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeclineDecision", arg0, arg1)
+	ret := m.ctrl.Call(m, "RevokeInvitation", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeclineDecision indicates an expected call of DeclineDecision.
-func (mr *MockRepositoryMockRecorder) DeclineDecision(arg0, arg1 interface{}) *gomock.Call {
+// RevokeInvitation indicates an expected call of RevokeInvitation.
+func (mr *MockRepositoryMockRecorder) RevokeInvitation(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineDecision", reflect.TypeOf((*MockRepository)(nil).DeclineDecision), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitation", reflect.TypeOf((*MockRepository)(nil).RevokeInvitation), arg0, arg1, arg2)
 }
 
-// EditBid mocks base method.
-func (m *MockRepository) EditBid(arg0 int, arg1, arg2 string) (models.Bid, error) {
+// RollbackBid mocks base method.
+func (m *MockRepository) RollbackBid(arg0, arg1 int, arg2 string, arg3 int) (models.Bid, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditBid", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RollbackBid", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(models.Bid)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EditBid indicates an expected call of EditBid.
-func (mr *MockRepositoryMockRecorder) EditBid(arg0, arg1, arg2 interface{}) *gomock.Call {
+// RollbackBid indicates an expected call of RollbackBid.
+func (mr *MockRepositoryMockRecorder) RollbackBid(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditBid", reflect.TypeOf((*MockRepository)(nil).EditBid), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackBid", reflect.TypeOf((*MockRepository)(nil).RollbackBid), arg0, arg1, arg2, arg3)
 }
 
-// EditTender mocks base method.
-func (m *MockRepository) EditTender(arg0 int, arg1, arg2 string) (models.Tender, error) {
+// RollbackTender mocks base method.
+func (m *MockRepository) RollbackTender(arg0, arg1 int, arg2 string, arg3 int) (models.Tender, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "EditTender", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "RollbackTender", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(models.Tender)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// EditTender indicates an expected call of EditTender.
-func (mr *MockRepositoryMockRecorder) EditTender(arg0, arg1, arg2 interface{}) *gomock.Call {
+// RollbackTender indicates an expected call of RollbackTender.
+func (mr *MockRepositoryMockRecorder) RollbackTender(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditTender", reflect.TypeOf((*MockRepository)(nil).EditTender), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTender", reflect.TypeOf((*MockRepository)(nil).RollbackTender), arg0, arg1, arg2, arg3)
 }
 
-// GetAllTenders mocks base method.
-func (m *MockRepository) GetAllTenders(arg0 string) ([]models.Tender, error) {
+// ScoreBid mocks base method.
+func (m *MockRepository) ScoreBid(arg0 int, arg1 map[string]int, arg2 string) ([]models.Score, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllTenders", arg0)
-	ret0, _ := ret[0].([]models.Tender)
+	ret := m.ctrl.Call(m, "ScoreBid", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Score)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllTenders indicates an expected call of GetAllTenders.
-func (mr *MockRepositoryMockRecorder) GetAllTenders(arg0 interface{}) *gomock.Call {
+// ScoreBid indicates an expected call of ScoreBid.
+func (mr *MockRepositoryMockRecorder) ScoreBid(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllTenders", reflect.TypeOf((*MockRepository)(nil).GetAllTenders), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScoreBid", reflect.TypeOf((*MockRepository)(nil).ScoreBid), arg0, arg1, arg2)
 }
 
-// GetBidsByUser mocks base method.
-func (m *MockRepository) GetBidsByUser(arg0 string) ([]models.Bid, error) {
+// SetBidStatus mocks base method.
+func (m *MockRepository) SetBidStatus(arg0 int, arg1, arg2 string, arg3 int) (models.Bid, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBidsByUser", arg0)
-	ret0, _ := ret[0].([]models.Bid)
+	ret := m.ctrl.Call(m, "SetBidStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Bid)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBidsByUser indicates an expected call of GetBidsByUser.
-func (mr *MockRepositoryMockRecorder) GetBidsByUser(arg0 interface{}) *gomock.Call {
+// SetBidStatus indicates an expected call of SetBidStatus.
+func (mr *MockRepositoryMockRecorder) SetBidStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsByUser", reflect.TypeOf((*MockRepository)(nil).GetBidsByUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBidStatus", reflect.TypeOf((*MockRepository)(nil).SetBidStatus), arg0, arg1, arg2, arg3)
 }
 
-// GetBidsForTender mocks base method.
-func (m *MockRepository) GetBidsForTender(arg0 int) ([]models.Bid, error) {
+// SetOrganizationDecisionPolicy mocks base method.
+func (m *MockRepository) SetOrganizationDecisionPolicy(arg0 int, arg1 models.DecisionPolicy, arg2 string) (models.DecisionPolicy, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBidsForTender", arg0)
-	ret0, _ := ret[0].([]models.Bid)
+	ret := m.ctrl.Call(m, "SetOrganizationDecisionPolicy", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.DecisionPolicy)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetBidsForTender indicates an expected call of GetBidsForTender.
-func (mr *MockRepositoryMockRecorder) GetBidsForTender(arg0 interface{}) *gomock.Call {
+// SetOrganizationDecisionPolicy indicates an expected call of SetOrganizationDecisionPolicy.
+func (mr *MockRepositoryMockRecorder) SetOrganizationDecisionPolicy(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBidsForTender", reflect.TypeOf((*MockRepository)(nil).GetBidsForTender), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrganizationDecisionPolicy", reflect.TypeOf((*MockRepository)(nil).SetOrganizationDecisionPolicy), arg0, arg1, arg2)
 }
 
-// GetReviewsByAuthorAndTender mocks base method.
-func (m *MockRepository) GetReviewsByAuthorAndTender(arg0 int, arg1 string, arg2 int) ([]models.Review, error) {
+// SetResponsible mocks base method.
+func (m *MockRepository) SetResponsible(arg0 int, arg1, arg2, arg3 string) ([]models.Responsible, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReviewsByAuthorAndTender", arg0, arg1, arg2)
-	ret0, _ := ret[0].([]models.Review)
+	ret := m.ctrl.Call(m, "SetResponsible", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Responsible)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReviewsByAuthorAndTender indicates an expected call of GetReviewsByAuthorAndTender.
-func (mr *MockRepositoryMockRecorder) GetReviewsByAuthorAndTender(arg0, arg1, arg2 interface{}) *gomock.Call {
+// SetResponsible indicates an expected call of SetResponsible.
+func (mr *MockRepositoryMockRecorder) SetResponsible(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByAuthorAndTender", reflect.TypeOf((*MockRepository)(nil).GetReviewsByAuthorAndTender), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetResponsible", reflect.TypeOf((*MockRepository)(nil).SetResponsible), arg0, arg1, arg2, arg3)
 }
 
-// GetTenderIDByBidID mocks base method.
-func (m *MockRepository) GetTenderIDByBidID(arg0 int) (int, error) {
+// SetTenderCriteria mocks base method.
+func (m *MockRepository) SetTenderCriteria(arg0 int, arg1 []models.Criterion, arg2 string) ([]models.Criterion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTenderIDByBidID", arg0)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "SetTenderCriteria", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Criterion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTenderIDByBidID indicates an expected call of GetTenderIDByBidID.
-func (mr *MockRepositoryMockRecorder) GetTenderIDByBidID(arg0 interface{}) *gomock.Call {
+// SetTenderCriteria indicates an expected call of SetTenderCriteria.
+func (mr *MockRepositoryMockRecorder) SetTenderCriteria(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTenderIDByBidID", reflect.TypeOf((*MockRepository)(nil).GetTenderIDByBidID), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderCriteria", reflect.TypeOf((*MockRepository)(nil).SetTenderCriteria), arg0, arg1, arg2)
 }
 
-// GetTendersByUser mocks base method.
-func (m *MockRepository) GetTendersByUser(arg0 string) ([]models.Tender, error) {
+// SetTenderDecisionPolicy mocks base method.
+func (m *MockRepository) SetTenderDecisionPolicy(arg0 int, arg1 models.DecisionPolicy, arg2 string, arg3 int) (models.Tender, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTendersByUser", arg0)
-	ret0, _ := ret[0].([]models.Tender)
+	ret := m.ctrl.Call(m, "SetTenderDecisionPolicy", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTendersByUser indicates an expected call of GetTendersByUser.
-func (mr *MockRepositoryMockRecorder) GetTendersByUser(arg0 interface{}) *gomock.Call {
+// SetTenderDecisionPolicy indicates an expected call of SetTenderDecisionPolicy.
+func (mr *MockRepositoryMockRecorder) SetTenderDecisionPolicy(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTendersByUser", reflect.TypeOf((*MockRepository)(nil).GetTendersByUser), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderDecisionPolicy", reflect.TypeOf((*MockRepository)(nil).SetTenderDecisionPolicy), arg0, arg1, arg2, arg3)
 }
 
-// RollbackBid mocks base method.
-func (m *MockRepository) RollbackBid(arg0, arg1 int) (models.Bid, error) {
+// SetTenderStatus mocks base method.
+func (m *MockRepository) SetTenderStatus(arg0 int, arg1, arg2 string, arg3 int) (models.Tender, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollbackBid", arg0, arg1)
-	ret0, _ := ret[0].(models.Bid)
+	ret := m.ctrl.Call(m, "SetTenderStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.Tender)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RollbackBid indicates an expected call of RollbackBid.
-func (mr *MockRepositoryMockRecorder) RollbackBid(arg0, arg1 interface{}) *gomock.Call {
+// SetTenderStatus indicates an expected call of SetTenderStatus.
+func (mr *MockRepositoryMockRecorder) SetTenderStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackBid", reflect.TypeOf((*MockRepository)(nil).RollbackBid), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderStatus", reflect.TypeOf((*MockRepository)(nil).SetTenderStatus), arg0, arg1, arg2, arg3)
 }
 
-// RollbackTender mocks base method.
-func (m *MockRepository) RollbackTender(arg0, arg1 int) (models.Tender, error) {
+// SetTenderVisibility mocks base method.
+func (m *MockRepository) SetTenderVisibility(arg0 int, arg1 models.TenderVisibility, arg2 string, arg3 int) (models.Tender, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RollbackTender", arg0, arg1)
+	ret := m.ctrl.Call(m, "SetTenderVisibility", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(models.Tender)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RollbackTender indicates an expected call of RollbackTender.
-func (mr *MockRepositoryMockRecorder) RollbackTender(arg0, arg1 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTender", reflect.TypeOf((*MockRepository)(nil).RollbackTender), arg0, arg1)
-}
-
-// SetBidStatus mocks base method.
-func (m *MockRepository) SetBidStatus(arg0 int, arg1 string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetBidStatus", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// SetBidStatus indicates an expected call of SetBidStatus.
-func (mr *MockRepositoryMockRecorder) SetBidStatus(arg0, arg1 interface{}) *gomock.Call {
+// SetTenderVisibility indicates an expected call of SetTenderVisibility.
+func (mr *MockRepositoryMockRecorder) SetTenderVisibility(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBidStatus", reflect.TypeOf((*MockRepository)(nil).SetBidStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderVisibility", reflect.TypeOf((*MockRepository)(nil).SetTenderVisibility), arg0, arg1, arg2, arg3)
 }
 
-// SetTenderStatus mocks base method.
-func (m *MockRepository) SetTenderStatus(arg0 int, arg1 string) error {
+// SubmitAuctionPrice mocks base method.
+func (m *MockRepository) SubmitAuctionPrice(arg0 int, arg1 models.Money, arg2 string) (models.Auction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SetTenderStatus", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SubmitAuctionPrice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.Auction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// SetTenderStatus indicates an expected call of SetTenderStatus.
-func (mr *MockRepositoryMockRecorder) SetTenderStatus(arg0, arg1 interface{}) *gomock.Call {
+// SubmitAuctionPrice indicates an expected call of SubmitAuctionPrice.
+func (mr *MockRepositoryMockRecorder) SubmitAuctionPrice(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTenderStatus", reflect.TypeOf((*MockRepository)(nil).SetTenderStatus), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAuctionPrice", reflect.TypeOf((*MockRepository)(nil).SubmitAuctionPrice), arg0, arg1, arg2)
 }
 
 // SubmitDecision mocks base method.
-func (m *MockRepository) SubmitDecision(arg0 int, arg1 string) error {
+func (m *MockRepository) SubmitDecision(arg0 int, arg1 string) (models.Bid, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SubmitDecision", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(models.Bid)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SubmitDecision indicates an expected call of SubmitDecision.